@@ -0,0 +1,78 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/salivare/subscriptions-service/internal/config"
+)
+
+// databaseURLs returns the DSN of the maintenance database, the DSN for golang-migrate
+// and the name of the target database.
+func databaseURLs(pg config.PostgresConfig) (admin, target, dbName string, err error) {
+	dsn, err := url.Parse(pg.DSN())
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to parse database URL: %w", err)
+	}
+
+	dbName = strings.TrimPrefix(dsn.Path, "/")
+	if dbName == "" {
+		return "", "", "", fmt.Errorf("database URL has no database name")
+	}
+
+	adminDSN := *dsn
+	adminDSN.Path = "/postgres"
+
+	targetDSN := *dsn
+	query := targetDSN.Query()
+	query.Set("x-migrations-table", pg.MigrationsTable)
+	targetDSN.RawQuery = query.Encode()
+
+	return adminDSN.String(), targetDSN.String(), dbName, nil
+}
+
+// ensureDatabase creates dbName if it does not exist yet.
+func ensureDatabase(adminDSN, dbName string, dryRun bool) error {
+	db, err := sql.Open("postgres", adminDSN)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var exists bool
+	err = db.QueryRow(
+		"SELECT EXISTS(SELECT 1 FROM pg_database WHERE datname = $1)",
+		dbName,
+	).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return nil
+	}
+
+	stmt := createDatabaseSQL(dbName)
+
+	if dryRun {
+		fmt.Printf("would run: %s\n", stmt)
+		return nil
+	}
+
+	if _, err := db.Exec(stmt); err != nil {
+		return err
+	}
+
+	fmt.Printf("created database %s\n", pq.QuoteIdentifier(dbName))
+
+	return nil
+}
+
+// createDatabaseSQL quotes the name, since CREATE DATABASE does not accept parameters.
+func createDatabaseSQL(dbName string) string {
+	return "CREATE DATABASE " + pq.QuoteIdentifier(dbName)
+}
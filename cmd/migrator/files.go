@@ -0,0 +1,150 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4/source"
+)
+
+// migrationFile is one version in the migrations directory.
+type migrationFile struct {
+	Version uint
+	Name    string
+	HasUp   bool
+	HasDown bool
+}
+
+// listMigrations reads the migrations directory, sorted by version.
+func listMigrations(dir string) ([]migrationFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint]*migrationFile)
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		m, err := source.Parse(e.Name())
+		if err != nil {
+			continue
+		}
+
+		f, ok := byVersion[m.Version]
+		if !ok {
+			f = &migrationFile{Version: m.Version, Name: m.Identifier}
+			byVersion[m.Version] = f
+		}
+
+		switch m.Direction {
+		case source.Up:
+			f.HasUp = true
+		case source.Down:
+			f.HasDown = true
+		}
+	}
+
+	files := make([]migrationFile, 0, len(byVersion))
+	for _, f := range byVersion {
+		files = append(files, *f)
+	}
+
+	slices.SortFunc(files, func(a, b migrationFile) int { return cmp.Compare(a.Version, b.Version) })
+
+	return files, nil
+}
+
+// plan returns the migrations that moving from current to target would run, in order.
+// current 0 means nothing is applied; target 0 means everything is rolled back.
+func plan(files []migrationFile, current, target uint) []migrationFile {
+	var steps []migrationFile
+
+	if target >= current {
+		for _, f := range files {
+			if f.Version > current && f.Version <= target {
+				steps = append(steps, f)
+			}
+		}
+
+		return steps
+	}
+
+	for i := len(files) - 1; i >= 0; i-- {
+		if files[i].Version <= current && files[i].Version > target {
+			steps = append(steps, files[i])
+		}
+	}
+
+	return steps
+}
+
+// targetAfterSteps returns the version reached after n steps up (n > 0) or down (n < 0).
+func targetAfterSteps(files []migrationFile, current uint, n int) (uint, error) {
+	idx := -1
+	for i, f := range files {
+		if f.Version == current {
+			idx = i
+		}
+	}
+
+	if current != 0 && idx == -1 {
+		return 0, fmt.Errorf("current version %d has no migration file", current)
+	}
+
+	target := idx + n
+	if target < -1 || target >= len(files) {
+		return 0, fmt.Errorf("cannot move %d steps from version %d: out of range", n, current)
+	}
+
+	if target == -1 {
+		return 0, nil
+	}
+
+	return files[target].Version, nil
+}
+
+var unsafeNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// createMigration writes empty up and down files for the next version and returns their paths.
+func createMigration(dir, name string, files []migrationFile, dryRun bool) ([]string, error) {
+	name = strings.Trim(unsafeNameChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return nil, fmt.Errorf("migration name must contain letters or digits")
+	}
+
+	var version uint = 1
+	if len(files) > 0 {
+		version = files[len(files)-1].Version + 1
+	}
+
+	paths := []string{
+		filepath.Join(dir, fmt.Sprintf("%d_%s.up.sql", version, name)),
+		filepath.Join(dir, fmt.Sprintf("%d_%s.down.sql", version, name)),
+	}
+
+	if dryRun {
+		return paths, nil
+	}
+
+	for _, p := range paths {
+		f, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := f.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func versions(files []migrationFile) []uint {
+	var vs []uint
+	for _, f := range files {
+		vs = append(vs, f.Version)
+	}
+	return vs
+}
+
+func TestListMigrations(t *testing.T) {
+	files, err := listMigrations("../../migrations")
+	require.NoError(t, err)
+
+	require.GreaterOrEqual(t, len(files), 2)
+	assert.Equal(t, migrationFile{Version: 1, Name: "init", HasUp: true, HasDown: true}, files[0])
+	assert.Equal(t, "create_subscriptions_unique", files[1].Name)
+
+	for i, f := range files {
+		assert.Equal(t, uint(i+1), f.Version, "versions are consecutive")
+		assert.True(t, f.HasUp && f.HasDown, "%d_%s has both directions", f.Version, f.Name)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	files := []migrationFile{{Version: 1}, {Version: 2}, {Version: 3}, {Version: 4}}
+
+	assert.Equal(t, []uint{1, 2, 3, 4}, versions(plan(files, 0, 4)))
+	assert.Equal(t, []uint{3, 4}, versions(plan(files, 2, 4)))
+	assert.Equal(t, []uint{4, 3}, versions(plan(files, 4, 2)))
+	assert.Equal(t, []uint{2, 1}, versions(plan(files, 2, 0)))
+	assert.Empty(t, plan(files, 3, 3))
+}
+
+func TestTargetAfterSteps(t *testing.T) {
+	files := []migrationFile{{Version: 1}, {Version: 2}, {Version: 5}}
+
+	tests := []struct {
+		name    string
+		current uint
+		n       int
+		want    uint
+		wantErr bool
+	}{
+		{name: "up from empty", current: 0, n: 1, want: 1},
+		{name: "up skips gaps", current: 2, n: 1, want: 5},
+		{name: "down to empty", current: 2, n: -2, want: 0},
+		{name: "up past the last", current: 5, n: 1, wantErr: true},
+		{name: "down past the first", current: 1, n: -2, wantErr: true},
+		{name: "unknown current version", current: 3, n: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				got, err := targetAfterSteps(files, tt.current, tt.n)
+				if tt.wantErr {
+					assert.Error(t, err)
+					return
+				}
+
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			},
+		)
+	}
+}
+
+func TestCreateMigration(t *testing.T) {
+	dir := t.TempDir()
+	files := []migrationFile{{Version: 1}, {Version: 2}}
+
+	paths, err := createMigration(dir, "Add Trial Period!", files, false)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t, []string{
+			filepath.Join(dir, "3_add_trial_period.up.sql"),
+			filepath.Join(dir, "3_add_trial_period.down.sql"),
+		}, paths,
+	)
+
+	for _, p := range paths {
+		assert.FileExists(t, p)
+	}
+
+	_, err = createMigration(dir, "add trial period", files, false)
+	assert.ErrorIs(t, err, os.ErrExist)
+
+	_, err = createMigration(dir, "!!!", files, false)
+	assert.Error(t, err)
+}
+
+func TestCreateDatabaseSQL(t *testing.T) {
+	assert.Equal(t, `CREATE DATABASE "subscriptions"`, createDatabaseSQL("subscriptions"))
+	assert.Equal(t, `CREATE DATABASE "x""; DROP DATABASE postgres; --"`, createDatabaseSQL(`x"; DROP DATABASE postgres; --`))
+}
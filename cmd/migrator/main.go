@@ -1,11 +1,14 @@
 package main
 
 import (
-	"database/sql"
+	"bufio"
 	"errors"
+	"flag"
 	"fmt"
-
-	_ "github.com/lib/pq"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -15,77 +18,375 @@ import (
 	"github.com/salivare/subscriptions-service/internal/storage"
 )
 
-func ensureDatabase(adminDSN, dbName string) error {
-	db, err := sql.Open("postgres", adminDSN)
-	if err != nil {
-		return err
+const envProd = "prod"
+
+const usage = `Usage: migrator [flags] <command> [args]
+
+Commands:
+  up [N]        apply all or the next N pending migrations (default command)
+  down [N]      roll back all or the last N applied migrations
+  goto V        migrate up or down to version V
+  force V       set the version to V and clear the dirty flag without running migrations
+  version       print the current version
+  status        list applied and pending migrations
+  create NAME   create empty up/down files for the next version
+
+Flags:
+`
+
+var (
+	dryRun  = flag.Bool("dry-run", false, "print what would be done without changing anything")
+	confirm = flag.Bool("yes", false, "skip the confirmation prompt for rolling back in prod")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
 	}
-	defer db.Close()
 
-	var exists bool
-	err = db.QueryRow(
-		"SELECT EXISTS(SELECT 1 FROM pg_database WHERE datname = $1)",
-		dbName,
-	).Scan(&exists)
+	// config.Path parses the command line, including the flags above.
+	cfg, err := config.Load(config.Path())
 	if err != nil {
-		return err
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 
-	if !exists {
-		_, err = db.Exec("CREATE DATABASE " + dbName)
-		if err != nil {
-			return err
-		}
+	if err := run(cfg, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "migrator:", err)
+		os.Exit(1)
 	}
-
-	return nil
 }
 
-func main() {
-	cfg := config.MustLoad()
+func run(cfg *config.Config, args []string) error {
+	command := "up"
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
 	pg := cfg.Postgres
 
-	baseDSN := fmt.Sprintf(
-		"postgres://%s:%s@%s:%d",
-		pg.User,
-		pg.Password,
-		pg.Host,
-		pg.Port,
-	)
+	files, err := listMigrations(pg.MigrationsPath)
+	if err != nil {
+		return fmt.Errorf("failed to read migrations: %w", err)
+	}
 
-	adminDSN := baseDSN + "/postgres?sslmode=" + pg.SSLMode
+	if command == "create" {
+		if len(args) != 1 {
+			return errors.New("create: expected NAME")
+		}
 
-	if err := ensureDatabase(adminDSN, pg.DBName); err != nil {
-		panic(fmt.Errorf("failed to ensure database: %w", err))
-	}
+		paths, err := createMigration(pg.MigrationsPath, args[0], files, *dryRun)
+		if err != nil {
+			return fmt.Errorf("create: %w", err)
+		}
 
-	finalDSN := fmt.Sprintf(
-		"%s/%s?sslmode=%s&x-migrations-table=%s",
-		baseDSN,
-		pg.DBName,
-		pg.SSLMode,
-		pg.MigrationsTable,
-	)
+		for _, p := range paths {
+			if *dryRun {
+				fmt.Printf("would create %s\n", p)
+			} else {
+				fmt.Printf("created %s\n", p)
+			}
+		}
 
-	m, err := migrate.New("file://"+pg.MigrationsPath, finalDSN)
+		return nil
+	}
+
+	adminDSN, targetDSN, dbName, err := databaseURLs(pg)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
+	if command == "up" || command == "goto" {
+		err := storage.RetryBackoff(
+			pg.Retry, func() error {
+				return ensureDatabase(adminDSN, dbName, *dryRun)
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to ensure database: %w", err)
+		}
+	}
+
+	var m *migrate.Migrate
+
 	err = storage.RetryBackoff(
 		pg.Retry, func() error {
-			err := m.Up()
-			if errors.Is(err, migrate.ErrNoChange) {
-				fmt.Println("no migrations to apply")
-				return nil
-			}
+			m, err = migrate.New("file://"+pg.MigrationsPath, targetDSN)
 			return err
 		},
 	)
+	if err != nil {
+		return fmt.Errorf("failed to init migrations: %w", err)
+	}
+	defer m.Close()
+
+	m.Log = stdoutLogger{}
 
+	current, dirty, err := currentVersion(m)
 	if err != nil {
-		panic(err)
+		return err
+	}
+
+	mg := &migrator{
+		m:       m,
+		files:   files,
+		current: current,
+		dirty:   dirty,
+		env:     cfg.Env,
+		dbName:  dbName,
+	}
+
+	switch command {
+	case "up":
+		return mg.up(args)
+	case "down":
+		return mg.down(args)
+	case "goto":
+		return mg.gotoVersion(args)
+	case "force":
+		return mg.force(args)
+	case "version":
+		return mg.version()
+	case "status":
+		return mg.status()
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+type migrator struct {
+	m       *migrate.Migrate
+	files   []migrationFile
+	current uint
+	dirty   bool
+	env     string
+	dbName  string
+}
+
+func (mg *migrator) up(args []string) error {
+	n, err := optionalCount(args)
+	if err != nil {
+		return fmt.Errorf("up: %w", err)
+	}
+
+	target := uint(0)
+	if len(mg.files) > 0 {
+		target = mg.files[len(mg.files)-1].Version
+	}
+
+	if n > 0 {
+		if target, err = targetAfterSteps(mg.files, mg.current, n); err != nil {
+			return fmt.Errorf("up: %w", err)
+		}
+	}
+
+	return mg.migrateTo(target, func() error {
+		if n > 0 {
+			return mg.m.Steps(n)
+		}
+		return mg.m.Up()
+	})
+}
+
+func (mg *migrator) down(args []string) error {
+	n, err := optionalCount(args)
+	if err != nil {
+		return fmt.Errorf("down: %w", err)
+	}
+
+	target := uint(0)
+	if n > 0 {
+		if target, err = targetAfterSteps(mg.files, mg.current, -n); err != nil {
+			return fmt.Errorf("down: %w", err)
+		}
+	}
+
+	return mg.migrateTo(target, func() error {
+		if n > 0 {
+			return mg.m.Steps(-n)
+		}
+		return mg.m.Down()
+	})
+}
+
+func (mg *migrator) gotoVersion(args []string) error {
+	if len(args) != 1 {
+		return errors.New("goto: expected V")
+	}
+
+	target, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("goto: invalid version %q", args[0])
+	}
+
+	return mg.migrateTo(uint(target), func() error {
+		return mg.m.Migrate(uint(target))
+	})
+}
+
+// migrateTo prints the plan and, unless in dry-run mode, runs apply.
+// Rolling back in prod needs confirmation.
+func (mg *migrator) migrateTo(target uint, apply func() error) error {
+	if mg.dirty {
+		return fmt.Errorf("database is dirty at version %d, fix it and run force", mg.current)
+	}
+
+	steps := plan(mg.files, mg.current, target)
+	if len(steps) == 0 {
+		fmt.Println("no migrations to apply")
+		return nil
+	}
+
+	rollback := target < mg.current
+	direction := "up"
+	if rollback {
+		direction = "down"
+	}
+
+	for _, s := range steps {
+		prefix := "will apply"
+		if *dryRun {
+			prefix = "would apply"
+		}
+		fmt.Printf("%s %d_%s.%s.sql\n", prefix, s.Version, s.Name, direction)
+	}
+
+	if *dryRun {
+		return nil
+	}
+
+	if rollback && mg.env == envProd && !*confirm {
+		if err := confirmRollback(mg.dbName); err != nil {
+			return err
+		}
+	}
+
+	if err := apply(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
 	}
 
 	fmt.Println("applied migrations")
+
+	return nil
+}
+
+func (mg *migrator) force(args []string) error {
+	if len(args) != 1 {
+		return errors.New("force: expected V")
+	}
+
+	// -1 is golang-migrate's "no version".
+	v, err := strconv.Atoi(args[0])
+	if err != nil || v < -1 {
+		return fmt.Errorf("force: invalid version %q", args[0])
+	}
+
+	if *dryRun {
+		fmt.Printf("would force version %d\n", v)
+		return nil
+	}
+
+	if err := mg.m.Force(v); err != nil {
+		return fmt.Errorf("force: %w", err)
+	}
+
+	fmt.Printf("forced version %d\n", v)
+
+	return nil
+}
+
+func (mg *migrator) version() error {
+	if mg.current == 0 {
+		fmt.Println("no migrations applied")
+		return nil
+	}
+
+	if mg.dirty {
+		fmt.Printf("%d (dirty)\n", mg.current)
+		return nil
+	}
+
+	fmt.Println(mg.current)
+
+	return nil
+}
+
+func (mg *migrator) status() error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE")
+
+	for _, f := range mg.files {
+		state := "pending"
+
+		switch {
+		case f.Version == mg.current && mg.dirty:
+			state = "dirty"
+		case f.Version <= mg.current:
+			state = "applied"
+		}
+
+		if !f.HasDown {
+			state += " (no down)"
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\n", f.Version, f.Name, state)
+	}
+
+	return w.Flush()
+}
+
+func currentVersion(m *migrate.Migrate) (uint, bool, error) {
+	v, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read version: %w", err)
+	}
+
+	return v, dirty, nil
+}
+
+func optionalCount(args []string) (int, error) {
+	switch len(args) {
+	case 0:
+		return 0, nil
+	case 1:
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return 0, fmt.Errorf("invalid count %q", args[0])
+		}
+		return n, nil
+	default:
+		return 0, errors.New("expected at most one argument")
+	}
+}
+
+// confirmRollback asks the operator to type the database name.
+func confirmRollback(dbName string) error {
+	fmt.Printf("env is %s: type the database name (%s) to roll back: ", envProd, dbName)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return errors.New("rollback not confirmed")
+	}
+
+	if strings.TrimSpace(answer) != dbName {
+		return errors.New("rollback not confirmed")
+	}
+
+	return nil
+}
+
+type stdoutLogger struct{}
+
+func (stdoutLogger) Printf(format string, v ...any) {
+	fmt.Printf(format, v...)
+}
+
+func (stdoutLogger) Verbose() bool {
+	return false
 }
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+)
+
+// service is a subscription product with its monthly price tiers in rubles.
+type service struct {
+	name   string
+	prices []int64
+}
+
+// catalog is weighted towards popular services by listing them first;
+// pickService favours the head of the list.
+var catalog = []service{
+	{"Yandex Plus", []int64{299, 399, 499}},
+	{"Netflix", []int64{599, 799, 999}},
+	{"Spotify", []int64{169, 229, 299}},
+	{"Kinopoisk", []int64{299, 399}},
+	{"VK Music", []int64{149, 199}},
+	{"YouTube Premium", []int64{299, 399}},
+	{"Okko", []int64{199, 399, 699}},
+	{"ivi", []int64{199, 399}},
+	{"Apple Music", []int64{169, 269}},
+	{"iCloud+", []int64{59, 149, 599}},
+	{"Google One", []int64{139, 349, 699}},
+	{"ChatGPT Plus", []int64{1990}},
+	{"GitHub Copilot", []int64{990, 1890}},
+	{"Microsoft 365", []int64{379, 549}},
+	{"Adobe Creative Cloud", []int64{1990, 4990}},
+	{"Notion", []int64{790, 1490}},
+	{"Figma", []int64{1190, 4490}},
+	{"Discord Nitro", []int64{299, 899}},
+	{"PlayStation Plus", []int64{649, 999, 1299}},
+	{"Xbox Game Pass", []int64{599, 1099}},
+	{"Duolingo Super", []int64{499}},
+	{"Amediateka", []int64{599}},
+	{"Start", []int64{299, 499}},
+	{"Dropbox", []int64{990, 1790}},
+}
+
+// maxAttempts bounds retries when a generated row hits the unique (user, service, start) index.
+const maxAttempts = 20
+
+type periodKey struct {
+	userID  uuid.UUID
+	service string
+}
+
+type uniqueKey struct {
+	userID  uuid.UUID
+	service string
+	start   time.Time
+}
+
+// generator produces the same sequence of subscriptions for the same seed and parameters.
+type generator struct {
+	faker  *gofakeit.Faker
+	users  []uuid.UUID
+	from   time.Time
+	months int
+	seen   map[uniqueKey]struct{}
+	// periods holds the rows checked by the no-overlap rule, per user and service.
+	periods map[periodKey][]models.Subscription
+}
+
+// newGenerator creates users up front; start dates fall between from and to inclusive.
+func newGenerator(seed uint64, users int, from, to time.Time) *generator {
+	faker := gofakeit.New(seed)
+
+	ids := make([]uuid.UUID, users)
+	for i := range ids {
+		ids[i] = uuid.MustParse(faker.UUID())
+	}
+
+	return &generator{
+		faker:   faker,
+		users:   ids,
+		from:    from,
+		months:  monthsBetween(from, to) + 1,
+		seen:    make(map[uniqueKey]struct{}),
+		periods: make(map[periodKey][]models.Subscription),
+	}
+}
+
+// next returns a subscription that does not collide with earlier ones,
+// or false when the parameters leave no room for another unique row.
+// A row whose period overlaps an earlier one of the same user and service is
+// kept as a legitimate duplicate with AllowOverlap set.
+func (g *generator) next() (models.Subscription, bool) {
+	for range maxAttempts {
+		sub := g.random()
+
+		key := uniqueKey{userID: sub.UserID, service: sub.ServiceName, start: sub.StartDate}
+		if _, ok := g.seen[key]; ok {
+			continue
+		}
+
+		g.seen[key] = struct{}{}
+
+		pk := periodKey{userID: sub.UserID, service: sub.ServiceName}
+		for _, other := range g.periods[pk] {
+			if other.Overlaps(sub) {
+				sub.AllowOverlap = true
+				break
+			}
+		}
+
+		if !sub.AllowOverlap {
+			g.periods[pk] = append(g.periods[pk], sub)
+		}
+
+		return sub, true
+	}
+
+	return models.Subscription{}, false
+}
+
+func (g *generator) random() models.Subscription {
+	// Squaring skews towards the first users, so some users hold many subscriptions.
+	r := g.faker.Float64()
+	userID := g.users[int(r*r*float64(len(g.users)))]
+
+	name, price := g.pickService()
+
+	start := g.from.AddDate(0, g.faker.IntN(g.months), 0)
+
+	// A third of subscriptions are still active; the rest last from a month to two years.
+	var end *time.Time
+	if g.faker.IntN(3) > 0 {
+		e := start.AddDate(0, g.faker.IntRange(1, 24), 0)
+		end = &e
+	}
+
+	return models.Subscription{
+		ServiceName: name,
+		Price:       &price,
+		UserID:      userID,
+		StartDate:   start,
+		EndDate:     end,
+	}
+}
+
+func (g *generator) pickService() (string, int64) {
+	// One in ten is a niche app outside the catalog.
+	if g.faker.IntN(10) == 0 {
+		return g.faker.AppName(), int64(g.faker.IntRange(99, 1999))
+	}
+
+	r := g.faker.Float64()
+	s := catalog[int(r*r*float64(len(catalog)))]
+
+	return s.name, s.prices[g.faker.IntN(len(s.prices))]
+}
+
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
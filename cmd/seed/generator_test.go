@@ -0,0 +1,93 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+)
+
+var (
+	jan2022 = time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	dec2025 = time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func generate(g *generator, n int) []models.Subscription {
+	var subs []models.Subscription
+	for range n {
+		sub, ok := g.next()
+		if !ok {
+			break
+		}
+		subs = append(subs, sub)
+	}
+	return subs
+}
+
+func TestGenerator_Deterministic(t *testing.T) {
+	a := generate(newGenerator(42, 10, jan2022, dec2025), 200)
+	b := generate(newGenerator(42, 10, jan2022, dec2025), 200)
+	c := generate(newGenerator(43, 10, jan2022, dec2025), 200)
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
+
+func TestGenerator_RespectsUniqueIndexAndRange(t *testing.T) {
+	subs := generate(newGenerator(1, 20, jan2022, dec2025), 2000)
+	require.Len(t, subs, 2000)
+
+	seen := make(map[uniqueKey]struct{})
+	openEnded := 0
+
+	for _, sub := range subs {
+		key := uniqueKey{userID: sub.UserID, service: sub.ServiceName, start: sub.StartDate}
+		assert.NotContains(t, seen, key)
+		seen[key] = struct{}{}
+
+		assert.False(t, sub.StartDate.Before(jan2022))
+		assert.False(t, sub.StartDate.After(dec2025))
+		assert.Positive(t, *sub.Price)
+
+		if sub.EndDate == nil {
+			openEnded++
+		} else {
+			assert.True(t, sub.EndDate.After(sub.StartDate))
+		}
+	}
+
+	assert.Positive(t, openEnded)
+}
+
+func TestGenerator_FlagsOverlappingRows(t *testing.T) {
+	subs := generate(newGenerator(1, 20, jan2022, dec2025), 2000)
+
+	checked := make(map[periodKey][]models.Subscription)
+	flagged := 0
+
+	for _, sub := range subs {
+		if sub.AllowOverlap {
+			flagged++
+			continue
+		}
+
+		key := periodKey{userID: sub.UserID, service: sub.ServiceName}
+		for _, other := range checked[key] {
+			assert.False(t, other.Overlaps(sub), "unflagged rows must not overlap")
+		}
+		checked[key] = append(checked[key], sub)
+	}
+
+	assert.Positive(t, flagged)
+	assert.Less(t, flagged, len(subs))
+}
+
+func TestGenerator_StopsWhenNoUniqueRowsLeft(t *testing.T) {
+	// One user and one month leave room for at most one row per service.
+	subs := generate(newGenerator(1, 1, jan2022, jan2022), 10000)
+
+	assert.Less(t, len(subs), 10000)
+}
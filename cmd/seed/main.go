@@ -0,0 +1,223 @@
+// Command seed fills a database with realistic fake subscriptions.
+// The same -seed, -users, -subscriptions, -from and -to always produce the same rows.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/salivare-io/slogx"
+
+	"github.com/salivare/subscriptions-service/internal/app"
+	"github.com/salivare/subscriptions-service/internal/config"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/format"
+	"github.com/salivare/subscriptions-service/internal/tenant"
+)
+
+const (
+	targetAPI     = "api"
+	targetStorage = "storage"
+)
+
+var (
+	target        = flag.String("target", targetStorage, "where to write: storage (the backend from the config) or api")
+	baseURL       = flag.String("url", "http://localhost:8082", "base URL of the service for -target=api")
+	users         = flag.Int("users", 100, "number of users")
+	subscriptions = flag.Int("subscriptions", 1000, "number of subscriptions")
+	seed          = flag.Uint64("seed", 1, "random seed; 0 picks a random one")
+	from          = flag.String("from", "01-2022", "earliest start month, MM-YYYY")
+	to            = flag.String("to", "12-2025", "latest start month, MM-YYYY")
+	workers       = flag.Int("workers", 8, "concurrent writers")
+	dryRun        = flag.Bool("dry-run", false, "print the rows as JSON lines instead of writing them")
+	tenantID      = flag.String("tenant", "", "tenant to seed, sent as "+tenant.Header+" with -target=api; for -target=storage defaults to tenancy.default_tenant of the config")
+)
+
+func main() {
+	// config.Path parses the command line, including the flags above.
+	path := config.Path()
+
+	if err := run(path); err != nil {
+		fmt.Fprintln(os.Stderr, "seed:", err)
+		os.Exit(1)
+	}
+}
+
+func run(path string) error {
+	if *users < 1 || *subscriptions < 0 || *workers < 1 {
+		return errors.New("-users and -workers must be positive, -subscriptions must not be negative")
+	}
+
+	fromMonth, err := time.Parse(format.MonthYear, *from)
+	if err != nil {
+		return fmt.Errorf("invalid -from: %w", err)
+	}
+
+	toMonth, err := time.Parse(format.MonthYear, *to)
+	if err != nil {
+		return fmt.Errorf("invalid -to: %w", err)
+	}
+
+	if toMonth.Before(fromMonth) {
+		return errors.New("-to must not be before -from")
+	}
+
+	if *tenantID != "" {
+		if err := tenant.Validate(*tenantID); err != nil {
+			return fmt.Errorf("invalid -tenant: %w", err)
+		}
+	}
+
+	gen := newGenerator(*seed, *users, fromMonth, toMonth)
+
+	rows := make([]models.Subscription, 0, *subscriptions)
+	for range *subscriptions {
+		sub, ok := gen.next()
+		if !ok {
+			break
+		}
+		rows = append(rows, sub)
+	}
+
+	if len(rows) < *subscriptions {
+		fmt.Fprintf(
+			os.Stderr, "only %d unique subscriptions fit, widen -from/-to or add -users\n", len(rows),
+		)
+	}
+
+	if *dryRun {
+		enc := json.NewEncoder(os.Stdout)
+		for _, sub := range rows {
+			if err := enc.Encode(toCreateRequest(sub)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Storage logs every duplicate at warn level, which a repeated run is full of.
+	log := slogx.New(slogx.WithLevel(slog.LevelError))
+
+	ctx, stop := signal.NotifyContext(slogx.ToContext(context.Background(), log), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	s, closeSink, err := newSink(log, path)
+	if err != nil {
+		return err
+	}
+	defer closeSink()
+
+	return write(ctx, s, rows)
+}
+
+func newSink(log *slogx.Logger, path string) (sink, func(), error) {
+	switch *target {
+	case targetAPI:
+		return apiSink{client: &http.Client{Timeout: 10 * time.Second}, baseURL: *baseURL, tenantID: *tenantID}, func() {}, nil
+	case targetStorage:
+		cfg, err := config.Load(path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if cfg.Storage.Driver == config.DriverMemory {
+			return nil, nil, errors.New("the memory driver keeps nothing after seed exits, use -target=api")
+		}
+
+		// Postgres refuses writes without a tenant, whether tenancy is enabled or not.
+		id := *tenantID
+		if id == "" {
+			id = cfg.Tenancy.DefaultTenant
+		}
+		if id == "" {
+			id = tenant.Default
+		}
+
+		st, err := app.NewStorage(log, cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return storageSink{saver: st, tenantID: id}, st.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown -target %q, want %s or %s", *target, targetStorage, targetAPI)
+	}
+}
+
+func write(ctx context.Context, s sink, rows []models.Subscription) error {
+	var (
+		created, skipped atomic.Int64
+		firstErr         error
+		errOnce          sync.Once
+		wg               sync.WaitGroup
+	)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan models.Subscription)
+	started := time.Now()
+
+	for range *workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for sub := range jobs {
+				err := s.save(ctx, sub)
+
+				switch {
+				case err == nil:
+					created.Add(1)
+				case errors.Is(err, errDuplicate):
+					skipped.Add(1)
+				default:
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, sub := range rows {
+		select {
+		case jobs <- sub:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	elapsed := time.Since(started)
+
+	fmt.Printf(
+		"created %d, skipped %d existing, in %s (%.0f rows/s)\n",
+		created.Load(),
+		skipped.Load(),
+		elapsed.Round(time.Millisecond),
+		float64(created.Load()+skipped.Load())/elapsed.Seconds(),
+	)
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/format"
+	"github.com/salivare/subscriptions-service/internal/httpserver/request"
+	"github.com/salivare/subscriptions-service/internal/services/subscription"
+	"github.com/salivare/subscriptions-service/internal/storage"
+	"github.com/salivare/subscriptions-service/internal/tenant"
+)
+
+// errDuplicate is returned by sinks when the row already exists, e.g. on a repeated run,
+// or when its period overlaps a row that was seeded earlier.
+var errDuplicate = errors.New("subscription already exists")
+
+type sink interface {
+	save(ctx context.Context, sub models.Subscription) error
+}
+
+// storageSink writes straight to a storage backend, bypassing HTTP and so the
+// middleware that would otherwise resolve the tenant.
+type storageSink struct {
+	saver    subscription.Saver
+	tenantID string
+}
+
+func (s storageSink) save(ctx context.Context, sub models.Subscription) error {
+	if err := sub.Validate(); err != nil {
+		return err
+	}
+
+	_, _, err := s.saver.SaveSubscription(tenant.WithID(ctx, s.tenantID), sub)
+	if errors.Is(err, storage.ErrSubscriptionExists) || errors.Is(err, storage.ErrOverlap) {
+		return errDuplicate
+	}
+
+	return err
+}
+
+// apiSink posts to the create endpoint of a running service. Without tenantID the
+// service picks the tenant itself.
+type apiSink struct {
+	client   *http.Client
+	baseURL  string
+	tenantID string
+}
+
+func (s apiSink) save(ctx context.Context, sub models.Subscription) error {
+	body, err := json.Marshal(toCreateRequest(sub))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, strings.TrimRight(s.baseURL, "/")+"/api/v1/subscription", bytes.NewReader(body),
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.tenantID != "" {
+		req.Header.Set(tenant.Header, s.tenantID)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	case http.StatusConflict:
+		return errDuplicate
+	default:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+}
+
+func toCreateRequest(sub models.Subscription) request.CreateRequest {
+	req := request.CreateRequest{
+		ServiceName:  sub.ServiceName,
+		Price:        sub.Price,
+		UserID:       sub.UserID.String(),
+		StartDate:    sub.StartDate.Format(format.MonthYear),
+		AllowOverlap: sub.AllowOverlap,
+	}
+
+	if sub.EndDate != nil {
+		req.EndDate = sub.EndDate.Format(format.MonthYear)
+	}
+
+	return req
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/storage"
+	"github.com/salivare/subscriptions-service/internal/storage/memory"
+	"github.com/salivare/subscriptions-service/internal/tenant"
+)
+
+// tenantStorage refuses a context without a tenant like the postgres storage does,
+// and records the tenant of every write.
+type tenantStorage struct {
+	*memory.Storage
+	tenants map[string]int
+}
+
+func (s *tenantStorage) SaveSubscription(ctx context.Context, sub models.Subscription) (uuid.UUID, time.Time, error) {
+	id, ok := tenant.FromContext(ctx)
+	if !ok {
+		return uuid.Nil, time.Time{}, storage.ErrNoTenant
+	}
+
+	s.tenants[id]++
+
+	return s.Storage.SaveSubscription(ctx, sub)
+}
+
+func TestStorageSink_SetsTenant(t *testing.T) {
+	st := &tenantStorage{Storage: memory.New(), tenants: map[string]int{}}
+	rows := generate(newGenerator(1, 5, jan2022, dec2025), 20)
+
+	require.NoError(t, write(context.Background(), storageSink{saver: st, tenantID: "retail"}, rows))
+	assert.Equal(t, map[string]int{"retail": len(rows)}, st.tenants)
+
+	// A repeated run skips every row instead of failing.
+	require.NoError(t, write(context.Background(), storageSink{saver: st, tenantID: "retail"}, rows))
+}
+
+func TestAPISink_SendsTenant(t *testing.T) {
+	var got []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get(tenant.Header))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	sub := generate(newGenerator(1, 1, jan2022, dec2025), 1)[0]
+
+	require.NoError(t, apiSink{client: srv.Client(), baseURL: srv.URL}.save(context.Background(), sub))
+	require.NoError(t, apiSink{client: srv.Client(), baseURL: srv.URL, tenantID: "retail"}.save(context.Background(), sub))
+
+	assert.Equal(t, []string{"", "retail"}, got)
+}
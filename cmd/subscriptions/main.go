@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -18,8 +19,15 @@ const (
 )
 
 func main() {
-	cfg := config.MustLoad()
-	log := setupLogger(cfg.Env)
+	path := config.Path()
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	log := setupLogger(cfg)
 
 	application, err := app.New(log, cfg)
 	if err != nil {
@@ -29,34 +37,80 @@ func main() {
 
 	go application.HTTPSrv.MustRun()
 
+	if application.AdminSrv != nil {
+		go application.AdminSrv.MustRun()
+	}
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 
-	<-stop
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+
+wait:
+	for {
+		select {
+		case <-reload:
+			reloadConfig(log, application, path)
+		case <-stop:
+			break wait
+		}
+	}
 
 	log.Info("Shutting down...")
 
-	application.HTTPSrv.Stop()
+	application.Stop()
 
 	log.Info("Goodbye!")
 }
 
-func setupLogger(env string) *slogx.Logger {
-	var level slog.Level
+// reloadConfig re-reads the config on SIGHUP. An invalid file is rejected as a whole.
+func reloadConfig(log *slogx.Logger, application *app.App, path string) {
+	log.Info("Reloading configuration...", slog.String("path", path))
 
-	switch env {
+	cfg, err := config.Load(path)
+	if err != nil {
+		log.Error("failed to reload config, keeping the current one", slogx.Err(err))
+		return
+	}
+
+	if err := application.Reload(cfg); err != nil {
+		log.Error("failed to apply config, keeping the current one", slogx.Err(err))
+		return
+	}
+
+	log.SetLevel(logLevel(cfg))
+}
+
+func logLevel(cfg *config.Config) slog.Level {
+	switch cfg.LogLevel {
+	case "trace":
+		return slogx.LevelTrace
+	case "debug":
+		return slog.LevelDebug
+	case "info":
+		return slog.LevelInfo
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	}
+
+	switch cfg.Env {
 	case envLocal:
-		level = slogx.LevelTrace
+		return slogx.LevelTrace
 	case envDev:
-		level = slog.LevelDebug
+		return slog.LevelDebug
 	case envProd:
-		level = slog.LevelInfo
+		return slog.LevelInfo
 	default:
-		level = slog.LevelInfo
+		return slog.LevelInfo
 	}
+}
 
+func setupLogger(cfg *config.Config) *slogx.Logger {
 	return slogx.New(
-		slogx.WithLevel(level),
+		slogx.WithLevel(logLevel(cfg)),
 		slogx.WithContextKeys("trace_id", "request_id"),
 		slogx.WithRemoval(slogx.NewRemovalSet().Add("bearer_token")),
 	)
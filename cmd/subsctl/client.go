@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	savev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/save"
+	"github.com/salivare/subscriptions-service/internal/httpserver/request"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/tenant"
+)
+
+const apiPrefix = "/api/v1/subscription"
+
+// client calls the subscriptions API.
+type client struct {
+	http    *http.Client
+	baseURL string
+	token   string
+	tenant  string
+}
+
+// apiError is a problem returned by the service.
+type apiError struct {
+	problem response.Problem
+}
+
+func (e *apiError) Error() string {
+	var b strings.Builder
+
+	b.WriteString(strings.ToLower(e.problem.Title))
+
+	if e.problem.Detail != "" {
+		b.WriteString(": " + e.problem.Detail)
+	}
+
+	if e.problem.Code != "" {
+		b.WriteString(" [" + e.problem.Code + "]")
+	}
+
+	for _, f := range e.problem.Errors {
+		fmt.Fprintf(&b, "\n  --%s: %s", flagName(f.Field), f.Message)
+	}
+
+	if e.problem.RequestID != "" {
+		b.WriteString("\nrequest id: " + e.problem.RequestID)
+	}
+
+	return b.String()
+}
+
+func (c *client) create(ctx context.Context, req request.CreateRequest) (savev1.CreateResponse, error) {
+	var out savev1.CreateResponse
+	err := c.do(ctx, http.MethodPost, apiPrefix, req, &out)
+	return out, err
+}
+
+func (c *client) get(ctx context.Context, id string) (response.SubscriptionResponse, error) {
+	var out response.SubscriptionResponse
+	err := c.do(ctx, http.MethodGet, apiPrefix+"/"+url.PathEscape(id), nil, &out)
+	return out, err
+}
+
+func (c *client) update(ctx context.Context, id string, req request.UpdateRequest) (response.SubscriptionResponse, error) {
+	var out response.SubscriptionResponse
+	err := c.do(ctx, http.MethodPatch, apiPrefix+"/"+url.PathEscape(id), req, &out)
+	return out, err
+}
+
+func (c *client) delete(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, apiPrefix+"/"+url.PathEscape(id), nil, nil)
+}
+
+func (c *client) sum(ctx context.Context, req request.SumRequest) (response.SumResponse, error) {
+	var out response.SumResponse
+	err := c.do(ctx, http.MethodPost, apiPrefix+"/sum", req, &out)
+	return out, err
+}
+
+// do sends body as JSON and decodes the data of the response envelope into out.
+func (c *client) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(c.baseURL, "/")+path, reader)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.tenant != "" {
+		req.Header.Set(tenant.Header, c.tenant)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot reach %s: %w", c.baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeProblem(resp)
+	}
+
+	envelope := struct {
+		Status string          `json:"status"`
+		Data   json.RawMessage `json:"data"`
+	}{}
+
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("unexpected response from %s: %w", c.baseURL, err)
+	}
+
+	if out == nil || len(envelope.Data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(envelope.Data, out)
+}
+
+func decodeProblem(resp *http.Response) error {
+	var p response.Problem
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&p); err != nil || p.Title == "" {
+		p = response.Problem{Title: http.StatusText(resp.StatusCode), Status: resp.StatusCode}
+	}
+
+	if p.RequestID == "" {
+		p.RequestID = resp.Header.Get("X-Request-ID")
+	}
+
+	return &apiError{problem: p}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// bindRequest registers a flag for every JSON field of the request struct pointed to by req,
+// named after the JSON key in kebab case. Only flags given on the command line are set,
+// so an omitted flag leaves a pointer field nil and a PATCH leaves that field unchanged.
+func bindRequest(fs *flag.FlagSet, req any) {
+	v := reflect.ValueOf(req).Elem()
+	t := v.Type()
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		usage := tag
+		if strings.Contains(field.Tag.Get("validate"), "required") {
+			usage += " (required)"
+		}
+		if strings.Contains(tag, "date") {
+			usage += ", MM-YYYY"
+		}
+
+		fs.Var(&fieldValue{v: v.Field(i)}, flagName(tag), usage)
+	}
+}
+
+// flagName converts a JSON key to its flag name.
+func flagName(jsonKey string) string {
+	return strings.ReplaceAll(jsonKey, "_", "-")
+}
+
+// fieldValue sets a string, int64, bool or pointer-to-those struct field from a flag.
+type fieldValue struct {
+	v reflect.Value
+}
+
+func (f *fieldValue) String() string {
+	if !f.v.IsValid() {
+		return ""
+	}
+
+	v := f.v
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+func (f *fieldValue) Set(s string) error {
+	target := f.v
+	if target.Kind() == reflect.Pointer {
+		target.Set(reflect.New(target.Type().Elem()))
+		target = target.Elem()
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(s)
+	case reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a whole number", s)
+		}
+		target.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("%q is not true or false", s)
+		}
+		target.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field type %s", target.Type())
+	}
+
+	return nil
+}
+
+// IsBoolFlag lets a bool field be set by the bare flag, e.g. --allow-overlap.
+func (f *fieldValue) IsBoolFlag() bool {
+	t := f.v.Type()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Bool
+}
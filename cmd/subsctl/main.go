@@ -0,0 +1,186 @@
+// Command subsctl is a command-line client for the subscriptions API.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/salivare/subscriptions-service/internal/httpserver/request"
+	"github.com/salivare/subscriptions-service/internal/tenant"
+)
+
+const usage = `Usage: subsctl <command> [flags] [ID]
+
+Commands:
+  create   create a subscription
+  get      show a subscription
+  update   change the given fields of a subscription
+  delete   delete a subscription
+  sum      total price of subscriptions matching the filters
+
+Run "subsctl <command> -h" for the flags of a command.
+The target is taken from -url/-token/-tenant, SUBSCTL_URL/SUBSCTL_TOKEN/SUBSCTL_TENANT
+or a profile in the config file (-profile or SUBSCTL_PROFILE).
+`
+
+// errUsage marks errors caused by a wrong command line.
+var errUsage = errors.New("usage error")
+
+type globalOptions struct {
+	profile      string
+	profilesPath string
+	baseURL      string
+	token        string
+	tenant       string
+	output       string
+	timeout      time.Duration
+}
+
+func (o *globalOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.profile, "profile", "", "profile from the config file")
+	fs.StringVar(&o.profilesPath, "config", defaultProfilesPath(), "path to the subsctl config file with profiles")
+	fs.StringVar(&o.baseURL, "url", "", "base URL of the service, overrides the profile")
+	fs.StringVar(&o.token, "token", "", "bearer token, overrides the profile")
+	fs.StringVar(&o.tenant, "tenant", "", "tenant sent as "+tenant.Header+", overrides the profile")
+	fs.StringVar(&o.output, "o", outputTable, "output format: table, json or yaml")
+	fs.DurationVar(&o.timeout, "timeout", 10*time.Second, "request timeout")
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdout, os.Stderr)
+
+	switch {
+	case err == nil:
+	case errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	default:
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Fprint(stderr, usage)
+		return nil
+	}
+
+	name, args := args[0], args[1:]
+
+	var (
+		opts      globalOptions
+		createReq request.CreateRequest
+		updateReq request.UpdateRequest
+		sumReq    request.SumRequest
+	)
+
+	fs := flag.NewFlagSet("subsctl "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	opts.register(fs)
+
+	var positional string
+
+	switch name {
+	case "create":
+		bindRequest(fs, &createReq)
+	case "update":
+		bindRequest(fs, &updateReq)
+		positional = "ID"
+	case "sum":
+		bindRequest(fs, &sumReq)
+	case "get", "delete":
+		positional = "ID"
+	default:
+		fmt.Fprint(stderr, usage)
+		return fmt.Errorf("%w: unknown command %q", errUsage, name)
+	}
+
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: subsctl %s [flags] %s\n\nFlags:\n", name, positional)
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+
+	var id string
+	if positional != "" {
+		if len(rest) != 1 {
+			fs.Usage()
+			return fmt.Errorf("%w: %s expects exactly one %s", errUsage, name, positional)
+		}
+		id = rest[0]
+	} else if len(rest) > 0 {
+		return fmt.Errorf("%w: unexpected argument %q", errUsage, rest[0])
+	}
+
+	t, err := resolveTarget(&opts)
+	if err != nil {
+		return err
+	}
+
+	c := &client{
+		http:    &http.Client{Timeout: opts.timeout},
+		baseURL: t.baseURL,
+		token:   t.token,
+		tenant:  t.tenant,
+	}
+
+	ctx := context.Background()
+
+	var result any
+
+	switch name {
+	case "create":
+		result, err = c.create(ctx, createReq)
+	case "get":
+		result, err = c.get(ctx, id)
+	case "update":
+		result, err = c.update(ctx, id, updateReq)
+	case "delete":
+		if err = c.delete(ctx, id); err == nil {
+			fmt.Fprintf(stdout, "deleted %s\n", id)
+			return nil
+		}
+	case "sum":
+		result, err = c.sum(ctx, sumReq)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return render(stdout, opts.output, result)
+}
+
+// parseInterspersed parses flags that may come before or after positional
+// arguments, so "subsctl get ID -o json" works as well as "subsctl get -o json ID".
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var rest []string
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		if fs.NArg() == 0 {
+			return rest, nil
+		}
+
+		rest = append(rest, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/httpserver/request"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/tenant"
+)
+
+const testID = "6f1c2a4e-5b7d-4c3e-9a8f-1d2e3f4a5b6c"
+
+func TestBindRequest(t *testing.T) {
+	var req request.UpdateRequest
+
+	fs := flag.NewFlagSet("update", flag.ContinueOnError)
+	bindRequest(fs, &req)
+
+	require.NoError(t, fs.Parse([]string{"--price", "250", "--end-date", "12-2024", "--allow-overlap"}))
+
+	require.NotNil(t, req.Price)
+	assert.Equal(t, int64(250), *req.Price)
+	require.NotNil(t, req.EndDate)
+	assert.Equal(t, "12-2024", *req.EndDate)
+	require.NotNil(t, req.AllowOverlap)
+	assert.True(t, *req.AllowOverlap)
+	assert.Nil(t, req.ServiceName, "omitted flags must stay unset")
+
+	err := fs.Parse([]string{"--price", "abc"})
+	assert.ErrorContains(t, err, "not a whole number")
+}
+
+func TestRun(t *testing.T) {
+	var gotBody map[string]any
+	var gotAuth, gotTenant string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotTenant = r.Header.Get(tenant.Header)
+		gotBody = nil
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			_ = json.Unmarshal(data, &gotBody)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == apiPrefix+"/"+testID:
+			price := int64(400)
+			_ = json.NewEncoder(w).Encode(response.Response{Status: response.StatusOK, Data: response.SubscriptionResponse{
+				ID: uuid.MustParse(testID), ServiceName: "Netflix", Price: &price, UserID: uuid.MustParse(testID), StartDate: "01-2024",
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == apiPrefix+"/sum":
+			_ = json.NewEncoder(w).Encode(response.Response{Status: response.StatusOK, Data: response.SumResponse{Total: 1200}})
+		case r.Method == http.MethodPost && r.URL.Path == apiPrefix:
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(response.Problem{
+				Title: "Bad Request", Status: http.StatusBadRequest, Detail: "request validation failed",
+				Code: "VALIDATION_FAILED", RequestID: "req-1",
+				Errors: []response.FieldError{{Field: "service_name", Rule: "required", Message: "field service_name is a required field"}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv("SUBSCTL_PROFILE", "")
+	t.Setenv("SUBSCTL_URL", srv.URL)
+	t.Setenv("SUBSCTL_TOKEN", "")
+	t.Setenv("SUBSCTL_TENANT", "")
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantOut  string
+		wantErr  string
+		wantBody map[string]any
+	}{
+		{
+			name:    "get as table",
+			args:    []string{"get", testID},
+			wantOut: "Netflix  400",
+		},
+		{
+			name:    "get as json with flags after the id",
+			args:    []string{"get", testID, "-o", "json"},
+			wantOut: `"service_name": "Netflix"`,
+		},
+		{
+			name:    "sum as yaml",
+			args:    []string{"sum", "--user-id", testID, "-o", "yaml"},
+			wantOut: "total: 1200",
+			wantBody: map[string]any{
+				"user_id": testID, "service_name": nil,
+				"start_date_from": nil, "start_date_to": nil, "end_date_from": nil, "end_date_to": nil, "as_of": nil,
+			},
+		},
+		{
+			name:    "validation problem",
+			args:    []string{"create", "--price", "400"},
+			wantErr: "bad request: request validation failed [VALIDATION_FAILED]\n  --service-name: field service_name is a required field\nrequest id: req-1",
+		},
+		{
+			name:    "not found without problem body",
+			args:    []string{"delete", testID},
+			wantErr: "not found",
+		},
+		{
+			name:    "missing id",
+			args:    []string{"get"},
+			wantErr: "usage error: get expects exactly one ID",
+		},
+		{
+			name:    "unknown command",
+			args:    []string{"list"},
+			wantErr: `usage error: unknown command "list"`,
+		},
+		{
+			name:    "unknown output",
+			args:    []string{"get", testID, "-o", "xml"},
+			wantErr: `unknown output format "xml"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			err := run(append(tt.args, "-config", ""), &stdout, &stderr)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Contains(t, stdout.String(), tt.wantOut)
+
+			if tt.wantBody != nil {
+				assert.Equal(t, tt.wantBody, gotBody)
+			}
+		})
+	}
+
+	t.Run("token flag", func(t *testing.T) {
+		require.NoError(t, run([]string{"get", testID, "-token", "secret", "-config", ""}, io.Discard, io.Discard))
+		assert.Equal(t, "Bearer secret", gotAuth)
+	})
+
+	t.Run("tenant flag", func(t *testing.T) {
+		require.NoError(t, run([]string{"get", testID, "-config", ""}, io.Discard, io.Discard))
+		assert.Empty(t, gotTenant)
+
+		require.NoError(t, run([]string{"get", testID, "-tenant", "retail", "-config", ""}, io.Discard, io.Discard))
+		assert.Equal(t, "retail", gotTenant)
+	})
+}
+
+func TestResolveTarget(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	tokenPath := filepath.Join(dir, "staging.token")
+
+	require.NoError(t, os.WriteFile(tokenPath, []byte("from-file\n"), 0o600))
+	require.NoError(t, os.WriteFile(path, []byte(`current: local
+profiles:
+  local:
+    base_url: http://localhost:9000
+  staging:
+    base_url: https://staging.example.com
+    token_file: `+tokenPath+`
+    tenant: retail
+`), 0o600))
+
+	tests := []struct {
+		name       string
+		opts       globalOptions
+		env        map[string]string
+		wantURL    string
+		wantToken  string
+		wantTenant string
+		wantErr    string
+	}{
+		{
+			name:    "current profile",
+			opts:    globalOptions{profilesPath: path},
+			wantURL: "http://localhost:9000",
+		},
+		{
+			name:       "named profile with token file",
+			opts:       globalOptions{profilesPath: path, profile: "staging"},
+			wantURL:    "https://staging.example.com",
+			wantToken:  "from-file",
+			wantTenant: "retail",
+		},
+		{
+			name:       "profile from env",
+			opts:       globalOptions{profilesPath: path},
+			env:        map[string]string{"SUBSCTL_PROFILE": "staging"},
+			wantURL:    "https://staging.example.com",
+			wantToken:  "from-file",
+			wantTenant: "retail",
+		},
+		{
+			name:       "env overrides profile, flags override env",
+			opts:       globalOptions{profilesPath: path, profile: "staging", token: "flag", tenant: "wholesale"},
+			env:        map[string]string{"SUBSCTL_URL": "http://env", "SUBSCTL_TOKEN": "env", "SUBSCTL_TENANT": "env"},
+			wantURL:    "http://env",
+			wantToken:  "flag",
+			wantTenant: "wholesale",
+		},
+		{
+			name:    "missing file falls back to default",
+			opts:    globalOptions{profilesPath: filepath.Join(dir, "missing.yaml")},
+			wantURL: defaultBaseURL,
+		},
+		{
+			name:    "unknown profile",
+			opts:    globalOptions{profilesPath: path, profile: "prod"},
+			wantErr: `profile "prod" is not defined`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"SUBSCTL_PROFILE", "SUBSCTL_URL", "SUBSCTL_TOKEN", "SUBSCTL_TENANT"} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			got, err := resolveTarget(&tt.opts)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantURL, got.baseURL)
+			assert.Equal(t, tt.wantToken, got.token)
+			assert.Equal(t, tt.wantTenant, got.tenant)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+
+	savev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/save"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// render writes v in the requested format.
+func render(w io.Writer, format string, v any) error {
+	switch format {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case outputYAML:
+		// Go through JSON so that keys match the API instead of Go field names.
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		var generic any
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+
+		enc := yaml.NewEncoder(w)
+		defer enc.Close()
+		return enc.Encode(generic)
+	case outputTable:
+		return renderTable(w, v)
+	default:
+		return fmt.Errorf("unknown output format %q, want %s, %s or %s", format, outputTable, outputJSON, outputYAML)
+	}
+}
+
+func renderTable(w io.Writer, v any) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	switch v := v.(type) {
+	case response.SubscriptionResponse:
+		end := "-"
+		if v.EndDate != nil {
+			end = *v.EndDate
+		}
+
+		price := "-"
+		if v.Price != nil {
+			price = fmt.Sprint(*v.Price)
+		}
+
+		trial := "-"
+		if v.TrialEndDate != nil {
+			trial = fmt.Sprintf("%d until %s", v.TrialPrice, *v.TrialEndDate)
+		}
+
+		fmt.Fprintln(tw, "ID\tSERVICE\tPRICE\tUSER\tSTART\tEND\tTRIAL\tUPDATED")
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", v.ID, v.ServiceName, price, v.UserID, v.StartDate, end, trial, v.UpdatedAt)
+	case savev1.CreateResponse:
+		fmt.Fprintln(tw, "ID\tCREATED")
+		fmt.Fprintf(tw, "%s\t%s\n", v.ID, v.CreatedAt)
+	case response.SumResponse:
+		fmt.Fprintln(tw, "TOTAL")
+		fmt.Fprintf(tw, "%d\n", v.Total)
+	default:
+		fmt.Fprintf(tw, "%v\n", v)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultBaseURL = "http://localhost:8082"
+
+// profilesFile is the subsctl config file, by default ~/.config/subsctl/config.yaml:
+//
+//	current: staging
+//	profiles:
+//	  local:
+//	    base_url: http://localhost:8082
+//	  staging:
+//	    base_url: https://subscriptions.staging.example.com
+//	    token_file: ~/.config/subsctl/staging.token
+//	    tenant: retail
+type profilesFile struct {
+	Current  string             `yaml:"current"`
+	Profiles map[string]profile `yaml:"profiles"`
+}
+
+type profile struct {
+	BaseURL   string `yaml:"base_url"`
+	Token     string `yaml:"token"`
+	TokenFile string `yaml:"token_file"`
+	Tenant    string `yaml:"tenant"`
+}
+
+// target is the service subsctl talks to and the credentials it sends.
+type target struct {
+	baseURL string
+	token   string
+	tenant  string
+}
+
+func defaultProfilesPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "subsctl", "config.yaml")
+}
+
+// resolveTarget picks the base URL, token and tenant. Flags win over SUBSCTL_URL,
+// SUBSCTL_TOKEN and SUBSCTL_TENANT, which win over the selected profile.
+func resolveTarget(opts *globalOptions) (target, error) {
+	p, err := loadProfile(opts.profilesPath, firstNonEmpty(opts.profile, os.Getenv("SUBSCTL_PROFILE")))
+	if err != nil {
+		return target{}, err
+	}
+
+	if p.TokenFile != "" && p.Token == "" {
+		data, err := os.ReadFile(expandHome(p.TokenFile))
+		if err != nil {
+			return target{}, fmt.Errorf("token_file: %w", err)
+		}
+		p.Token = strings.TrimSpace(string(data))
+	}
+
+	return target{
+		baseURL: firstNonEmpty(opts.baseURL, os.Getenv("SUBSCTL_URL"), p.BaseURL, defaultBaseURL),
+		token:   firstNonEmpty(opts.token, os.Getenv("SUBSCTL_TOKEN"), p.Token),
+		tenant:  firstNonEmpty(opts.tenant, os.Getenv("SUBSCTL_TENANT"), p.Tenant),
+	}, nil
+}
+
+// loadProfile returns the named profile, or the current one if name is empty.
+// A missing file is fine unless a profile was asked for by name.
+func loadProfile(path, name string) (profile, error) {
+	if path == "" {
+		return profile{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		if name != "" {
+			return profile{}, fmt.Errorf("profile %q: %s does not exist", name, path)
+		}
+		return profile{}, nil
+	}
+	if err != nil {
+		return profile{}, err
+	}
+
+	var f profilesFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return profile{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if name == "" {
+		name = f.Current
+	}
+
+	if name == "" {
+		return profile{}, nil
+	}
+
+	p, ok := f.Profiles[name]
+	if !ok {
+		return profile{}, fmt.Errorf("profile %q is not defined in %s", name, path)
+	}
+
+	return p, nil
+}
+
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+
+	return path
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
@@ -1,5 +1,5 @@
-// Package subscriptions_service Code generated by swaggo/swag. DO NOT EDIT
-package subscriptions_service
+// Package module Code generated by swaggo/swag. DO NOT EDIT
+package module
 
 import "github.com/swaggo/swag"
 
@@ -15,9 +15,63 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/subscription": {
+        "/api/v1/budget": {
+            "get": {
+                "description": "Budgets of a user, the overall budget first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "budgets"
+                ],
+                "summary": "List budgets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.BudgetResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid user_id",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Creates a new subscription for a user",
+                "description": "Creates a monthly budget for a user, overall or for one service. A user has at most one overall budget and one per service.",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,17 +79,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "subscriptions"
+                    "budgets"
                 ],
-                "summary": "Create subscription",
+                "summary": "Create budget",
                 "parameters": [
                     {
-                        "description": "Subscription data",
+                        "description": "Budget data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CreateRequest"
+                            "$ref": "#/definitions/request.CreateBudgetRequest"
                         }
                     }
                 ],
@@ -43,93 +97,112 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/savev1.CreateResponse"
+                            "$ref": "#/definitions/response.BudgetResponse"
                         }
                     },
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "$ref": "#/definitions/savev1.Response"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "409": {
-                        "description": "Subscription already exists",
+                        "description": "Budget already exists",
                         "schema": {
-                            "$ref": "#/definitions/savev1.Response"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/savev1.Response"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/subscription/sum": {
-            "post": {
-                "description": "Sum of subscriptions for selected periods with optional filters",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/budget/evaluation": {
+            "get": {
+                "description": "For every month from from (the current month by default) to to (from by default), at most 36 months, compares each budget of the user with the cost of the subscriptions active in that month, priced like the sum: paused months cost nothing, trials cost the trial price, scheduled price changes apply. Exceeded budgets raise a budget_exceeded event once per month.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "subscriptions"
+                    "budgets"
                 ],
-                "summary": "Calculate total subscription cost",
+                "summary": "Evaluate budgets",
                 "parameters": [
                     {
-                        "description": "Filters",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.SumRequest"
-                        }
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First month, MM-YYYY",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last month, MM-YYYY",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.SumResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.BudgetMonthResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid user_id or period",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/subscription/{id}": {
+        "/api/v1/budget/{id}": {
             "get": {
-                "description": "Get subscription by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns a budget by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "subscriptions"
+                    "budgets"
                 ],
-                "summary": "Get subscription",
+                "summary": "Get budget",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Subscription ID (UUID)",
+                        "description": "Budget ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -137,47 +210,44 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Subscription data",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/response.BudgetResponse"
                         }
                     },
                     "400": {
                         "description": "Invalid ID",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "404": {
-                        "description": "Subscription not found",
+                        "description": "Budget not found",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
             },
             "delete": {
-                "description": "Deletes a subscription by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Deletes a budget by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "subscriptions"
+                    "budgets"
                 ],
-                "summary": "Delete subscription",
+                "summary": "Delete budget",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Subscription ID (UUID)",
+                        "description": "Budget ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -193,25 +263,25 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid ID",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "404": {
-                        "description": "Subscription not found",
+                        "description": "Budget not found",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
             },
             "patch": {
-                "description": "Partially update subscription fields (PATCH). Any field may be omitted.",
+                "description": "Changes the monthly amount of a budget. To change the service of a budget, delete it and create a new one.",
                 "consumes": [
                     "application/json"
                 ],
@@ -219,122 +289,1465 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "subscriptions"
+                    "budgets"
                 ],
-                "summary": "Update subscription",
+                "summary": "Update budget",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Subscription ID (UUID)",
+                        "description": "Budget ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Fields to update",
-                        "name": "body",
+                        "description": "New amount",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateRequest"
+                            "$ref": "#/definitions/request.UpdateBudgetRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated subscription",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.SubscriptionResponse"
+                            "$ref": "#/definitions/response.BudgetResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid request",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "404": {
-                        "description": "Subscription not found",
+                        "description": "Budget not found",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "request.CreateRequest": {
-            "type": "object",
-            "required": [
-                "price",
-                "service_name",
-                "start_date",
-                "user_id"
-            ],
-            "properties": {
-                "end_date": {
-                    "type": "string"
-                },
-                "price": {
-                    "type": "integer",
-                    "minimum": 0
-                },
-                "service_name": {
-                    "type": "string"
-                },
-                "start_date": {
-                    "type": "string"
-                },
-                "user_id": {
-                    "type": "string"
+        },
+        "/api/v1/subscription": {
+            "post": {
+                "description": "Creates a new subscription for a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Create subscription",
+                "parameters": [
+                    {
+                        "description": "Subscription data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/savev1.CreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Subscription already exists or overlaps another one",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
                 }
             }
         },
-        "request.SumRequest": {
-            "type": "object",
-            "properties": {
-                "end_date_from": {
-                    "type": "string"
-                },
-                "end_date_to": {
-                    "type": "string"
-                },
-                "service_name": {
-                    "type": "string"
-                },
-                "start_date_from": {
-                    "type": "string"
-                },
-                "start_date_to": {
-                    "type": "string"
-                },
-                "user_id": {
-                    "type": "string"
+        "/api/v1/subscription/sum": {
+            "post": {
+                "description": "Sum of subscriptions for selected periods with optional filters",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Calculate total subscription cost",
+                "parameters": [
+                    {
+                        "description": "Filters",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SumRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SumResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
                 }
             }
         },
-        "request.UpdateRequest": {
-            "type": "object",
-            "properties": {
-                "end_date": {
-                    "type": "string"
-                },
-                "price": {
-                    "type": "integer",
-                    "minimum": 0
-                },
+        "/api/v1/subscription/trials/ending": {
+            "get": {
+                "description": "Subscriptions whose last trial month is the given month, the current month by default. Subscriptions cancelled before the trial ends are not listed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Trials ending in a month",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Month, MM-YYYY",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.SubscriptionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid month or user_id",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/subscription/{id}": {
+            "get": {
+                "description": "Get subscription by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Get subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subscription data",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Idempotent full replacement (PUT): every field is set from the body, omitted optional fields are reset. If no subscription has the id, it is created with it. The owner of an existing subscription cannot be changed, use transfer.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Replace subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID), chosen by the client",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Subscription data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Replaced subscription",
+                        "schema": {
+                            "$ref": "#/definitions/response.SubscriptionResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created subscription",
+                        "schema": {
+                            "$ref": "#/definitions/response.SubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or owner change",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Subscription already exists or overlaps another one",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a subscription by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Delete subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Partially update subscription fields (PATCH). Any field may be omitted.\nWith application/merge-patch+json (RFC 7396) null removes a field: \"end_date\": null makes the subscription open-ended.\nWith application/json-patch+json (RFC 6902) the body is a list of operations; a failed \"test\" operation rejects the whole patch with 409.",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Update subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated subscription",
+                        "schema": {
+                            "$ref": "#/definitions/response.SubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Subscription already exists, overlaps another one or a test operation failed",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported content type",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/subscription/{id}/pause": {
+            "post": {
+                "description": "Stops billing from the month in from (default: current month) until resumed. Paused months are excluded from sums. The body is optional.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Pause subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "First paused month",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.PauseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid month or month outside the subscription period",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Subscription is already paused",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/subscription/{id}/prices": {
+            "get": {
+                "description": "Past and scheduled price changes of a subscription, oldest first. The price before the first change is the subscription price.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "List price changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.PriceChangeResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Sets a new price from a future month on, keeping the subscription identity. Scheduling the same month again replaces the price.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Schedule price change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New price",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SchedulePriceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PriceChangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or month",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/subscription/{id}/resume": {
+            "post": {
+                "description": "Ends the open pause; from is the first month billed again (default: current month, or the first paused month if the pause has not started yet). The body is optional.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Resume subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "First month billed again",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.ResumeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid month or month before the pause",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Subscription is not paused",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/subscription/{id}/transfer": {
+            "post": {
+                "description": "Ends the subscription for its owner the month before effective_from (default: current month) and creates its continuation for user_id in one transaction. The continuation keeps the service, end date and price in effect, the rest of the trial and later scheduled price changes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Transfer subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New owner and first month",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or month outside the subscription period",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "The new owner already has the subscription, or it is paused",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user_id}": {
+            "delete": {
+                "description": "Hard-deletes every subscription, pause, price change and budget of the user in one transaction and appends a record to the erasure log.\nThe record keeps only the SHA-256 of the user id and is hash-chained to the previous one. A user without data is erased too, so every request leaves a record.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Erase user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.ErasureResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user_id",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user_id}/erasure": {
+            "get": {
+                "description": "Verifies the whole erasure log and returns the latest record of the user, found by the hash of user_id.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Erasure proof",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.ErasureResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user_id",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User was never erased",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error or broken erasure log",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user_id}/export": {
+            "get": {
+                "description": "Machine-readable archive of every subscription of the user with its pauses and price history, the budgets and the summary of the current month. Served as an attachment.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Export user data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.UserExportResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user_id",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user_id}/subscriptions": {
+            "get": {
+                "description": "Every subscription of the user, including ended ones, ordered by start date and service.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List user subscriptions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/response.SubscriptionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user_id",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user_id}/subscriptions/cancel-all": {
+            "post": {
+                "description": "Sets end_date (default: current month) on every subscription of the user that has none, in one statement. A subscription that starts later ends in its first month. Subscriptions that already have an end date are left alone.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Cancel all user subscriptions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Last month of the subscriptions",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.CancelAllRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.CancelAllResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user_id or end_date before the current month",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user_id}/summary": {
+            "get": {
+                "description": "Active subscriptions (paused ones excluded), the cost of the current month and the service that costs the most in it. Costs are priced like the sum: pauses, trials and scheduled prices apply.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "User summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/response.UserSummaryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user_id",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "request.CancelAllRequest": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateBudgetRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "user_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "service_name": {
+                    "description": "ServiceName limits the budget to one service; omit it for the overall budget.",
+                    "type": "string",
+                    "minLength": 1
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.CreateRequest": {
+            "type": "object",
+            "required": [
+                "price",
+                "service_name",
+                "start_date",
+                "user_id"
+            ],
+            "properties": {
+                "allow_overlap": {
+                    "description": "AllowOverlap lets the period overlap another subscription of the same user and service.",
+                    "type": "boolean"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "service_name": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "trial_months": {
+                    "description": "TrialMonths months from start_date are billed at TrialPrice, 0 for a free trial.",
+                    "type": "integer",
+                    "maximum": 120,
+                    "minimum": 0
+                },
+                "trial_price": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.PauseRequest": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.ResumeRequest": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.SchedulePriceRequest": {
+            "type": "object",
+            "required": [
+                "effective_from",
+                "price"
+            ],
+            "properties": {
+                "effective_from": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "request.SumRequest": {
+            "type": "object",
+            "properties": {
+                "as_of": {
+                    "description": "AsOf is the month whose prices are summed, the current month by default. It decides\nwhether the trial price applies; subscriptions not active in it count with their nearest active month.",
+                    "type": "string"
+                },
+                "end_date_from": {
+                    "type": "string"
+                },
+                "end_date_to": {
+                    "type": "string"
+                },
                 "service_name": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "start_date_from": {
+                    "type": "string"
+                },
+                "start_date_to": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.TransferRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "effective_from": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.UpdateBudgetRequest": {
+            "type": "object",
+            "required": [
+                "amount"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "request.UpdateRequest": {
+            "type": "object",
+            "properties": {
+                "allow_overlap": {
+                    "type": "boolean"
+                },
+                "end_date": {
                     "type": "string"
                 },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "service_name": {
+                    "type": "string",
+                    "minLength": 1
+                },
                 "start_date": {
                     "type": "string"
+                },
+                "trial_months": {
+                    "type": "integer",
+                    "maximum": 120,
+                    "minimum": 0
+                },
+                "trial_price": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.BudgetMonthResponse": {
+            "type": "object",
+            "properties": {
+                "budgets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.BudgetStatusResponse"
+                    }
+                },
+                "exceeded": {
+                    "type": "boolean"
+                },
+                "month": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.BudgetResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "service_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.BudgetStatusResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "budget_id": {
+                    "type": "string"
+                },
+                "exceeded": {
+                    "type": "boolean"
+                },
+                "remaining": {
+                    "type": "integer"
+                },
+                "service_name": {
+                    "type": "string"
+                },
+                "spent": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.CancelAllResponse": {
+            "type": "object",
+            "properties": {
+                "cancelled": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "end_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ErasureResponse": {
+            "type": "object",
+            "properties": {
+                "budgets": {
+                    "type": "integer"
+                },
+                "erased_at": {
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "prev_hash": {
+                    "type": "string"
+                },
+                "seq": {
+                    "type": "integer"
+                },
+                "subscriptions": {
+                    "type": "integer"
+                },
+                "user_hash": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "param": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.PauseResponse": {
+            "type": "object",
+            "properties": {
+                "paused_from": {
+                    "type": "string"
+                },
+                "resumed_from": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.PriceChangeResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "effective_from": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "scheduled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "response.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
@@ -342,15 +1755,81 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "data": {},
-                "error": {},
                 "status": {
                     "type": "string"
                 }
             }
         },
+        "response.ServiceCostResponse": {
+            "type": "object",
+            "properties": {
+                "cost": {
+                    "type": "integer"
+                },
+                "service_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.SubscriptionExportResponse": {
+            "type": "object",
+            "properties": {
+                "allow_overlap": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "pauses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PauseResponse"
+                    }
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "price_changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PriceChangeResponse"
+                    }
+                },
+                "service_name": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "trial_end_date": {
+                    "type": "string"
+                },
+                "trial_months": {
+                    "type": "integer"
+                },
+                "trial_price": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
         "response.SubscriptionResponse": {
             "type": "object",
             "properties": {
+                "allow_overlap": {
+                    "type": "boolean"
+                },
                 "created_at": {
                     "type": "string"
                 },
@@ -360,6 +1839,12 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "pauses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PauseResponse"
+                    }
+                },
                 "price": {
                     "type": "integer"
                 },
@@ -369,6 +1854,15 @@ const docTemplate = `{
                 "start_date": {
                     "type": "string"
                 },
+                "trial_end_date": {
+                    "type": "string"
+                },
+                "trial_months": {
+                    "type": "integer"
+                },
+                "trial_price": {
+                    "type": "integer"
+                },
                 "updated_at": {
                     "type": "string"
                 },
@@ -385,26 +1879,70 @@ const docTemplate = `{
                 }
             }
         },
-        "savev1.CreateResponse": {
+        "response.TransferResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "continuation": {
+                    "$ref": "#/definitions/response.SubscriptionResponse"
+                },
+                "ended": {
+                    "$ref": "#/definitions/response.SubscriptionResponse"
+                }
+            }
+        },
+        "response.UserExportResponse": {
+            "type": "object",
+            "properties": {
+                "budgets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.BudgetResponse"
+                    }
+                },
+                "exported_at": {
                     "type": "string"
                 },
-                "id": {
+                "subscriptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.SubscriptionExportResponse"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/response.UserSummaryResponse"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "savev1.Response": {
+        "response.UserSummaryResponse": {
             "type": "object",
             "properties": {
-                "data": {},
-                "error": {},
-                "id": {
+                "active_count": {
+                    "type": "integer"
+                },
+                "month": {
                     "type": "string"
                 },
-                "status": {
+                "monthly_cost": {
+                    "type": "integer"
+                },
+                "most_expensive": {
+                    "$ref": "#/definitions/response.ServiceCostResponse"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "savev1.CreateResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "string"
                 }
             }
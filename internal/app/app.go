@@ -1,24 +1,93 @@
 package app
 
 import (
+	"context"
+	"expvar"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+
 	"github.com/salivare-io/slogx"
 	httpapp "github.com/salivare/subscriptions-service/internal/app/http"
 	swaggerapp "github.com/salivare/subscriptions-service/internal/app/swagger"
 	"github.com/salivare/subscriptions-service/internal/config"
+	budgetdeletev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/budgets/v1/delete"
+	budgetevaluatev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/budgets/v1/evaluate"
+	budgetgetv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/budgets/v1/get"
+	budgetlistv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/budgets/v1/list"
+	budgetsavev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/budgets/v1/save"
+	budgetupdatev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/budgets/v1/update"
 	deletev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/delete"
 	getv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/get"
+	pausev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/pause"
+	pricesv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/prices"
+	replacev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/replace"
+	resumev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/resume"
 	savev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/save"
+	schedulepricev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/scheduleprice"
 	sumv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/sum"
+	transferv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/transfer"
+	trialsv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/trials"
 	updatev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/update"
+	usercancelallv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/users/v1/cancelall"
+	usererasev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/users/v1/erase"
+	usererasurev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/users/v1/erasure"
+	userexportv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/users/v1/export"
+	userlistv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/users/v1/list"
+	usersummaryv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/users/v1/summary"
 	"github.com/salivare/subscriptions-service/internal/httpserver/middleware"
 	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+	"github.com/salivare/subscriptions-service/internal/services/budget"
+	"github.com/salivare/subscriptions-service/internal/services/privacy"
 	"github.com/salivare/subscriptions-service/internal/services/subscription"
+	"github.com/salivare/subscriptions-service/internal/storage/memory"
 	"github.com/salivare/subscriptions-service/internal/storage/postgres"
+	"github.com/salivare/subscriptions-service/internal/storage/sqlite"
 )
 
+// Storage is implemented by every storage backend selectable with storage.driver.
+type Storage interface {
+	subscription.Saver
+	subscription.Updater
+	subscription.Deleter
+	subscription.Getter
+	subscription.Summer
+	subscription.ServiceSummer
+	subscription.TrialLister
+	subscription.PriceScheduler
+	subscription.PriceLister
+	subscription.Pauser
+	subscription.Transferrer
+	subscription.Upserter
+	subscription.UserLister
+	subscription.Canceller
+	budget.Saver
+	budget.Updater
+	budget.Deleter
+	budget.Getter
+	budget.Lister
+	budget.AlertMarker
+	privacy.Eraser
+	privacy.ErasureLister
+	Close()
+}
+
 // App is a root structure that aggregates all application modules
 type App struct {
 	HTTPSrv *httpapp.App
+	// AdminSrv serves /debug/vars, nil unless admin_server is enabled.
+	AdminSrv   *httpapp.App
+	log        *slogx.Logger
+	storage    Storage
+	cache      *subscription.Cache
+	cors       *middleware.ReloadableCORS
+	stopListen context.CancelFunc
+	listenerWG sync.WaitGroup
+
+	// reloadMu guards cfg, the configuration currently in effect.
+	reloadMu sync.Mutex
+	cfg      *config.Config
 }
 
 // New creates a new instance of the root application.
@@ -27,20 +96,59 @@ func New(log *slogx.Logger, cfg *config.Config) (*App, error) {
 	r.Use(middleware.RequestID)
 	r.Use(middleware.Logger(log))
 	r.Use(middleware.LoggerContext(log))
+	r.Use(middleware.ReadYourWrites)
 
-	storage, err := postgres.New(cfg.Postgres)
+	// Registered even when disabled so that a reload can turn it on.
+	cors := middleware.NewReloadableCORS(cfg.CORS)
+	r.Use(cors.Middleware)
+	r.Use(middleware.Tenant(cfg.Tenancy))
+
+	storage, err := NewStorage(log, cfg)
 	if err != nil {
-		log.Error("could not connect to postgres", slogx.Err(err))
 		return nil, err
 	}
 
-	subSrv := subscription.New(storage, storage, storage, storage, storage)
+	application := &App{
+		log:     log,
+		storage: storage,
+		cors:    cors,
+		cfg:     cfg,
+	}
+
+	subSrv := application.newService(log, cfg, storage)
 
-	r.POST("/api/v1/subscription", savev1.New(subSrv))
-	r.DELETE("/api/v1/subscription/{id}", deletev1.New(subSrv))
-	r.PATCH("/api/v1/subscription/{id}", updatev1.New(subSrv))
-	r.GET("/api/v1/subscription/{id}", getv1.New(subSrv))
-	r.POST("/api/v1/subscription/sum", sumv1.New(subSrv))
+	api := r.Group("/api/v1")
+	api.POST("/subscription", savev1.New(subSrv))
+	api.DELETE("/subscription/{id}", deletev1.New(subSrv))
+	api.PATCH("/subscription/{id}", updatev1.New(subSrv))
+	api.PUT("/subscription/{id}", replacev1.New(subSrv))
+	api.GET("/subscription/{id}", getv1.New(subSrv))
+	api.POST("/subscription/sum", sumv1.New(subSrv))
+	api.GET("/subscription/trials/ending", trialsv1.New(subSrv))
+	api.GET("/subscription/{id}/prices", pricesv1.New(subSrv))
+	api.POST("/subscription/{id}/prices", schedulepricev1.New(subSrv))
+	api.POST("/subscription/{id}/pause", pausev1.New(subSrv))
+	api.POST("/subscription/{id}/resume", resumev1.New(subSrv))
+	api.POST("/subscription/{id}/transfer", transferv1.New(subSrv))
+
+	api.GET("/users/{user_id}/subscriptions", userlistv1.New(subSrv))
+	api.GET("/users/{user_id}/summary", usersummaryv1.New(subSrv))
+	api.POST("/users/{user_id}/subscriptions/cancel-all", usercancelallv1.New(subSrv))
+
+	privacySrv := application.newPrivacyService(storage, subSrv)
+
+	api.GET("/users/{user_id}/export", userexportv1.New(privacySrv))
+	api.GET("/users/{user_id}/erasure", usererasurev1.New(privacySrv))
+	api.DELETE("/users/{user_id}", usererasev1.New(privacySrv))
+
+	budgetSrv := newBudgetService(cfg, storage)
+
+	api.POST("/budget", budgetsavev1.New(budgetSrv))
+	api.GET("/budget", budgetlistv1.New(budgetSrv))
+	api.GET("/budget/evaluation", budgetevaluatev1.New(budgetSrv))
+	api.GET("/budget/{id}", budgetgetv1.New(budgetSrv))
+	api.PATCH("/budget/{id}", budgetupdatev1.New(budgetSrv))
+	api.DELETE("/budget/{id}", budgetdeletev1.New(budgetSrv))
 
 	sw := swaggerapp.New(
 		cfg.SwaggerServer.JSONPath,
@@ -48,9 +156,130 @@ func New(log *slogx.Logger, cfg *config.Config) (*App, error) {
 	)
 	sw.Register(r.Mux())
 
-	httpApp := httpapp.New(log, cfg.HTTPServer, r)
+	application.HTTPSrv = httpapp.New(log, cfg.HTTPServer, r)
+
+	if cfg.AdminServer.Enabled {
+		application.AdminSrv = newAdminServer(log, cfg)
+	}
+
+	return application, nil
+}
+
+// Stop shuts down the HTTP servers and then releases the database connections.
+func (a *App) Stop() {
+	a.HTTPSrv.Stop()
+
+	if a.AdminSrv != nil {
+		a.AdminSrv.Stop()
+	}
+
+	if a.stopListen != nil {
+		a.stopListen()
+		a.listenerWG.Wait()
+	}
+
+	a.storage.Close()
+}
+
+// newAdminServer serves the expvar dump, cache counters included, on admin_server.
+// It borrows the timeouts of http_server.
+func newAdminServer(log *slogx.Logger, cfg *config.Config) *httpapp.App {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	admin := cfg.HTTPServer
+	admin.Host = cfg.AdminServer.Host
+	admin.Port = cfg.AdminServer.Port
 
-	return &App{
-		HTTPSrv: httpApp,
-	}, nil
+	return httpapp.New(log.With(slog.String("server", "admin")), admin, mux)
+}
+
+// newService builds the subscription service, putting the read-through cache
+// in front of storage when it is enabled.
+func (a *App) newService(log *slogx.Logger, cfg *config.Config, storage Storage) *subscription.Service {
+	if !cfg.Cache.Enabled {
+		return subscription.New(storage)
+	}
+
+	// Only postgres is shared between instances, so only it needs cross-instance invalidation.
+	var broadcaster subscription.Broadcaster
+	if b, ok := storage.(subscription.Broadcaster); ok && cfg.Storage.Driver == config.DriverPostgres {
+		broadcaster = b
+	}
+
+	c := subscription.NewCache(log, cfg.Cache, storage, broadcaster)
+	a.cache = c
+
+	if expvar.Get("subscription_cache") == nil {
+		expvar.Publish("subscription_cache", expvar.Func(func() any { return c.Stats() }))
+	}
+
+	ctx, cancel := context.WithCancel(slogx.ToContext(context.Background(), log))
+	a.stopListen = cancel
+	a.listenerWG.Add(1)
+
+	go func() {
+		defer a.listenerWG.Done()
+		c.Run(ctx)
+	}()
+
+	return subscription.New(c)
+}
+
+// newPrivacyService builds the privacy service. Erasing a user bypasses the cache,
+// so the cache, when enabled, is dropped after every erasure.
+func (a *App) newPrivacyService(storage Storage, subSrv *subscription.Service) *privacy.Service {
+	var invalidator privacy.Invalidator
+	if a.cache != nil {
+		invalidator = a.cache
+	}
+
+	return privacy.New(storage, subSrv, invalidator)
+}
+
+// newBudgetService builds the budget service. Exceeded budgets are published with
+// NOTIFY when the storage is postgres, and only logged otherwise.
+func newBudgetService(cfg *config.Config, storage Storage) *budget.Service {
+	var publisher budget.Publisher
+	if p, ok := storage.(budget.Publisher); ok && cfg.Storage.Driver == config.DriverPostgres {
+		publisher = p
+	}
+
+	return budget.New(storage, publisher)
+}
+
+// NewStorage opens the backend selected by storage.driver.
+func NewStorage(log *slogx.Logger, cfg *config.Config) (Storage, error) {
+	switch cfg.Storage.Driver {
+	case config.DriverPostgres:
+		storage, err := postgres.New(cfg.Postgres)
+		if err != nil {
+			log.Error("could not connect to postgres", slogx.Err(err))
+			return nil, err
+		}
+
+		// Without the policies a query missing its tenant filter would leak rows, so
+		// tenancy refuses to start rather than run unisolated.
+		if cfg.Tenancy.Enabled {
+			if err := storage.CheckRowSecurity(context.Background(), cfg.Postgres.MigrationsTable); err != nil {
+				log.Error("tenants are not isolated", slogx.Err(err))
+				storage.Close()
+				return nil, err
+			}
+		}
+
+		return storage, nil
+	case config.DriverSQLite:
+		storage, err := sqlite.New(cfg.SQLite)
+		if err != nil {
+			log.Error("could not open sqlite database", slogx.Err(err))
+			return nil, err
+		}
+		return storage, nil
+	case config.DriverMemory:
+		log.Warn("using in-memory storage, data is lost on restart")
+		return memory.New(), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Storage.Driver)
+	}
 }
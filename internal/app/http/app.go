@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/salivare-io/slogx"
@@ -20,7 +21,7 @@ type App struct {
 	server          *http.Server
 	host            string
 	port            int
-	shutdownTimeout time.Duration
+	shutdownTimeout atomic.Int64
 }
 
 // New creates a new instance of the HTTP application.
@@ -40,13 +41,21 @@ func New(
 		MaxHeaderBytes:    cfg.MaxHeaderBytes,
 	}
 
-	return &App{
-		log:             log,
-		server:          srv,
-		host:            cfg.Host,
-		port:            cfg.Port,
-		shutdownTimeout: cfg.ShutdownTimeout,
+	a := &App{
+		log:    log,
+		server: srv,
+		host:   cfg.Host,
+		port:   cfg.Port,
 	}
+	a.SetShutdownTimeout(cfg.ShutdownTimeout)
+
+	return a
+}
+
+// SetShutdownTimeout changes how long Stop waits for active connections.
+// The other server timeouts are fixed once the server is running.
+func (a *App) SetShutdownTimeout(d time.Duration) {
+	a.shutdownTimeout.Store(int64(d))
 }
 
 // MustRun starts the HTTP server and panics if an error occurs during startup.
@@ -97,10 +106,11 @@ func (a *App) Stop() {
 
 	log.Info("HTTP server is stopping")
 
+	shutdownTimeout := time.Duration(a.shutdownTimeout.Load())
 	done := make(chan struct{})
 
 	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 		defer cancel()
 
 		if err := a.server.Shutdown(ctx); err != nil {
@@ -112,7 +122,7 @@ func (a *App) Stop() {
 	select {
 	case <-done:
 		log.Info("HTTP server stopped gracefully")
-	case <-time.After(a.shutdownTimeout):
+	case <-time.After(shutdownTimeout):
 		log.Warn("graceful stop timed out, forcing close")
 		if err := a.server.Close(); err != nil {
 			log.Error("HTTP server close error", slogx.Err(err))
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"github.com/salivare-io/slogx"
+
+	"github.com/salivare/subscriptions-service/internal/config"
+)
+
+// poolResetter is implemented by storages whose connection pools can be rebuilt at runtime.
+type poolResetter interface {
+	ResetPool(cfg config.PostgresConfig) error
+}
+
+// Reload applies the settings of cfg that can change at runtime: CORS, the
+// shutdown timeout and the postgres pool settings and replicas. Settings that
+// need a restart are logged and keep their running values. Nothing is applied
+// if the pool cannot be rebuilt. The log level is up to the caller.
+func (a *App) Reload(cfg *config.Config) error {
+	const op = "app.Reload"
+	log := a.log.With(slog.String("op", op))
+
+	a.reloadMu.Lock()
+	defer a.reloadMu.Unlock()
+
+	next, ignored := mergeReloadable(a.cfg, cfg)
+
+	for _, setting := range ignored {
+		log.Warn("setting requires a restart, keeping the running value", slog.String("setting", setting))
+	}
+
+	if poolSettings(a.cfg.Postgres) != poolSettings(next.Postgres) ||
+		!reflect.DeepEqual(a.cfg.Postgres.ReplicaDSNs, next.Postgres.ReplicaDSNs) {
+		if r, ok := a.storage.(poolResetter); ok {
+			if err := r.ResetPool(next.Postgres); err != nil {
+				log.Error("failed to reset database pool", slogx.Err(err))
+				return fmt.Errorf("%s: %w", op, err)
+			}
+
+			log.Info("database pool reset", slog.Int("max_conns", next.Postgres.MaxConns))
+		}
+	}
+
+	a.cors.Update(next.CORS)
+	a.HTTPSrv.SetShutdownTimeout(next.HTTPServer.ShutdownTimeout)
+	if a.AdminSrv != nil {
+		a.AdminSrv.SetShutdownTimeout(next.HTTPServer.ShutdownTimeout)
+	}
+
+	a.cfg = next
+
+	log.Info("configuration reloaded")
+
+	return nil
+}
+
+type pgPoolSettings struct {
+	maxConns, minConns          int
+	idle, lifetime, healthCheck time.Duration
+}
+
+func poolSettings(cfg config.PostgresConfig) pgPoolSettings {
+	return pgPoolSettings{
+		maxConns:    cfg.MaxConns,
+		minConns:    cfg.MinConns,
+		idle:        cfg.MaxConnIdleTime,
+		lifetime:    cfg.MaxConnLifetime,
+		healthCheck: cfg.HealthCheckPeriod,
+	}
+}
+
+// mergeReloadable returns the running config with the reloadable settings of cfg,
+// and the sections of cfg whose other settings differ from the running ones.
+func mergeReloadable(running, cfg *config.Config) (*config.Config, []string) {
+	next := *running
+
+	next.Env = cfg.Env
+	next.LogLevel = cfg.LogLevel
+	next.CORS = cfg.CORS
+	next.HTTPServer.ShutdownTimeout = cfg.HTTPServer.ShutdownTimeout
+	next.Postgres.MaxConns = cfg.Postgres.MaxConns
+	next.Postgres.MinConns = cfg.Postgres.MinConns
+	next.Postgres.MaxConnIdleTime = cfg.Postgres.MaxConnIdleTime
+	next.Postgres.MaxConnLifetime = cfg.Postgres.MaxConnLifetime
+	next.Postgres.HealthCheckPeriod = cfg.Postgres.HealthCheckPeriod
+	next.Postgres.ReplicaDSNs = cfg.Postgres.ReplicaDSNs
+
+	var ignored []string
+
+	for _, section := range []struct {
+		name      string
+		next, cfg any
+	}{
+		{"http_server", next.HTTPServer, cfg.HTTPServer},
+		{"admin_server", next.AdminServer, cfg.AdminServer},
+		{"storage", next.Storage, cfg.Storage},
+		{"postgres", next.Postgres, cfg.Postgres},
+		{"sqlite", next.SQLite, cfg.SQLite},
+		{"swagger_server", next.SwaggerServer, cfg.SwaggerServer},
+		{"cache", next.Cache, cfg.Cache},
+		{"tenancy", next.Tenancy, cfg.Tenancy},
+	} {
+		if !reflect.DeepEqual(section.next, section.cfg) {
+			ignored = append(ignored, section.name)
+		}
+	}
+
+	return &next, ignored
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/config"
+)
+
+func TestMergeReloadable(t *testing.T) {
+	running := &config.Config{
+		Env:        "prod",
+		HTTPServer: config.HTTPConfig{Port: 8082, ShutdownTimeout: 5 * time.Second},
+		Storage:    config.StorageConfig{Driver: config.DriverPostgres},
+		Postgres:   config.PostgresConfig{Host: "db", MaxConns: 10},
+		CORS:       config.CORSConfig{Enabled: false},
+	}
+
+	cfg := *running
+	cfg.Env = "dev"
+	cfg.HTTPServer.ShutdownTimeout = 10 * time.Second
+	cfg.HTTPServer.Port = 9090
+	cfg.Postgres.MaxConns = 50
+	cfg.Postgres.Host = "other-db"
+	cfg.CORS = config.CORSConfig{Enabled: true, AllowedOrigins: []string{"*"}}
+
+	next, ignored := mergeReloadable(running, &cfg)
+
+	assert.ElementsMatch(t, []string{"http_server", "postgres"}, ignored)
+
+	assert.Equal(t, "dev", next.Env)
+	assert.Equal(t, 10*time.Second, next.HTTPServer.ShutdownTimeout)
+	assert.Equal(t, 50, next.Postgres.MaxConns)
+	assert.True(t, next.CORS.Enabled)
+
+	assert.Equal(t, 8082, next.HTTPServer.Port, "port needs a restart")
+	assert.Equal(t, "db", next.Postgres.Host, "connection settings need a restart")
+}
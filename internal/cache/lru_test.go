@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU[string, int](2, time.Minute)
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+
+	c.Put("c", 3)
+
+	_, ok = c.Get("b")
+	assert.False(t, ok, "b was least recently used and must be evicted")
+
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	assert.Equal(t, Stats{Hits: 2, Misses: 1, Evictions: 1, Size: 2}, c.Stats())
+}
+
+func TestLRU_Expires(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	c := NewLRU[string, int](10, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Put("a", 1)
+
+	now = now.Add(59 * time.Second)
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+
+	assert.Equal(t, 0, c.Stats().Size)
+}
+
+func TestLRU_DeleteAndPurge(t *testing.T) {
+	c := NewLRU[string, int](10, time.Minute)
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Delete("a")
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+
+	c.Purge()
+
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Stats().Size)
+}
@@ -1,89 +1,257 @@
 package config
 
 import (
+	"errors"
 	"flag"
+	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
+// Storage drivers accepted in storage.driver.
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMemory   = "memory"
+)
+
 // Config is the main application configuration structure.
+// Every field can be set from the environment, which overrides the YAML file;
+// without a file the configuration is read from the environment alone.
 type Config struct {
-	Env           string         `yaml:"env" env-default:"local"`
+	Env           string         `yaml:"env" env:"ENV" env-default:"local"`
+	LogLevel      string         `yaml:"log_level" env:"LOG_LEVEL"` // trace, debug, info, warn, error; derived from env when empty
 	HTTPServer    HTTPConfig     `yaml:"http_server"`
+	AdminServer   AdminConfig    `yaml:"admin_server"`
+	Storage       StorageConfig  `yaml:"storage"`
 	Postgres      PostgresConfig `yaml:"postgres"`
+	SQLite        SQLiteConfig   `yaml:"sqlite"`
 	SwaggerServer SwaggerConfig  `yaml:"swagger_server"`
+	CORS          CORSConfig     `yaml:"cors"`
+	Cache         CacheConfig    `yaml:"cache"`
+	Tenancy       TenancyConfig  `yaml:"tenancy"`
 }
 
 // HTTPConfig defines the parameters for the underlying http.Server.
 type HTTPConfig struct {
-	Host              string        `yaml:"host" env-default:"localhost"`
-	Port              int           `yaml:"port" env-default:"8080"`
-	Timeout           time.Duration `yaml:"timeout" env-default:"4s"`
-	IdleTimeout       time.Duration `yaml:"idle_timeout" env-default:"60s"`
-	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env-default:"10s"`
-	ReadTimeout       time.Duration `yaml:"read_timeout" env-default:"5s"`
-	WriteTimeout      time.Duration `yaml:"write_timeout" env-default:"10s"`
-	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env-default:"2s"`
-	MaxHeaderBytes    int           `yaml:"max_header_bytes" env-default:"1048576"`
+	Host              string        `yaml:"host" env:"HTTP_HOST" env-default:"localhost"`
+	Port              int           `yaml:"port" env:"HTTP_PORT" env-default:"8080"`
+	Timeout           time.Duration `yaml:"timeout" env:"HTTP_TIMEOUT" env-default:"4s"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT" env-default:"60s"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env:"HTTP_SHUTDOWN_TIMEOUT" env-default:"10s"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT" env-default:"5s"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT" env-default:"10s"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT" env-default:"2s"`
+	MaxHeaderBytes    int           `yaml:"max_header_bytes" env:"HTTP_MAX_HEADER_BYTES" env-default:"1048576"`
+}
+
+// AdminConfig configures the listener for operational endpoints such as /debug/vars.
+// They are not served on the API listener: they need no tenant and expose process internals,
+// so Host should be reachable only from inside the deployment. The other http.Server
+// settings are those of http_server.
+type AdminConfig struct {
+	Enabled bool   `yaml:"enabled" env:"ADMIN_ENABLED" env-default:"false"`
+	Host    string `yaml:"host" env:"ADMIN_HOST" env-default:"localhost"`
+	Port    int    `yaml:"port" env:"ADMIN_PORT" env-default:"8081"`
+}
+
+// StorageConfig selects the storage backend.
+type StorageConfig struct {
+	Driver string `yaml:"driver" env:"STORAGE_DRIVER" env-default:"postgres"` // postgres, sqlite, memory
 }
 
+// PostgresConfig configures the primary database and its read replicas.
+// URL, when set, replaces the individual connection fields.
 type PostgresConfig struct {
-	Host              string        `yaml:"host" env-default:"localhost"`
-	Port              int           `yaml:"port" env-default:"5432"`
-	User              string        `yaml:"user" env-default:"postgres"`
-	Password          string        `yaml:"password" env-default:""`
-	DBName            string        `yaml:"dbname" env-default:"postgres"`
-	SSLMode           string        `yaml:"sslmode" env-default:"disable"`
-	MaxConns          int           `yaml:"max_conns"`
-	MinConns          int           `yaml:"min_conns"`
-	MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time"`
-	MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime"`
-	HealthCheckPeriod time.Duration `yaml:"health_check_period"`
-	MigrationsPath    string        `yaml:"migrations_path" env-default:"./migrations"`
-	MigrationsTable   string        `yaml:"migrations_table" env-default:"schema_migrations"`
-	Retry             RetryConfig   `yaml:"retry"`
+	URL               string        `yaml:"url" env:"DATABASE_URL"`
+	Host              string        `yaml:"host" env:"POSTGRES_HOST" env-default:"localhost"`
+	Port              int           `yaml:"port" env:"POSTGRES_PORT" env-default:"5432"`
+	User              string        `yaml:"user" env:"POSTGRES_USER" env-default:"postgres"`
+	Password          string        `yaml:"password" env:"POSTGRES_PASSWORD" env-default:""`
+	DBName            string        `yaml:"dbname" env:"POSTGRES_DB" env-default:"postgres"`
+	SSLMode           string        `yaml:"sslmode" env:"POSTGRES_SSLMODE" env-default:"disable"`
+	MaxConns          int           `yaml:"max_conns" env:"POSTGRES_MAX_CONNS"`
+	MinConns          int           `yaml:"min_conns" env:"POSTGRES_MIN_CONNS"`
+	MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time" env:"POSTGRES_MAX_CONN_IDLE_TIME"`
+	MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime" env:"POSTGRES_MAX_CONN_LIFETIME"`
+	HealthCheckPeriod time.Duration `yaml:"health_check_period" env:"POSTGRES_HEALTH_CHECK_PERIOD"`
+	ReplicaDSNs       []string      `yaml:"replica_dsns" env:"POSTGRES_REPLICA_DSNS"`
+	MigrationsPath    string        `yaml:"migrations_path" env:"POSTGRES_MIGRATIONS_PATH" env-default:"./migrations"`
+	MigrationsTable   string        `yaml:"migrations_table" env:"POSTGRES_MIGRATIONS_TABLE" env-default:"schema_migrations"`
+	Retry             RetryConfig   `yaml:"retry" env-prefix:"POSTGRES_RETRY_"`
+}
+
+// DSN returns URL if it is set, otherwise a URL built from the individual fields.
+func (c PostgresConfig) DSN() string {
+	if c.URL != "" {
+		return c.URL
+	}
+
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     net.JoinHostPort(c.Host, strconv.Itoa(c.Port)),
+		Path:     "/" + c.DBName,
+		RawQuery: url.Values{"sslmode": {c.SSLMode}}.Encode(),
+	}
+
+	return u.String()
+}
+
+// SQLiteConfig configures the single-node SQLite backend. Migrations are applied on startup.
+type SQLiteConfig struct {
+	Path            string        `yaml:"path" env:"SQLITE_PATH" env-default:"./data/subscriptions.db"`
+	BusyTimeout     time.Duration `yaml:"busy_timeout" env:"SQLITE_BUSY_TIMEOUT" env-default:"5s"`
+	MigrationsPath  string        `yaml:"migrations_path" env:"SQLITE_MIGRATIONS_PATH" env-default:"./migrations/sqlite"`
+	MigrationsTable string        `yaml:"migrations_table" env:"SQLITE_MIGRATIONS_TABLE" env-default:"schema_migrations"`
 }
 
 type RetryConfig struct {
-	Attempts     int           `yaml:"attempts" env-default:"10"`
-	InitialDelay time.Duration `yaml:"initial_delay" env-default:"1s"`
-	MaxDelay     time.Duration `yaml:"max_delay" env-default:"10s"`
-	Step         time.Duration `yaml:"step" env-default:"2s"`
+	Attempts     int           `yaml:"attempts" env:"ATTEMPTS" env-default:"10"`
+	InitialDelay time.Duration `yaml:"initial_delay" env:"INITIAL_DELAY" env-default:"1s"`
+	MaxDelay     time.Duration `yaml:"max_delay" env:"MAX_DELAY" env-default:"10s"`
+	Step         time.Duration `yaml:"step" env:"STEP" env-default:"2s"`
 }
 
 type SwaggerConfig struct {
-	JSONPath string `yaml:"json_path" env-default:"./swagger.json"`
-	UIPath   string `yaml:"ui_path" env-default:"./swaggerui"`
+	JSONPath string `yaml:"json_path" env:"SWAGGER_JSON_PATH" env-default:"./swagger.json"`
+	UIPath   string `yaml:"ui_path" env:"SWAGGER_UI_PATH" env-default:"./swaggerui"`
+}
+
+// CORSConfig controls cross-origin access to the API.
+// An origin of "*" allows any origin, "https://*.example.com" allows any subdomain of example.com.
+type CORSConfig struct {
+	Enabled          bool          `yaml:"enabled" env:"CORS_ENABLED" env-default:"false"`
+	AllowedOrigins   []string      `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+	AllowedMethods   []string      `yaml:"allowed_methods" env:"CORS_ALLOWED_METHODS" env-default:"GET,HEAD,POST,PUT,PATCH,DELETE"`
+	AllowedHeaders   []string      `yaml:"allowed_headers" env:"CORS_ALLOWED_HEADERS" env-default:"Content-Type,Authorization,X-Request-ID,X-Read-Your-Writes,X-Tenant-ID"`
+	ExposedHeaders   []string      `yaml:"exposed_headers" env:"CORS_EXPOSED_HEADERS" env-default:"X-Request-ID"`
+	AllowCredentials bool          `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS" env-default:"false"`
+	MaxAge           time.Duration `yaml:"max_age" env:"CORS_MAX_AGE" env-default:"10m"`
 }
 
-// MustLoad reads the configuration from the path provided via flags or environment variables.
+// CacheConfig controls the in-process read-through cache for subscriptions and sums.
+// With the postgres driver, invalidations are broadcast to other instances via LISTEN/NOTIFY on NotifyChannel.
+type CacheConfig struct {
+	Enabled          bool          `yaml:"enabled" env:"CACHE_ENABLED" env-default:"false"`
+	MaxSubscriptions int           `yaml:"max_subscriptions" env:"CACHE_MAX_SUBSCRIPTIONS" env-default:"10000"`
+	MaxSums          int           `yaml:"max_sums" env:"CACHE_MAX_SUMS" env-default:"1000"`
+	SubscriptionTTL  time.Duration `yaml:"subscription_ttl" env:"CACHE_SUBSCRIPTION_TTL" env-default:"5m"`
+	SumTTL           time.Duration `yaml:"sum_ttl" env:"CACHE_SUM_TTL" env-default:"1m"`
+	NotifyChannel    string        `yaml:"notify_channel" env:"CACHE_NOTIFY_CHANNEL" env-default:"subscriptions_cache"`
+}
+
+// TenancyConfig controls how the tenant of a request is resolved. When disabled, every
+// request belongs to DefaultTenant. When enabled, the tenant comes from Header, which
+// only a trusted gateway in front of the service may set: the service does not
+// authenticate callers, so anyone reaching it directly can name any tenant.
+type TenancyConfig struct {
+	Enabled       bool   `yaml:"enabled" env:"TENANCY_ENABLED" env-default:"false"`
+	Header        string `yaml:"header" env:"TENANCY_HEADER" env-default:"X-Tenant-ID"`
+	DefaultTenant string `yaml:"default_tenant" env:"TENANCY_DEFAULT_TENANT" env-default:"default"`
+}
+
+// MustLoad reads the configuration from the path provided via flags or environment variables,
+// or from the environment alone if no path is given.
 // It panics if the configuration cannot be loaded.
 func MustLoad() *Config {
-	path := fetchConfigPath()
-
-	if path == "" {
-		panic("config file path is empty")
-	}
+	return MustLoadByPath(Path())
+}
 
-	return MustLoadByPath(path)
+// Path returns the config path provided via the -config flag or CONFIG_PATH.
+// It is empty in environment-only mode.
+func Path() string {
+	return fetchConfigPath()
 }
 
 // MustLoadByPath reads the configuration from a specific file path.
 // It panics if the file is missing or invalid.
 func MustLoadByPath(configPath string) *Config {
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		panic("config file does not exist: " + configPath)
+	cfg, err := Load(configPath)
+	if err != nil {
+		panic(err.Error())
 	}
 
+	return cfg
+}
+
+// Load reads and validates the configuration from a specific file path,
+// or from the environment alone if configPath is empty.
+func Load(configPath string) (*Config, error) {
 	var cfg Config
 
-	if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
-		panic("failed to read config: " + err.Error())
+	if configPath == "" {
+		if err := cleanenv.ReadEnv(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to read config from environment: %w", err)
+		}
+	} else {
+		if _, err := os.Stat(configPath); os.IsNotExist(err) {
+			return nil, fmt.Errorf("config file does not exist: %s", configPath)
+		}
+
+		if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	if err := cfg.readSecretFiles(); err != nil {
+		return nil, fmt.Errorf("failed to read secrets:\n%w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config:\n%w", err)
+	}
+
+	return &cfg, nil
+}
+
+// secretFields lists the settings that can instead be read from the file named
+// by <ENV>_FILE, as Docker and Kubernetes secrets are mounted.
+func (c *Config) secretFields() []struct {
+	env   string
+	value *string
+} {
+	return []struct {
+		env   string
+		value *string
+	}{
+		{"DATABASE_URL", &c.Postgres.URL},
+		{"POSTGRES_PASSWORD", &c.Postgres.Password},
+	}
+}
+
+func (c *Config) readSecretFiles() error {
+	var errs []error
+
+	for _, secret := range c.secretFields() {
+		fileEnv := secret.env + "_FILE"
+
+		path, ok := os.LookupEnv(fileEnv)
+		if !ok {
+			continue
+		}
+
+		if _, ok := os.LookupEnv(secret.env); ok {
+			errs = append(errs, fmt.Errorf("%s and %s are both set, use only one", secret.env, fileEnv))
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", fileEnv, err))
+			continue
+		}
+
+		*secret.value = strings.TrimRight(string(data), "\r\n")
 	}
 
-	return &cfg
+	return errors.Join(errs...)
 }
 
 func fetchConfigPath() string {
@@ -0,0 +1,202 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	valid := func() Config {
+		return Config{
+			HTTPServer: HTTPConfig{Port: 8082},
+			Storage:    StorageConfig{Driver: DriverPostgres},
+			Postgres: PostgresConfig{
+				Host:            "localhost",
+				Port:            5432,
+				DBName:          "subscriptions",
+				MaxConns:        10,
+				MinConns:        2,
+				MigrationsPath:  "./migrations",
+				MigrationsTable: "schema_migrations",
+				Retry:           RetryConfig{Attempts: 1},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(c *Config) {},
+		},
+		{
+			name: "database url replaces connection fields",
+			modify: func(c *Config) {
+				c.Postgres.URL = "postgres://user:pass@db:5432/subscriptions"
+				c.Postgres.Host = ""
+				c.Postgres.DBName = ""
+			},
+		},
+		{
+			name:    "unknown log level",
+			modify:  func(c *Config) { c.LogLevel = "verbose" },
+			wantErr: "log_level (LOG_LEVEL)",
+		},
+		{
+			name:    "zero port",
+			modify:  func(c *Config) { c.HTTPServer.Port = 0 },
+			wantErr: "http_server.port (HTTP_PORT): 0 is out of range",
+		},
+		{
+			name:    "unknown driver",
+			modify:  func(c *Config) { c.Storage.Driver = "mysql" },
+			wantErr: "storage.driver (STORAGE_DRIVER)",
+		},
+		{
+			name:    "min conns above max conns",
+			modify:  func(c *Config) { c.Postgres.MinConns = 20 },
+			wantErr: "postgres.min_conns (POSTGRES_MIN_CONNS): 20 exceeds max_conns 10",
+		},
+		{
+			name:    "missing migrations path",
+			modify:  func(c *Config) { c.Postgres.MigrationsPath = "" },
+			wantErr: "postgres.migrations_path (POSTGRES_MIGRATIONS_PATH): must not be empty",
+		},
+		{
+			name:    "database url with wrong scheme",
+			modify:  func(c *Config) { c.Postgres.URL = "mysql://db/subscriptions" },
+			wantErr: "postgres.url (DATABASE_URL)",
+		},
+		{
+			name: "sqlite migrations directory missing",
+			modify: func(c *Config) {
+				c.Storage.Driver = DriverSQLite
+				c.SQLite = SQLiteConfig{Path: "db.sqlite", MigrationsPath: "./missing", MigrationsTable: "m"}
+			},
+			wantErr: "sqlite.migrations_path (SQLITE_MIGRATIONS_PATH)",
+		},
+		{
+			name: "tenancy without postgres",
+			modify: func(c *Config) {
+				c.Storage.Driver = DriverMemory
+				c.Tenancy.Enabled = true
+				c.Tenancy.Header = "X-Tenant-ID"
+			},
+			wantErr: "tenancy.enabled (TENANCY_ENABLED): requires storage.driver",
+		},
+		{
+			name:    "tenancy without header",
+			modify:  func(c *Config) { c.Tenancy.Enabled = true },
+			wantErr: "tenancy.header (TENANCY_HEADER)",
+		},
+		{
+			name:    "invalid default tenant",
+			modify:  func(c *Config) { c.Tenancy.DefaultTenant = "acme corp" },
+			wantErr: "tenancy.default_tenant (TENANCY_DEFAULT_TENANT)",
+		},
+		{
+			name:   "tenancy with postgres",
+			modify: func(c *Config) { c.Tenancy = TenancyConfig{Enabled: true, Header: "X-Tenant-ID"} },
+		},
+		{
+			name:    "admin server on the api port",
+			modify:  func(c *Config) { c.AdminServer = AdminConfig{Enabled: true, Port: 8082} },
+			wantErr: "admin_server.port (ADMIN_PORT)",
+		},
+		{
+			name:   "admin server disabled on the api port",
+			modify: func(c *Config) { c.AdminServer = AdminConfig{Port: 8082} },
+		},
+		{
+			name:    "negative cache size",
+			modify:  func(c *Config) { c.Cache.MaxSums = -1 },
+			wantErr: "cache.max_sums (CACHE_MAX_SUMS)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				cfg := valid()
+				tt.modify(&cfg)
+
+				err := cfg.Validate()
+				if tt.wantErr == "" {
+					assert.NoError(t, err)
+					return
+				}
+
+				assert.ErrorContains(t, err, tt.wantErr)
+			},
+		)
+	}
+}
+
+func TestValidate_ReportsEveryProblem(t *testing.T) {
+	cfg := Config{
+		HTTPServer: HTTPConfig{Port: 0},
+		Storage:    StorageConfig{Driver: DriverPostgres},
+		Postgres:   PostgresConfig{Host: "db", Port: 5432, DBName: "subs", MaxConns: 1, MinConns: 5},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+
+	var joined interface{ Unwrap() []error }
+	require.True(t, errors.As(err, &joined))
+	assert.Len(t, joined.Unwrap(), 5)
+}
+
+func TestPostgresConfig_DSN(t *testing.T) {
+	cfg := PostgresConfig{
+		Host:     "db",
+		Port:     5432,
+		User:     "app",
+		Password: "p@ss/word",
+		DBName:   "subscriptions",
+		SSLMode:  "disable",
+	}
+
+	assert.Equal(t, "postgres://app:p%40ss%2Fword@db:5432/subscriptions?sslmode=disable", cfg.DSN())
+
+	cfg.URL = "postgres://other@replica/subscriptions"
+	assert.Equal(t, cfg.URL, cfg.DSN())
+}
+
+func TestLoad_EnvironmentOnly(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(secret, []byte("s3cret\n"), 0o600))
+
+	t.Setenv("HTTP_PORT", "9090")
+	t.Setenv("STORAGE_DRIVER", DriverPostgres)
+	t.Setenv("POSTGRES_HOST", "db")
+	t.Setenv("POSTGRES_PASSWORD_FILE", secret)
+	t.Setenv("POSTGRES_RETRY_ATTEMPTS", "3")
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://a.example.com,https://b.example.com")
+
+	cfg, err := Load("")
+	require.NoError(t, err)
+
+	assert.Equal(t, 9090, cfg.HTTPServer.Port)
+	assert.Equal(t, "db", cfg.Postgres.Host)
+	assert.Equal(t, "s3cret", cfg.Postgres.Password)
+	assert.Equal(t, 3, cfg.Postgres.Retry.Attempts)
+	assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, cfg.CORS.AllowedOrigins)
+}
+
+func TestLoad_SecretSetTwice(t *testing.T) {
+	t.Setenv("HTTP_PORT", "9090")
+	t.Setenv("POSTGRES_PASSWORD", "inline")
+	t.Setenv("POSTGRES_PASSWORD_FILE", "/run/secrets/password")
+
+	_, err := Load("")
+	assert.ErrorContains(t, err, "POSTGRES_PASSWORD and POSTGRES_PASSWORD_FILE are both set")
+}
@@ -0,0 +1,226 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/salivare/subscriptions-service/internal/tenant"
+)
+
+// logLevels are the values accepted in log_level.
+var logLevels = []string{"trace", "debug", "info", "warn", "error"}
+
+// Validate checks the configuration and reports every problem at once,
+// naming each setting by its YAML path and environment variable.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	if c.LogLevel != "" && !slices.Contains(logLevels, c.LogLevel) {
+		v.addf("log_level", "LOG_LEVEL", "unknown level %q, want one of %v", c.LogLevel, logLevels)
+	}
+
+	c.HTTPServer.validate(v)
+	c.AdminServer.validate(v, c.HTTPServer)
+
+	switch c.Storage.Driver {
+	case DriverPostgres:
+		c.Postgres.validate(v)
+	case DriverSQLite:
+		c.SQLite.validate(v)
+	case DriverMemory:
+	default:
+		v.addf(
+			"storage.driver", "STORAGE_DRIVER", "unknown driver %q, want one of %v",
+			c.Storage.Driver, []string{DriverPostgres, DriverSQLite, DriverMemory},
+		)
+	}
+
+	if c.CORS.Enabled && len(c.CORS.AllowedOrigins) == 0 {
+		v.addf("cors.allowed_origins", "CORS_ALLOWED_ORIGINS", "must not be empty when cors is enabled")
+	}
+
+	c.Cache.validate(v)
+	c.Tenancy.validate(v, c.Storage.Driver)
+
+	return v.err()
+}
+
+func (c HTTPConfig) validate(v *validator) {
+	if c.Port < 1 || c.Port > 65535 {
+		v.addf("http_server.port", "HTTP_PORT", "%d is out of range 1-65535", c.Port)
+	}
+
+	v.nonNegative("http_server.timeout", "HTTP_TIMEOUT", c.Timeout)
+	v.nonNegative("http_server.idle_timeout", "HTTP_IDLE_TIMEOUT", c.IdleTimeout)
+	v.nonNegative("http_server.shutdown_timeout", "HTTP_SHUTDOWN_TIMEOUT", c.ShutdownTimeout)
+	v.nonNegative("http_server.read_timeout", "HTTP_READ_TIMEOUT", c.ReadTimeout)
+	v.nonNegative("http_server.write_timeout", "HTTP_WRITE_TIMEOUT", c.WriteTimeout)
+	v.nonNegative("http_server.read_header_timeout", "HTTP_READ_HEADER_TIMEOUT", c.ReadHeaderTimeout)
+
+	if c.MaxHeaderBytes < 0 {
+		v.addf("http_server.max_header_bytes", "HTTP_MAX_HEADER_BYTES", "must not be negative")
+	}
+}
+
+func (c AdminConfig) validate(v *validator, api HTTPConfig) {
+	if !c.Enabled {
+		return
+	}
+
+	if c.Port < 1 || c.Port > 65535 {
+		v.addf("admin_server.port", "ADMIN_PORT", "%d is out of range 1-65535", c.Port)
+	}
+
+	if c.Port == api.Port {
+		v.addf("admin_server.port", "ADMIN_PORT", "must differ from http_server.port")
+	}
+}
+
+func (c PostgresConfig) validate(v *validator) {
+	if c.URL != "" {
+		if err := validatePostgresURL(c.URL); err != nil {
+			v.addf("postgres.url", "DATABASE_URL", "%v", err)
+		}
+	} else {
+		if c.Host == "" {
+			v.addf("postgres.host", "POSTGRES_HOST", "must not be empty")
+		}
+
+		if c.Port < 1 || c.Port > 65535 {
+			v.addf("postgres.port", "POSTGRES_PORT", "%d is out of range 1-65535", c.Port)
+		}
+
+		if c.DBName == "" {
+			v.addf("postgres.dbname", "POSTGRES_DB", "must not be empty")
+		}
+	}
+
+	for i, dsn := range c.ReplicaDSNs {
+		if err := validatePostgresURL(dsn); err != nil {
+			v.addf(fmt.Sprintf("postgres.replica_dsns[%d]", i), "POSTGRES_REPLICA_DSNS", "%v", err)
+		}
+	}
+
+	if c.MaxConns < 0 {
+		v.addf("postgres.max_conns", "POSTGRES_MAX_CONNS", "must not be negative")
+	}
+
+	if c.MinConns < 0 {
+		v.addf("postgres.min_conns", "POSTGRES_MIN_CONNS", "must not be negative")
+	}
+
+	if c.MaxConns > 0 && c.MinConns > c.MaxConns {
+		v.addf("postgres.min_conns", "POSTGRES_MIN_CONNS", "%d exceeds max_conns %d", c.MinConns, c.MaxConns)
+	}
+
+	v.nonNegative("postgres.max_conn_idle_time", "POSTGRES_MAX_CONN_IDLE_TIME", c.MaxConnIdleTime)
+	v.nonNegative("postgres.max_conn_lifetime", "POSTGRES_MAX_CONN_LIFETIME", c.MaxConnLifetime)
+	v.nonNegative("postgres.health_check_period", "POSTGRES_HEALTH_CHECK_PERIOD", c.HealthCheckPeriod)
+
+	if c.MigrationsPath == "" {
+		v.addf("postgres.migrations_path", "POSTGRES_MIGRATIONS_PATH", "must not be empty")
+	}
+
+	if c.MigrationsTable == "" {
+		v.addf("postgres.migrations_table", "POSTGRES_MIGRATIONS_TABLE", "must not be empty")
+	}
+
+	if c.Retry.Attempts < 1 {
+		v.addf("postgres.retry.attempts", "POSTGRES_RETRY_ATTEMPTS", "must be at least 1")
+	}
+}
+
+func (c SQLiteConfig) validate(v *validator) {
+	if c.Path == "" {
+		v.addf("sqlite.path", "SQLITE_PATH", "must not be empty")
+	}
+
+	v.nonNegative("sqlite.busy_timeout", "SQLITE_BUSY_TIMEOUT", c.BusyTimeout)
+
+	// Migrations are applied on startup, so the directory has to be there.
+	if c.MigrationsPath == "" {
+		v.addf("sqlite.migrations_path", "SQLITE_MIGRATIONS_PATH", "must not be empty")
+	} else if info, err := os.Stat(c.MigrationsPath); err != nil || !info.IsDir() {
+		v.addf("sqlite.migrations_path", "SQLITE_MIGRATIONS_PATH", "%q is not a directory", c.MigrationsPath)
+	}
+
+	if c.MigrationsTable == "" {
+		v.addf("sqlite.migrations_table", "SQLITE_MIGRATIONS_TABLE", "must not be empty")
+	}
+}
+
+func (c CacheConfig) validate(v *validator) {
+	if c.MaxSubscriptions < 0 {
+		v.addf("cache.max_subscriptions", "CACHE_MAX_SUBSCRIPTIONS", "must not be negative")
+	}
+
+	if c.MaxSums < 0 {
+		v.addf("cache.max_sums", "CACHE_MAX_SUMS", "must not be negative")
+	}
+
+	v.nonNegative("cache.subscription_ttl", "CACHE_SUBSCRIPTION_TTL", c.SubscriptionTTL)
+	v.nonNegative("cache.sum_ttl", "CACHE_SUM_TTL", c.SumTTL)
+
+	if c.Enabled && c.NotifyChannel == "" {
+		v.addf("cache.notify_channel", "CACHE_NOTIFY_CHANNEL", "must not be empty when cache is enabled")
+	}
+}
+
+func (c TenancyConfig) validate(v *validator, driver string) {
+	if c.DefaultTenant != "" && tenant.Validate(c.DefaultTenant) != nil {
+		v.addf("tenancy.default_tenant", "TENANCY_DEFAULT_TENANT", "%q is not a valid tenant id", c.DefaultTenant)
+	}
+
+	if !c.Enabled {
+		return
+	}
+
+	// Only postgres enforces isolation, with row-level security.
+	if driver != DriverPostgres {
+		v.addf("tenancy.enabled", "TENANCY_ENABLED", "requires storage.driver %q", DriverPostgres)
+	}
+
+	if c.Header == "" {
+		v.addf("tenancy.header", "TENANCY_HEADER", "must be set when tenancy is enabled")
+	}
+}
+
+func validatePostgresURL(dsn string) error {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return errors.New("is not a valid URL")
+	}
+
+	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
+		return fmt.Errorf("scheme %q is not postgres:// or postgresql://", u.Scheme)
+	}
+
+	if u.Host == "" {
+		return errors.New("host is missing")
+	}
+
+	return nil
+}
+
+// validator collects problems so that all of them are reported together.
+type validator struct {
+	errs []error
+}
+
+func (v *validator) addf(field, env, format string, args ...any) {
+	v.errs = append(v.errs, fmt.Errorf("%s (%s): %s", field, env, fmt.Sprintf(format, args...)))
+}
+
+func (v *validator) nonNegative(field, env string, d time.Duration) {
+	if d < 0 {
+		v.addf(field, env, "must not be negative")
+	}
+}
+
+func (v *validator) err() error {
+	return errors.Join(v.errs...)
+}
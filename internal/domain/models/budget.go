@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Budget caps the monthly cost of a user's subscriptions, or of a single service if ServiceName is set.
+type Budget struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	ServiceName *string
+	Amount      int64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// BudgetStatus compares a budget with the actual cost of one month.
+type BudgetStatus struct {
+	Budget Budget
+	Month  time.Time
+	Spent  int64
+}
+
+// Exceeded reports whether the month cost more than the budget allows.
+func (s BudgetStatus) Exceeded() bool {
+	return s.Spent > s.Budget.Amount
+}
+
+// Remaining returns what is left of the budget, negative when it is exceeded.
+func (s BudgetStatus) Remaining() int64 {
+	return s.Budget.Amount - s.Spent
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserExport is everything held about a user, with the data derived from it.
+type UserExport struct {
+	UserID        uuid.UUID
+	ExportedAt    time.Time
+	Subscriptions []SubscriptionExport
+	Budgets       []Budget
+	Summary       UserSummary
+}
+
+// SubscriptionExport is a subscription with its price history, scheduled changes included.
+type SubscriptionExport struct {
+	Subscription Subscription
+	PriceChanges []PriceChange
+}
+
+// Erasure records that the rows of a user were erased. It keeps a hash of the user id
+// only. Every record hashes the one before it, so a record that was edited, removed
+// or reordered breaks the chain from that point on.
+type Erasure struct {
+	// Seq numbers the records from 1 without gaps.
+	Seq           int64
+	UserHash      string
+	Subscriptions int
+	Budgets       int
+	ErasedAt      time.Time
+	// PrevHash is the Hash of the previous record, empty for the first one.
+	PrevHash string
+	Hash     string
+}
+
+// HashUserID returns the hex SHA-256 of the canonical form of id, the only trace of
+// the user an erasure keeps.
+func HashUserID(id uuid.UUID) string {
+	sum := sha256.Sum256([]byte(id.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewErasure returns the record that follows prev, the zero Erasure for the first one.
+// erasedAt should already have the precision of the storage.
+func NewErasure(prev Erasure, userID uuid.UUID, subscriptions, budgets int, erasedAt time.Time) Erasure {
+	e := Erasure{
+		Seq:           prev.Seq + 1,
+		UserHash:      HashUserID(userID),
+		Subscriptions: subscriptions,
+		Budgets:       budgets,
+		ErasedAt:      erasedAt.UTC(),
+		PrevHash:      prev.Hash,
+	}
+	e.Hash = e.ComputeHash()
+
+	return e
+}
+
+// ComputeHash returns the hex SHA-256 of every field of e except Hash.
+func (e Erasure) ComputeHash() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf(
+		"%d|%s|%s|%d|%d|%s",
+		e.Seq,
+		e.PrevHash,
+		e.UserHash,
+		e.Subscriptions,
+		e.Budgets,
+		e.ErasedAt.UTC().Format(time.RFC3339Nano),
+	)))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyErasures checks a chain of records ordered by Seq, starting with the first one.
+// It returns an error naming the first record that does not follow from its predecessor.
+func VerifyErasures(chain []Erasure) error {
+	var prev Erasure
+
+	for _, e := range chain {
+		switch {
+		case e.Seq != prev.Seq+1:
+			return fmt.Errorf("erasure %d: expected seq %d", e.Seq, prev.Seq+1)
+		case e.PrevHash != prev.Hash:
+			return fmt.Errorf("erasure %d: previous hash does not match", e.Seq)
+		case e.Hash != e.ComputeHash():
+			return fmt.Errorf("erasure %d: hash does not match its contents", e.Seq)
+		}
+
+		prev = e
+	}
+
+	return nil
+}
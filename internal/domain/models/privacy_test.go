@@ -0,0 +1,81 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+)
+
+func TestVerifyErasures(t *testing.T) {
+	at := time.Date(2024, time.June, 1, 12, 0, 0, 123456000, time.UTC)
+
+	chain := func() []models.Erasure {
+		var res []models.Erasure
+		var prev models.Erasure
+		for i := range 3 {
+			prev = models.NewErasure(prev, uuid.New(), i, 1, at.Add(time.Duration(i)*time.Hour))
+			res = append(res, prev)
+		}
+		return res
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(c []models.Erasure) []models.Erasure
+		wantErr bool
+	}{
+		{name: "intact", mutate: func(c []models.Erasure) []models.Erasure { return c }},
+		{name: "empty", mutate: func([]models.Erasure) []models.Erasure { return nil }},
+		{
+			name:    "edited counts",
+			mutate:  func(c []models.Erasure) []models.Erasure { c[1].Subscriptions++; return c },
+			wantErr: true,
+		},
+		{
+			name: "rehashed edit",
+			mutate: func(c []models.Erasure) []models.Erasure {
+				c[1].UserHash = models.HashUserID(uuid.New())
+				c[1].Hash = c[1].ComputeHash()
+				return c
+			},
+			wantErr: true,
+		},
+		{
+			name:    "removed record",
+			mutate:  func(c []models.Erasure) []models.Erasure { return append(c[:1], c[2:]...) },
+			wantErr: true,
+		},
+		{
+			name:    "removed first record",
+			mutate:  func(c []models.Erasure) []models.Erasure { return c[1:] },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				err := models.VerifyErasures(tt.mutate(chain()))
+				if tt.wantErr {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+			},
+		)
+	}
+}
+
+func TestHashUserID(t *testing.T) {
+	id := uuid.MustParse("5b2e1c4a-8f3d-4e6a-9c1b-2d7f0a3e5c91")
+
+	assert.Equal(t, models.HashUserID(id), models.HashUserID(id))
+	assert.NotEqual(t, models.HashUserID(id), models.HashUserID(uuid.New()))
+	assert.NotContains(t, models.HashUserID(id), id.String())
+	assert.Len(t, models.HashUserID(id), 64)
+}
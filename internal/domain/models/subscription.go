@@ -13,8 +13,167 @@ type Subscription struct {
 	UserID      uuid.UUID
 	StartDate   time.Time
 	EndDate     *time.Time
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// TrialMonths is the number of months from StartDate billed at TrialPrice instead of Price.
+	TrialMonths int
+	TrialPrice  int64
+	// AllowOverlap exempts the subscription from the rule that periods of the same user
+	// and service must not overlap, for legitimate duplicates such as a second seat.
+	AllowOverlap bool
+	// Pauses is the pause history, oldest first.
+	Pauses    []Pause
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Pause excludes the months from PausedFrom up to, but not including, ResumedFrom from billing.
+// ResumedFrom is nil while the subscription is still paused.
+type Pause struct {
+	SubscriptionID uuid.UUID
+	PausedFrom     time.Time
+	ResumedFrom    *time.Time
+	CreatedAt      time.Time
+}
+
+// Covers reports whether month is inside the pause.
+func (p Pause) Covers(month time.Time) bool {
+	return !month.Before(p.PausedFrom) && (p.ResumedFrom == nil || month.Before(*p.ResumedFrom))
+}
+
+// OpenPause returns the pause that has not been resumed yet, if any.
+func (s Subscription) OpenPause() *Pause {
+	for i := range s.Pauses {
+		if s.Pauses[i].ResumedFrom == nil {
+			return &s.Pauses[i]
+		}
+	}
+
+	return nil
+}
+
+// PausedAt reports whether month is excluded from billing by a pause.
+func (s Subscription) PausedAt(month time.Time) bool {
+	for _, p := range s.Pauses {
+		if p.Covers(month) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Overlaps reports whether the periods of s and other share at least one month.
+// An open-ended period runs indefinitely.
+func (s Subscription) Overlaps(other Subscription) bool {
+	return (other.EndDate == nil || !s.StartDate.After(*other.EndDate)) &&
+		(s.EndDate == nil || !other.StartDate.After(*s.EndDate))
+}
+
+// TrialEnd returns the last month of the trial, or nil if there is no trial.
+func (s Subscription) TrialEnd() *time.Time {
+	if s.TrialMonths <= 0 {
+		return nil
+	}
+
+	end := s.StartDate.AddDate(0, s.TrialMonths-1, 0)
+	return &end
+}
+
+// InTrial reports whether month falls inside the trial window.
+func (s Subscription) InTrial(month time.Time) bool {
+	end := s.TrialEnd()
+	return end != nil && !month.Before(s.StartDate) && !month.After(*end)
+}
+
+// PriceAt returns the price billed for month: nothing while paused, the trial price inside
+// the trial window, otherwise the latest of changes in effect by month, or Price if none is.
+func (s Subscription) PriceAt(month time.Time, changes []PriceChange) int64 {
+	if s.PausedAt(month) {
+		return 0
+	}
+
+	if s.InTrial(month) {
+		return s.TrialPrice
+	}
+
+	return s.BasePriceAt(month, changes)
+}
+
+// BasePriceAt returns the regular price in effect for month, ignoring trial and pauses:
+// the latest of changes in effect by month, or Price if none is.
+func (s Subscription) BasePriceAt(month time.Time, changes []PriceChange) int64 {
+	var current *PriceChange
+	for i, c := range changes {
+		if c.EffectiveFrom.After(month) {
+			continue
+		}
+		if current == nil || c.EffectiveFrom.After(current.EffectiveFrom) {
+			current = &changes[i]
+		}
+	}
+
+	if current != nil {
+		return current.Price
+	}
+
+	if s.Price == nil {
+		return 0
+	}
+
+	return *s.Price
+}
+
+// ActiveIn reports whether month is inside [StartDate, EndDate].
+func (s Subscription) ActiveIn(month time.Time) bool {
+	return !month.Before(s.StartDate) && (s.EndDate == nil || !month.After(*s.EndDate))
+}
+
+// BillingMonth clamps month to the months the subscription is active in, so that a
+// subscription that has ended or not started yet is priced by its nearest active month.
+func (s Subscription) BillingMonth(month time.Time) time.Time {
+	if s.EndDate != nil && month.After(*s.EndDate) {
+		month = *s.EndDate
+	}
+
+	if month.Before(s.StartDate) {
+		month = s.StartDate
+	}
+
+	return month
+}
+
+// Continuation returns the subscription that continues s for userID from month on: same
+// service, end date and overlap flag, the regular price in effect for month and what is left
+// of the trial. s itself is expected to end the month before.
+func (s Subscription) Continuation(userID uuid.UUID, month time.Time, changes []PriceChange) Subscription {
+	price := s.BasePriceAt(month, changes)
+
+	next := Subscription{
+		ServiceName:  s.ServiceName,
+		Price:        &price,
+		UserID:       userID,
+		StartDate:    month,
+		EndDate:      s.EndDate,
+		AllowOverlap: s.AllowOverlap,
+	}
+
+	if end := s.TrialEnd(); end != nil && s.InTrial(month) {
+		next.TrialMonths = monthsBetween(month, *end) + 1
+		next.TrialPrice = s.TrialPrice
+	}
+
+	return next
+}
+
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
+
+// PriceChange sets the price of a subscription from EffectiveFrom on.
+type PriceChange struct {
+	SubscriptionID uuid.UUID
+	EffectiveFrom  time.Time
+	Price          int64
+	CreatedAt      time.Time
 }
 
 type SumFilter struct {
@@ -26,4 +185,26 @@ type SumFilter struct {
 
 	EndDateFrom *time.Time
 	EndDateTo   *time.Time
+
+	// AsOf is the month whose price every matching subscription contributes, see Subscription.BillingMonth.
+	AsOf *time.Time
+
+	// ActiveOnly leaves out subscriptions that are not active in the AsOf month,
+	// so that the sum is the actual cost of that month.
+	ActiveOnly bool
+}
+
+// MonthOf returns the first day of the month of t in UTC.
+func MonthOf(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// AsOfOrCurrent returns f.AsOf, or the current month if it is not set.
+func (f SumFilter) AsOfOrCurrent() time.Time {
+	if f.AsOf != nil {
+		return *f.AsOf
+	}
+
+	return MonthOf(time.Now())
 }
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserSummary describes the subscriptions of a user in Month.
+type UserSummary struct {
+	UserID uuid.UUID
+	Month  time.Time
+	// ActiveCount counts the subscriptions active in Month that are not paused.
+	ActiveCount int
+	// MonthlyCost is the cost of Month, priced like a sum with ActiveOnly.
+	MonthlyCost int64
+	// MostExpensive is the service that costs the most in Month, nil if nothing is billed.
+	MostExpensive *ServiceCost
+}
+
+// ServiceCost is what the subscriptions to one service cost in a month.
+type ServiceCost struct {
+	ServiceName string
+	Cost        int64
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// MaxTrialMonths is the longest trial a subscription can have.
+const MaxTrialMonths = 120
+
+// ErrInvalid is matched by every error returned from Subscription.Validate.
+var ErrInvalid = errors.New("invalid subscription")
+
+// FieldError is a violated invariant of a single field, named as in the API.
+type FieldError struct {
+	Field   string
+	Rule    string
+	Message string
+}
+
+// ValidationErrors lists every invariant a subscription violates.
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, f := range e {
+		msgs[i] = f.Message
+	}
+
+	return ErrInvalid.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+func (e ValidationErrors) Is(target error) bool {
+	return target == ErrInvalid
+}
+
+// Validate checks the invariants every stored subscription must hold, whichever path
+// it comes from. The database enforces the same rules with CHECK constraints.
+func (s Subscription) Validate() error {
+	var errs ValidationErrors
+
+	add := func(field, rule, msg string) {
+		errs = append(errs, FieldError{Field: field, Rule: rule, Message: msg})
+	}
+
+	if strings.TrimSpace(s.ServiceName) == "" {
+		add("service_name", "required", "service_name must not be blank")
+	}
+
+	if s.Price == nil {
+		add("price", "required", "price is required")
+	} else if *s.Price < 0 {
+		add("price", "min", "price must not be negative")
+	}
+
+	if s.UserID == uuid.Nil {
+		add("user_id", "required", "user_id is required")
+	}
+
+	if s.StartDate.IsZero() {
+		add("start_date", "required", "start_date is required")
+	}
+
+	if s.EndDate != nil && s.EndDate.Before(s.StartDate) {
+		add("end_date", "gtefield", "end_date must not be before start_date")
+	}
+
+	if s.TrialMonths < 0 || s.TrialMonths > MaxTrialMonths {
+		add("trial_months", "range", fmt.Sprintf("trial_months must be between 0 and %d", MaxTrialMonths))
+	}
+
+	if s.TrialPrice < 0 {
+		add("trial_price", "min", "trial_price must not be negative")
+	} else if s.TrialPrice != 0 && s.TrialMonths == 0 {
+		add("trial_price", "excluded_unless", "trial_price requires trial_months")
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
@@ -0,0 +1,78 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+)
+
+func TestSubscription_Validate(t *testing.T) {
+	start := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	before := start.AddDate(0, -1, 0)
+
+	valid := func() models.Subscription {
+		price := int64(400)
+		return models.Subscription{ServiceName: "Netflix", Price: &price, UserID: uuid.New(), StartDate: start}
+	}
+
+	tests := []struct {
+		name       string
+		mutate     func(s *models.Subscription)
+		wantFields []string
+	}{
+		{name: "valid", mutate: func(*models.Subscription) {}},
+		{name: "free", mutate: func(s *models.Subscription) { *s.Price = 0 }},
+		{name: "single month", mutate: func(s *models.Subscription) { s.EndDate = &start }},
+		{name: "end before start", mutate: func(s *models.Subscription) { s.EndDate = &before }, wantFields: []string{"end_date"}},
+		{name: "negative price", mutate: func(s *models.Subscription) { *s.Price = -1 }, wantFields: []string{"price"}},
+		{name: "missing price", mutate: func(s *models.Subscription) { s.Price = nil }, wantFields: []string{"price"}},
+		{name: "blank service", mutate: func(s *models.Subscription) { s.ServiceName = "  " }, wantFields: []string{"service_name"}},
+		{
+			name:       "bad trial",
+			mutate:     func(s *models.Subscription) { s.TrialMonths, s.TrialPrice = -1, -1 },
+			wantFields: []string{"trial_months", "trial_price"},
+		},
+		{name: "trial price", mutate: func(s *models.Subscription) { s.TrialMonths, s.TrialPrice = 2, 99 }},
+		{
+			name:       "trial price without trial",
+			mutate:     func(s *models.Subscription) { s.TrialPrice = 99 },
+			wantFields: []string{"trial_price"},
+		},
+		{
+			name:       "empty",
+			mutate:     func(s *models.Subscription) { *s = models.Subscription{} },
+			wantFields: []string{"service_name", "price", "user_id", "start_date"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				sub := valid()
+				tt.mutate(&sub)
+
+				err := sub.Validate()
+				if tt.wantFields == nil {
+					assert.NoError(t, err)
+					return
+				}
+
+				require.ErrorIs(t, err, models.ErrInvalid)
+
+				var errs models.ValidationErrors
+				require.ErrorAs(t, err, &errs)
+
+				fields := make([]string, len(errs))
+				for i, e := range errs {
+					fields[i] = e.Field
+				}
+				assert.Equal(t, tt.wantFields, fields)
+			},
+		)
+	}
+}
@@ -0,0 +1,80 @@
+package deletev1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	deletev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/budgets/v1/delete"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+	budgetSrv "github.com/salivare/subscriptions-service/internal/services/budget"
+)
+
+type stubBudget struct {
+	err error
+
+	gotID uuid.UUID
+}
+
+func (s *stubBudget) Delete(_ context.Context, id uuid.UUID) error {
+	s.gotID = id
+	return s.err
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "ok", id: uuid.NewString(), wantStatus: http.StatusOK},
+		{name: "invalid id", id: "not-a-uuid", wantStatus: http.StatusBadRequest, wantCode: response.CodeInvalidID},
+		{
+			name:       "not found",
+			id:         uuid.NewString(),
+			err:        budgetSrv.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   response.CodeBudgetNotFound,
+		},
+		{
+			name:       "storage failure",
+			id:         uuid.NewString(),
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubBudget{err: tt.err}
+
+				r := router.New()
+				r.DELETE("/api/v1/budget/{id}", deletev1.New(stub))
+
+				req := httptest.NewRequest(http.MethodDelete, "/api/v1/budget/"+tt.id, nil)
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					return
+				}
+
+				handlertest.Data(t, rec, tt.wantStatus, nil)
+				assert.Equal(t, tt.id, stub.gotID.String())
+			},
+		)
+	}
+}
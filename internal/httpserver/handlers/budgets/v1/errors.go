@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	budgetSrv "github.com/salivare/subscriptions-service/internal/services/budget"
+)
+
+// errorMappings lists the budget service errors that are the client's fault.
+// Anything not listed here is reported as 500.
+var errorMappings = render.ErrorMappings{
+	{Target: budgetSrv.ErrNotFound, Status: http.StatusNotFound, Code: response.CodeBudgetNotFound},
+	{Target: budgetSrv.ErrAlreadyExists, Status: http.StatusConflict, Code: response.CodeBudgetExists},
+	{Target: budgetSrv.ErrInvalidPeriod, Status: http.StatusBadRequest, Code: response.CodeInvalidDateRange},
+}
+
+// ProblemFromError maps an error returned by the budget service to a problem.
+func ProblemFromError(err error) response.Problem {
+	return errorMappings.Problem(err)
+}
+
+// RenderError logs err at a level matching its status and writes the mapped problem.
+func RenderError(w http.ResponseWriter, r *http.Request, log *slogx.Logger, err error) {
+	render.Error(w, r, log, err, ProblemFromError(err))
+}
@@ -0,0 +1,39 @@
+package v1_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/budgets/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	budgetSrv "github.com/salivare/subscriptions-service/internal/services/budget"
+)
+
+func TestProblemFromError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"not found", budgetSrv.ErrNotFound, http.StatusNotFound, response.CodeBudgetNotFound},
+		{"already exists", fmt.Errorf("save: %w", budgetSrv.ErrAlreadyExists), http.StatusConflict, response.CodeBudgetExists},
+		{"invalid period", budgetSrv.ErrInvalidPeriod, http.StatusBadRequest, response.CodeInvalidDateRange},
+		{"unknown", errors.New("connection refused"), http.StatusInternalServerError, response.CodeInternalError},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				p := v1.ProblemFromError(tt.err)
+
+				assert.Equal(t, tt.wantStatus, p.Status)
+				assert.Equal(t, tt.wantCode, p.Code)
+			},
+		)
+	}
+}
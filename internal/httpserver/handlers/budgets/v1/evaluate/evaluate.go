@@ -0,0 +1,89 @@
+package evaluatev1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/format"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/budgets/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Budget service interface
+type Budget interface {
+	Evaluate(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]models.BudgetStatus, error)
+}
+
+// New creates a handler comparing the budgets of a user with the actual monthly cost.
+//
+//	@Summary		Evaluate budgets
+//	@Description	For every month from from (the current month by default) to to (from by default), at most 36 months, compares each budget of the user with the cost of the subscriptions active in that month, priced like the sum: paused months cost nothing, trials cost the trial price, scheduled price changes apply. Exceeded budgets raise a budget_exceeded event once per month.
+//	@Tags			budgets
+//	@Produce		json
+//	@Param			user_id	query		string	true	"User ID (UUID)"
+//	@Param			from	query		string	false	"First month, MM-YYYY"
+//	@Param			to		query		string	false	"Last month, MM-YYYY"
+//	@Success		200		{object}	response.Response{data=[]response.BudgetMonthResponse}
+//	@Failure		400		{object}	response.Problem	"Invalid user_id or period"
+//	@Failure		500		{object}	response.Problem	"Internal server error"
+//	@Router			/api/v1/budget/evaluation [get]
+func New(budget Budget) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.budgets.evaluate.New"
+		ctx := r.Context()
+		log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+		query := r.URL.Query()
+
+		userID, err := uuid.Parse(query.Get("user_id"))
+		if err != nil {
+			log.WarnContext(ctx, "invalid user_id", slogx.Err(err))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidRequest, "user_id is required and must be a UUID"))
+			return
+		}
+
+		current := models.MonthOf(time.Now())
+		from, to := current, current
+
+		for _, p := range []struct {
+			name string
+			dst  *time.Time
+		}{{"from", &from}, {"to", &to}} {
+			s := query.Get(p.name)
+			if s == "" {
+				continue
+			}
+
+			t, err := time.Parse(format.MonthYear, s)
+			if err != nil {
+				log.WarnContext(ctx, "invalid month", slog.String("param", p.name), slogx.Err(err))
+				render.Problem(w, r, response.BadRequest(response.CodeInvalidRequest, p.name+" must be MM-YYYY"))
+				return
+			}
+			*p.dst = t
+		}
+
+		if query.Get("to") == "" {
+			to = from
+		}
+
+		statuses, err := budget.Evaluate(ctx, userID, from, to)
+		if err != nil {
+			v1.RenderError(w, r, log, err)
+			return
+		}
+
+		render.JSON(
+			w, r, response.Response{
+				Status: response.StatusOK,
+				Data:   response.ToBudgetMonthResponses(statuses),
+			},
+		)
+	}
+}
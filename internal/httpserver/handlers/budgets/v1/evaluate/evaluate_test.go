@@ -0,0 +1,143 @@
+package evaluatev1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	evaluatev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/budgets/v1/evaluate"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+	budgetSrv "github.com/salivare/subscriptions-service/internal/services/budget"
+)
+
+type stubBudget struct {
+	err      error
+	gotUser  uuid.UUID
+	gotFrom  time.Time
+	gotTo    time.Time
+	statuses []models.BudgetStatus
+}
+
+func (s *stubBudget) Evaluate(_ context.Context, userID uuid.UUID, from, to time.Time) ([]models.BudgetStatus, error) {
+	s.gotUser, s.gotFrom, s.gotTo = userID, from, to
+	return s.statuses, s.err
+}
+
+func TestNew(t *testing.T) {
+	uid := uuid.NewString()
+	current := models.MonthOf(time.Now())
+	jan := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		query      string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantFrom   time.Time
+		wantTo     time.Time
+	}{
+		{name: "current month by default", query: "?user_id=" + uid, wantStatus: http.StatusOK, wantFrom: current, wantTo: current},
+		{name: "range", query: "?user_id=" + uid + "&from=01-2025&to=03-2025", wantStatus: http.StatusOK, wantFrom: jan, wantTo: mar},
+		{name: "to defaults to from", query: "?user_id=" + uid + "&from=01-2025", wantStatus: http.StatusOK, wantFrom: jan, wantTo: jan},
+		{
+			name:       "missing user",
+			query:      "?from=01-2025",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidRequest,
+		},
+		{
+			name:       "invalid month",
+			query:      "?user_id=" + uid + "&to=2025-03",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidRequest,
+		},
+		{
+			name:       "invalid period",
+			query:      "?user_id=" + uid,
+			err:        budgetSrv.ErrInvalidPeriod,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidDateRange,
+		},
+		{
+			name:       "storage failure",
+			query:      "?user_id=" + uid,
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubBudget{err: tt.err}
+
+				r := router.New()
+				r.GET("/api/v1/budget/evaluation", evaluatev1.New(stub))
+
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/budget/evaluation"+tt.query, nil)
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					return
+				}
+
+				var got []response.BudgetMonthResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.Empty(t, got)
+
+				assert.Equal(t, uid, stub.gotUser.String())
+				assert.True(t, tt.wantFrom.Equal(stub.gotFrom), "from: %s", stub.gotFrom)
+				assert.True(t, tt.wantTo.Equal(stub.gotTo), "to: %s", stub.gotTo)
+			},
+		)
+	}
+
+	t.Run("groups statuses by month", func(t *testing.T) {
+		overall := models.Budget{ID: uuid.New(), Amount: 1000}
+		netflix := "Netflix"
+		perService := models.Budget{ID: uuid.New(), ServiceName: &netflix, Amount: 300}
+
+		stub := &stubBudget{statuses: []models.BudgetStatus{
+			{Budget: overall, Month: jan, Spent: 900},
+			{Budget: perService, Month: jan, Spent: 400},
+			{Budget: overall, Month: mar, Spent: 100},
+			{Budget: perService, Month: mar, Spent: 0},
+		}}
+
+		r := router.New()
+		r.GET("/api/v1/budget/evaluation", evaluatev1.New(stub))
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/budget/evaluation?user_id="+uid, nil))
+
+		var got []response.BudgetMonthResponse
+		handlertest.Data(t, rec, http.StatusOK, &got)
+
+		require.Len(t, got, 2)
+		assert.Equal(t, "01-2025", got[0].Month)
+		assert.True(t, got[0].Exceeded)
+		require.Len(t, got[0].Budgets, 2)
+		assert.False(t, got[0].Budgets[0].Exceeded)
+		assert.Equal(t, int64(100), got[0].Budgets[0].Remaining)
+		assert.True(t, got[0].Budgets[1].Exceeded)
+		assert.Equal(t, int64(-100), got[0].Budgets[1].Remaining)
+		assert.Equal(t, "03-2025", got[1].Month)
+		assert.False(t, got[1].Exceeded)
+	})
+}
@@ -0,0 +1,58 @@
+package getv1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/budgets/v1"
+	subv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Budget service interface
+type Budget interface {
+	Get(ctx context.Context, id uuid.UUID) (models.Budget, error)
+}
+
+// New creates a handler returning a budget.
+//
+//	@Summary		Get budget
+//	@Description	Returns a budget by ID
+//	@Tags			budgets
+//	@Produce		json
+//	@Param			id	path		string	true	"Budget ID (UUID)"
+//	@Success		200	{object}	response.BudgetResponse
+//	@Failure		400	{object}	response.Problem	"Invalid ID"
+//	@Failure		404	{object}	response.Problem	"Budget not found"
+//	@Failure		500	{object}	response.Problem	"Internal server error"
+//	@Router			/api/v1/budget/{id} [get]
+func New(budget Budget) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.budgets.get.New"
+		ctx := r.Context()
+		log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+		id, ok := subv1.ExtractID(w, r, log)
+		if !ok {
+			return
+		}
+
+		b, err := budget.Get(ctx, id)
+		if err != nil {
+			v1.RenderError(w, r, log, err)
+			return
+		}
+
+		render.JSON(
+			w, r, response.Response{
+				Status: response.StatusOK,
+				Data:   response.ToBudgetResponse(b),
+			},
+		)
+	}
+}
@@ -0,0 +1,91 @@
+package getv1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	getv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/budgets/v1/get"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+	budgetSrv "github.com/salivare/subscriptions-service/internal/services/budget"
+)
+
+type stubBudget struct {
+	budget models.Budget
+	err    error
+
+	gotID uuid.UUID
+}
+
+func (s *stubBudget) Get(_ context.Context, id uuid.UUID) (models.Budget, error) {
+	s.gotID = id
+	if s.err != nil {
+		return models.Budget{}, s.err
+	}
+
+	return s.budget, nil
+}
+
+func TestNew(t *testing.T) {
+	netflix := "Netflix"
+	budget := models.Budget{ID: uuid.New(), UserID: uuid.New(), ServiceName: &netflix, Amount: 1000}
+
+	tests := []struct {
+		name       string
+		id         string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "ok", id: budget.ID.String(), wantStatus: http.StatusOK},
+		{name: "invalid id", id: "not-a-uuid", wantStatus: http.StatusBadRequest, wantCode: response.CodeInvalidID},
+		{
+			name:       "not found",
+			id:         uuid.NewString(),
+			err:        budgetSrv.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   response.CodeBudgetNotFound,
+		},
+		{
+			name:       "storage failure",
+			id:         uuid.NewString(),
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubBudget{budget: budget, err: tt.err}
+
+				r := router.New()
+				r.GET("/api/v1/budget/{id}", getv1.New(stub))
+
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/budget/"+tt.id, nil)
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					return
+				}
+
+				var got response.BudgetResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.Equal(t, response.ToBudgetResponse(budget), got)
+				assert.Equal(t, tt.id, stub.gotID.String())
+			},
+		)
+	}
+}
@@ -0,0 +1,63 @@
+package listv1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/budgets/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Budget service interface
+type Budget interface {
+	List(ctx context.Context, userID uuid.UUID) ([]models.Budget, error)
+}
+
+// New creates a handler listing the budgets of a user.
+//
+//	@Summary		List budgets
+//	@Description	Budgets of a user, the overall budget first
+//	@Tags			budgets
+//	@Produce		json
+//	@Param			user_id	query		string	true	"User ID (UUID)"
+//	@Success		200		{object}	response.Response{data=[]response.BudgetResponse}
+//	@Failure		400		{object}	response.Problem	"Missing or invalid user_id"
+//	@Failure		500		{object}	response.Problem	"Internal server error"
+//	@Router			/api/v1/budget [get]
+func New(budget Budget) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.budgets.list.New"
+		ctx := r.Context()
+		log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+		userID, err := uuid.Parse(r.URL.Query().Get("user_id"))
+		if err != nil {
+			log.WarnContext(ctx, "invalid user_id", slogx.Err(err))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidRequest, "user_id is required and must be a UUID"))
+			return
+		}
+
+		budgets, err := budget.List(ctx, userID)
+		if err != nil {
+			v1.RenderError(w, r, log, err)
+			return
+		}
+
+		data := make([]response.BudgetResponse, 0, len(budgets))
+		for _, b := range budgets {
+			data = append(data, response.ToBudgetResponse(b))
+		}
+
+		render.JSON(
+			w, r, response.Response{
+				Status: response.StatusOK,
+				Data:   data,
+			},
+		)
+	}
+}
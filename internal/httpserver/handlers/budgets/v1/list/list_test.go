@@ -0,0 +1,99 @@
+package listv1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	listv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/budgets/v1/list"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+)
+
+type stubBudget struct {
+	budgets []models.Budget
+	err     error
+
+	gotUser uuid.UUID
+}
+
+func (s *stubBudget) List(_ context.Context, userID uuid.UUID) ([]models.Budget, error) {
+	s.gotUser = userID
+	return s.budgets, s.err
+}
+
+func TestNew(t *testing.T) {
+	userID := uuid.New()
+	budget := models.Budget{ID: uuid.New(), UserID: userID, Amount: 1000}
+
+	tests := []struct {
+		name       string
+		query      string
+		budgets    []models.Budget
+		err        error
+		wantStatus int
+		wantCode   string
+		want       []response.BudgetResponse
+	}{
+		{
+			name:       "ok",
+			query:      "?user_id=" + userID.String(),
+			budgets:    []models.Budget{budget},
+			wantStatus: http.StatusOK,
+			want:       []response.BudgetResponse{response.ToBudgetResponse(budget)},
+		},
+		{
+			name:       "no budgets",
+			query:      "?user_id=" + userID.String(),
+			wantStatus: http.StatusOK,
+			want:       []response.BudgetResponse{},
+		},
+		{name: "missing user", wantStatus: http.StatusBadRequest, wantCode: response.CodeInvalidRequest},
+		{
+			name:       "invalid user",
+			query:      "?user_id=nope",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidRequest,
+		},
+		{
+			name:       "storage failure",
+			query:      "?user_id=" + userID.String(),
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubBudget{budgets: tt.budgets, err: tt.err}
+
+				r := router.New()
+				r.GET("/api/v1/budget", listv1.New(stub))
+
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/budget"+tt.query, nil)
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					return
+				}
+
+				var got []response.BudgetResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.Equal(t, tt.want, got)
+				assert.Equal(t, userID, stub.gotUser)
+			},
+		)
+	}
+}
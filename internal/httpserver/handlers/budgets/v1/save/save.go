@@ -0,0 +1,71 @@
+package savev1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/budgets/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/request"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Budget service interface
+type Budget interface {
+	Save(ctx context.Context, budget models.Budget) (models.Budget, error)
+}
+
+// New creates a handler for creating a budget.
+//
+//	@Summary		Create budget
+//	@Description	Creates a monthly budget for a user, overall or for one service. A user has at most one overall budget and one per service.
+//	@Tags			budgets
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.CreateBudgetRequest	true	"Budget data"
+//	@Success		200		{object}	response.BudgetResponse
+//	@Failure		400		{object}	response.Problem	"Invalid request"
+//	@Failure		409		{object}	response.Problem	"Budget already exists"
+//	@Failure		500		{object}	response.Problem	"Internal server error"
+//	@Router			/api/v1/budget [post]
+func New(budget Budget) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.budgets.save.New"
+		ctx := r.Context()
+		log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+		var req request.CreateBudgetRequest
+		if err := render.Bind(r, &req); err != nil {
+			log.ErrorContext(ctx, "invalid json", slogx.Err(err))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidJSON, "invalid json"))
+			return
+		}
+
+		if !request.ValidateStruct(w, r, &req) {
+			return
+		}
+
+		b, err := req.ToModel()
+		if err != nil {
+			log.ErrorContext(ctx, "failed to convert request to model", slogx.Err(err))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidRequest, err.Error()))
+			return
+		}
+
+		saved, err := budget.Save(ctx, b)
+		if err != nil {
+			v1.RenderError(w, r, log, err)
+			return
+		}
+
+		render.JSON(
+			w, r, response.Response{
+				Status: response.StatusOK,
+				Data:   response.ToBudgetResponse(saved),
+			},
+		)
+	}
+}
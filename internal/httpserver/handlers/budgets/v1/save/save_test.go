@@ -0,0 +1,137 @@
+package savev1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	savev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/budgets/v1/save"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+	budgetSrv "github.com/salivare/subscriptions-service/internal/services/budget"
+)
+
+type stubBudget struct {
+	err error
+
+	gotBudget models.Budget
+}
+
+func (s *stubBudget) Save(_ context.Context, b models.Budget) (models.Budget, error) {
+	s.gotBudget = b
+	if s.err != nil {
+		return models.Budget{}, s.err
+	}
+
+	b.ID = uuid.New()
+	b.CreatedAt = time.Now()
+	b.UpdatedAt = b.CreatedAt
+
+	return b, nil
+}
+
+func TestNew(t *testing.T) {
+	userID := uuid.New()
+	netflix := "Netflix"
+	valid := `{"user_id": "` + userID.String() + `", "service_name": "Netflix", "amount": 1000}`
+
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+		wantCode   string
+		want       models.Budget
+	}{
+		{
+			name:       "ok",
+			body:       valid,
+			wantStatus: http.StatusOK,
+			want:       models.Budget{UserID: userID, ServiceName: &netflix, Amount: 1000},
+		},
+		{
+			name:       "overall budget",
+			body:       `{"user_id": "` + userID.String() + `", "amount": 0}`,
+			wantStatus: http.StatusOK,
+			want:       models.Budget{UserID: userID},
+		},
+		{name: "invalid json", body: `{`, wantStatus: http.StatusBadRequest, wantCode: response.CodeInvalidJSON},
+		{
+			name:       "missing amount",
+			body:       `{"user_id": "` + uuid.NewString() + `"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeValidationFailed,
+		},
+		{
+			name:       "negative amount",
+			body:       `{"user_id": "` + uuid.NewString() + `", "amount": -1}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeValidationFailed,
+		},
+		{
+			name:       "invalid user",
+			body:       `{"user_id": "nope", "amount": 1}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeValidationFailed,
+		},
+		{
+			name:       "empty service",
+			body:       `{"user_id": "` + uuid.NewString() + `", "service_name": "", "amount": 1}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeValidationFailed,
+		},
+		{
+			name:       "already exists",
+			body:       valid,
+			err:        budgetSrv.ErrAlreadyExists,
+			wantStatus: http.StatusConflict,
+			wantCode:   response.CodeBudgetExists,
+		},
+		{
+			name:       "storage failure",
+			body:       valid,
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubBudget{err: tt.err}
+
+				r := router.New()
+				r.POST("/api/v1/budget", savev1.New(stub))
+
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/budget", strings.NewReader(tt.body))
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					return
+				}
+
+				var got response.BudgetResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.NotEqual(t, uuid.Nil, got.ID)
+				assert.Equal(t, tt.want.UserID, got.UserID)
+				assert.Equal(t, tt.want.ServiceName, got.ServiceName)
+				assert.Equal(t, tt.want.Amount, got.Amount)
+
+				assert.Equal(t, tt.want, stub.gotBudget)
+			},
+		)
+	}
+}
@@ -0,0 +1,72 @@
+package updatev1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/budgets/v1"
+	subv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/request"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Budget service interface
+type Budget interface {
+	Update(ctx context.Context, id uuid.UUID, amount int64) (models.Budget, error)
+}
+
+// New creates a handler changing the amount of a budget.
+//
+//	@Summary		Update budget
+//	@Description	Changes the monthly amount of a budget. To change the service of a budget, delete it and create a new one.
+//	@Tags			budgets
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Budget ID (UUID)"
+//	@Param			request	body		request.UpdateBudgetRequest	true	"New amount"
+//	@Success		200		{object}	response.BudgetResponse
+//	@Failure		400		{object}	response.Problem	"Invalid request"
+//	@Failure		404		{object}	response.Problem	"Budget not found"
+//	@Failure		500		{object}	response.Problem	"Internal server error"
+//	@Router			/api/v1/budget/{id} [patch]
+func New(budget Budget) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.budgets.update.New"
+		ctx := r.Context()
+		log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+		id, ok := subv1.ExtractID(w, r, log)
+		if !ok {
+			return
+		}
+
+		var req request.UpdateBudgetRequest
+		if err := render.Bind(r, &req); err != nil {
+			log.ErrorContext(ctx, "invalid json", slogx.Err(err))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidJSON, "invalid json"))
+			return
+		}
+
+		if !request.ValidateStruct(w, r, &req) {
+			return
+		}
+
+		b, err := budget.Update(ctx, id, *req.Amount)
+		if err != nil {
+			v1.RenderError(w, r, log, err)
+			return
+		}
+
+		render.JSON(
+			w, r, response.Response{
+				Status: response.StatusOK,
+				Data:   response.ToBudgetResponse(b),
+			},
+		)
+	}
+}
@@ -0,0 +1,115 @@
+package updatev1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	updatev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/budgets/v1/update"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+	budgetSrv "github.com/salivare/subscriptions-service/internal/services/budget"
+)
+
+type stubBudget struct {
+	err error
+
+	gotID     uuid.UUID
+	gotAmount int64
+}
+
+func (s *stubBudget) Update(_ context.Context, id uuid.UUID, amount int64) (models.Budget, error) {
+	s.gotID, s.gotAmount = id, amount
+	if s.err != nil {
+		return models.Budget{}, s.err
+	}
+
+	return models.Budget{ID: id, Amount: amount}, nil
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		body       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "ok", id: uuid.NewString(), body: `{"amount": 1500}`, wantStatus: http.StatusOK},
+		{
+			name:       "invalid id",
+			id:         "not-a-uuid",
+			body:       `{"amount": 1500}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidID,
+		},
+		{
+			name:       "invalid json",
+			id:         uuid.NewString(),
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidJSON,
+		},
+		{
+			name:       "missing amount",
+			id:         uuid.NewString(),
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeValidationFailed,
+		},
+		{
+			name:       "not found",
+			id:         uuid.NewString(),
+			body:       `{"amount": 1}`,
+			err:        budgetSrv.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   response.CodeBudgetNotFound,
+		},
+		{
+			name:       "storage failure",
+			id:         uuid.NewString(),
+			body:       `{"amount": 1}`,
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubBudget{err: tt.err}
+
+				r := router.New()
+				r.PATCH("/api/v1/budget/{id}", updatev1.New(stub))
+
+				req := httptest.NewRequest(http.MethodPatch, "/api/v1/budget/"+tt.id, strings.NewReader(tt.body))
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					return
+				}
+
+				var got response.BudgetResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.Equal(t, tt.id, got.ID.String())
+				assert.Equal(t, int64(1500), got.Amount)
+
+				assert.Equal(t, tt.id, stub.gotID.String())
+				assert.Equal(t, int64(1500), stub.gotAmount)
+			},
+		)
+	}
+}
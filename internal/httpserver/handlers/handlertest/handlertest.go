@@ -0,0 +1,51 @@
+// Package handlertest contains the response assertions shared by the handler tests.
+package handlertest
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Problem checks that rec holds an application/problem+json body with status and code
+// and returns it for further checks.
+func Problem(t *testing.T, rec *httptest.ResponseRecorder, status int, code string) response.Problem {
+	t.Helper()
+
+	require.Equal(t, status, rec.Code, rec.Body.String())
+	assert.Equal(t, response.ProblemContentType, rec.Header().Get("Content-Type"))
+
+	var p response.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+
+	assert.Equal(t, status, p.Status)
+	assert.Equal(t, code, p.Code)
+	assert.NotEmpty(t, p.Type)
+
+	return p
+}
+
+// Data checks that rec holds a successful JSON envelope with status and decodes its
+// data member into v. v may be nil for responses without data.
+func Data(t *testing.T, rec *httptest.ResponseRecorder, status int, v any) {
+	t.Helper()
+
+	require.Equal(t, status, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var envelope struct {
+		Status string          `json:"status"`
+		Data   json.RawMessage `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+	assert.Equal(t, response.StatusOK, envelope.Status)
+
+	if v != nil {
+		require.NoError(t, json.Unmarshal(envelope.Data, v))
+	}
+}
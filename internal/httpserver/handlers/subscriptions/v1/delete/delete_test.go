@@ -0,0 +1,82 @@
+package deletev1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	deletev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/delete"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+	subSrv "github.com/salivare/subscriptions-service/internal/services/subscription"
+)
+
+type stubSubscription struct {
+	err error
+
+	gotID uuid.UUID
+}
+
+func (s *stubSubscription) Delete(_ context.Context, id uuid.UUID) error {
+	s.gotID = id
+	return s.err
+}
+
+func TestNew(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name       string
+		id         string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "ok", id: id.String(), wantStatus: http.StatusOK},
+		{name: "invalid id", id: "not-a-uuid", wantStatus: http.StatusBadRequest, wantCode: response.CodeInvalidID},
+		{
+			name:       "not found",
+			id:         uuid.NewString(),
+			err:        subSrv.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   response.CodeSubscriptionNotFound,
+		},
+		{
+			name:       "storage failure",
+			id:         uuid.NewString(),
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubSubscription{err: tt.err}
+
+				r := router.New()
+				r.DELETE("/api/v1/subscription/{id}", deletev1.New(stub))
+
+				req := httptest.NewRequest(http.MethodDelete, "/api/v1/subscription/"+tt.id, nil)
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					return
+				}
+
+				handlertest.Data(t, rec, tt.wantStatus, nil)
+				assert.Equal(t, id, stub.gotID)
+			},
+		)
+	}
+}
@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/jsonpatch"
+	"github.com/salivare/subscriptions-service/internal/services/privacy"
+	subSrv "github.com/salivare/subscriptions-service/internal/services/subscription"
+)
+
+// errorMappings lists the service-level sentinel errors that are the client's fault.
+// Anything not listed here is reported as 500.
+var errorMappings = render.ErrorMappings{
+	{Target: subSrv.ErrNotFound, Status: http.StatusNotFound, Code: response.CodeSubscriptionNotFound},
+	{Target: subSrv.ErrAlreadyExists, Status: http.StatusConflict, Code: response.CodeSubscriptionExists},
+	{Target: subSrv.ErrStartDateInFuture, Status: http.StatusBadRequest, Code: response.CodeInvalidDateRange},
+	{Target: subSrv.ErrEndDateInFuture, Status: http.StatusBadRequest, Code: response.CodeInvalidDateRange},
+	{Target: subSrv.ErrInvalidDateRange, Status: http.StatusBadRequest, Code: response.CodeInvalidDateRange},
+	{Target: subSrv.ErrInvalidInput, Status: http.StatusBadRequest, Code: response.CodeInvalidRequest},
+	{Target: subSrv.ErrPriceChangeInPast, Status: http.StatusBadRequest, Code: response.CodeInvalidDateRange},
+	{Target: subSrv.ErrOutsidePeriod, Status: http.StatusBadRequest, Code: response.CodeInvalidDateRange},
+	{Target: subSrv.ErrAlreadyPaused, Status: http.StatusConflict, Code: response.CodeAlreadyPaused},
+	{Target: subSrv.ErrNotPaused, Status: http.StatusConflict, Code: response.CodeNotPaused},
+	{Target: subSrv.ErrOverlap, Status: http.StatusConflict, Code: response.CodeSubscriptionOverlap},
+	{Target: subSrv.ErrModified, Status: http.StatusConflict, Code: response.CodeSubscriptionModified},
+	{Target: jsonpatch.ErrTestFailed, Status: http.StatusConflict, Code: response.CodePatchTestFailed},
+	{Target: jsonpatch.ErrInvalid, Status: http.StatusBadRequest, Code: response.CodeInvalidPatch},
+	{Target: privacy.ErrNotErased, Status: http.StatusNotFound, Code: response.CodeErasureNotFound},
+}
+
+// ProblemFromError maps an error returned by the subscription service to a problem.
+// Violated domain invariants are listed per field, like request validation errors.
+func ProblemFromError(err error) response.Problem {
+	var invalid models.ValidationErrors
+	if errors.As(err, &invalid) {
+		return validationProblem(invalid)
+	}
+
+	p := errorMappings.Problem(err)
+
+	var overlap *subSrv.OverlapError
+	if errors.As(err, &overlap) {
+		p.Detail = overlap.Error()
+	}
+
+	return p
+}
+
+func validationProblem(errs models.ValidationErrors) response.Problem {
+	fields := make([]response.FieldError, 0, len(errs))
+	for _, e := range errs {
+		fields = append(fields, response.FieldError{Field: e.Field, Rule: e.Rule, Message: e.Message})
+	}
+
+	p := response.BadRequest(response.CodeValidationFailed, "subscription validation failed")
+	p.Errors = fields
+
+	return p
+}
+
+// RenderError logs err at a level matching its status and writes the mapped problem.
+func RenderError(w http.ResponseWriter, r *http.Request, log *slogx.Logger, err error) {
+	render.Error(w, r, log, err, ProblemFromError(err))
+}
@@ -0,0 +1,114 @@
+package v1_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/jsonpatch"
+	"github.com/salivare/subscriptions-service/internal/services/privacy"
+	subSrv "github.com/salivare/subscriptions-service/internal/services/subscription"
+)
+
+func TestProblemFromError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"not found", subSrv.ErrNotFound, http.StatusNotFound, response.CodeSubscriptionNotFound},
+		{"already exists", subSrv.ErrAlreadyExists, http.StatusConflict, response.CodeSubscriptionExists},
+		{"start date in future", subSrv.ErrStartDateInFuture, http.StatusBadRequest, response.CodeInvalidDateRange},
+		{"end date in future", subSrv.ErrEndDateInFuture, http.StatusBadRequest, response.CodeInvalidDateRange},
+		{
+			"wrapped date range",
+			fmt.Errorf("%w: end_date_to must be >= end_date_from", subSrv.ErrInvalidDateRange),
+			http.StatusBadRequest,
+			response.CodeInvalidDateRange,
+		},
+		{"invalid input", subSrv.ErrInvalidInput, http.StatusBadRequest, response.CodeInvalidRequest},
+		{"price change in past", subSrv.ErrPriceChangeInPast, http.StatusBadRequest, response.CodeInvalidDateRange},
+		{"outside period", subSrv.ErrOutsidePeriod, http.StatusBadRequest, response.CodeInvalidDateRange},
+		{"already paused", subSrv.ErrAlreadyPaused, http.StatusConflict, response.CodeAlreadyPaused},
+		{"not paused", subSrv.ErrNotPaused, http.StatusConflict, response.CodeNotPaused},
+		{"overlap", &subSrv.OverlapError{ConflictingID: uuid.New()}, http.StatusConflict, response.CodeSubscriptionOverlap},
+		{
+			"invalid subscription",
+			fmt.Errorf("%w: %w", subSrv.ErrInvalidInput, models.Subscription{}.Validate()),
+			http.StatusBadRequest,
+			response.CodeValidationFailed,
+		},
+		{"patch test failed", fmt.Errorf("operation 0 (test /price): %w", jsonpatch.ErrTestFailed), http.StatusConflict, response.CodePatchTestFailed},
+		{"invalid patch", fmt.Errorf("operation 0 (remove /x): %w", jsonpatch.ErrInvalid), http.StatusBadRequest, response.CodeInvalidPatch},
+		{"not erased", privacy.ErrNotErased, http.StatusNotFound, response.CodeErasureNotFound},
+		{"unknown", errors.New("connection refused"), http.StatusInternalServerError, response.CodeInternalError},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				p := v1.ProblemFromError(tt.err)
+
+				assert.Equal(t, tt.wantStatus, p.Status)
+				assert.Equal(t, tt.wantCode, p.Code)
+			},
+		)
+	}
+}
+
+func TestProblemFromError_ListsViolatedFields(t *testing.T) {
+	price := int64(400)
+	start := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, -1, 0)
+
+	sub := models.Subscription{ServiceName: "Netflix", Price: &price, UserID: uuid.New(), StartDate: start, EndDate: &end}
+
+	p := v1.ProblemFromError(fmt.Errorf("%w: %w", subSrv.ErrInvalidInput, sub.Validate()))
+
+	require.Len(t, p.Errors, 1)
+	assert.Equal(t, "end_date", p.Errors[0].Field)
+}
+
+func TestProblemFromError_Detail(t *testing.T) {
+	conflicting := uuid.New()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantDetail string
+	}{
+		{
+			name:       "op prefix is not shown",
+			err:        fmt.Errorf("services.subscriptions.Get: %w", subSrv.ErrNotFound),
+			wantDetail: subSrv.ErrNotFound.Error(),
+		},
+		{
+			name:       "wrapped cause is not shown",
+			err:        fmt.Errorf("operation 0 (remove /x): %w: %w", jsonpatch.ErrInvalid, errors.New("decode: unexpected EOF")),
+			wantDetail: jsonpatch.ErrInvalid.Error(),
+		},
+		{
+			name:       "overlap names the conflicting subscription",
+			err:        fmt.Errorf("services.subscriptions.Save: %w", &subSrv.OverlapError{ConflictingID: conflicting}),
+			wantDetail: subSrv.ErrOverlap.Error() + ": conflicts with subscription " + conflicting.String(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.wantDetail, v1.ProblemFromError(tt.err).Detail)
+			},
+		)
+	}
+}
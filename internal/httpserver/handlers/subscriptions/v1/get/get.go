@@ -2,7 +2,6 @@ package getv1
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 	"net/http"
 
@@ -12,7 +11,6 @@ import (
 	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
 	"github.com/salivare/subscriptions-service/internal/httpserver/render"
 	"github.com/salivare/subscriptions-service/internal/httpserver/response"
-	subSrv "github.com/salivare/subscriptions-service/internal/services/subscription"
 )
 
 type Subscription interface {
@@ -28,9 +26,9 @@ type Subscription interface {
 //	@Produce		json
 //	@Param			id	path		string				true	"Subscription ID (UUID)"
 //	@Success		200	{object}	response.Response	"Subscription data"
-//	@Failure		400	{object}	response.Response	"Invalid ID"
-//	@Failure		404	{object}	response.Response	"Subscription not found"
-//	@Failure		500	{object}	response.Response	"Internal error"
+//	@Failure		400	{object}	response.Problem	"Invalid ID"
+//	@Failure		404	{object}	response.Problem	"Subscription not found"
+//	@Failure		500	{object}	response.Problem	"Internal error"
 //	@Router			/api/v1/subscription/{id} [get]
 func New(subscription Subscription) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -46,20 +44,7 @@ func New(subscription Subscription) http.HandlerFunc {
 		sub, err := subscription.Get(ctx, id)
 
 		if err != nil {
-			if errors.Is(err, subSrv.ErrNotFound) {
-				log.ErrorContext(ctx, "subscription not found", slogx.Err(err))
-				render.JSON(
-					w, r, response.Response{
-						Status: response.StatusError,
-						Error:  response.ErrNotFound,
-						Code:   http.StatusNotFound,
-					},
-				)
-				return
-			}
-
-			log.ErrorContext(ctx, "failed to get subscription", slogx.Err(err))
-			render.JSON(w, r, response.Internal("internal error"))
+			v1.RenderError(w, r, log, err)
 			return
 		}
 
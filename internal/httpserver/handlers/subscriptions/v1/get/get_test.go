@@ -0,0 +1,94 @@
+package getv1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	getv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/get"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+	subSrv "github.com/salivare/subscriptions-service/internal/services/subscription"
+)
+
+type stubSubscription struct {
+	sub models.Subscription
+	err error
+
+	gotID uuid.UUID
+}
+
+func (s *stubSubscription) Get(_ context.Context, id uuid.UUID) (models.Subscription, error) {
+	s.gotID = id
+	return s.sub, s.err
+}
+
+func TestNew(t *testing.T) {
+	price := int64(400)
+	sub := models.Subscription{
+		ID:          uuid.New(),
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      uuid.New(),
+		StartDate:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "ok", id: sub.ID.String(), wantStatus: http.StatusOK},
+		{name: "invalid id", id: "not-a-uuid", wantStatus: http.StatusBadRequest, wantCode: response.CodeInvalidID},
+		{
+			name:       "not found",
+			id:         uuid.NewString(),
+			err:        subSrv.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   response.CodeSubscriptionNotFound,
+		},
+		{
+			name:       "storage failure",
+			id:         uuid.NewString(),
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubSubscription{sub: sub, err: tt.err}
+
+				r := router.New()
+				r.GET("/api/v1/subscription/{id}", getv1.New(stub))
+
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/subscription/"+tt.id, nil)
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					return
+				}
+
+				var got response.SubscriptionResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.Equal(t, sub.ID, stub.gotID)
+				assert.Equal(t, response.ToSubscriptionResponse(sub), got)
+			},
+		)
+	}
+}
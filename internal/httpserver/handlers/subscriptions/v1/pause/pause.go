@@ -0,0 +1,83 @@
+package pausev1
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/request"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Subscription service interface
+type Subscription interface {
+	Pause(ctx context.Context, id uuid.UUID, month *time.Time) (models.Subscription, error)
+}
+
+// New creates a handler that pauses a subscription.
+//
+//	@Summary		Pause subscription
+//	@Description	Stops billing from the month in from (default: current month) until resumed. Paused months are excluded from sums. The body is optional.
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Subscription ID (UUID)"
+//	@Param			body	body		request.PauseRequest	false	"First paused month"
+//	@Success		200		{object}	response.SubscriptionResponse
+//	@Failure		400		{object}	response.Problem	"Invalid month or month outside the subscription period"
+//	@Failure		404		{object}	response.Problem	"Subscription not found"
+//	@Failure		409		{object}	response.Problem	"Subscription is already paused"
+//	@Failure		500		{object}	response.Problem	"Internal error"
+//	@Router			/api/v1/subscription/{id}/pause [post]
+func New(subscription Subscription) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.subscriptions.pause.New"
+		ctx := r.Context()
+		log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+		id, ok := v1.ExtractID(w, r, log)
+		if !ok {
+			return
+		}
+
+		// The body is optional, an empty one means the default month.
+		var reqBody request.PauseRequest
+		if err := render.Bind(r, &reqBody); err != nil && !errors.Is(err, io.EOF) {
+			log.ErrorContext(ctx, "invalid json", slogx.Err(err))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidJSON, "invalid json"))
+			return
+		}
+
+		if !request.ValidateStruct(w, r, &reqBody) {
+			return
+		}
+
+		month, err := reqBody.Month()
+		if err != nil {
+			log.ErrorContext(ctx, "invalid month", slogx.Err(err))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidRequest, err.Error()))
+			return
+		}
+
+		sub, err := subscription.Pause(ctx, id, month)
+		if err != nil {
+			v1.RenderError(w, r, log, err)
+			return
+		}
+
+		render.JSON(
+			w, r, response.Response{
+				Status: response.StatusOK,
+				Data:   response.ToSubscriptionResponse(sub),
+			},
+		)
+	}
+}
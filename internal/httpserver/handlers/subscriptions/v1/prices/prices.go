@@ -0,0 +1,62 @@
+package pricesv1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Subscription service interface
+type Subscription interface {
+	PriceChanges(ctx context.Context, id uuid.UUID) ([]models.PriceChange, error)
+}
+
+// New creates a handler listing the price changes of a subscription.
+//
+//	@Summary		List price changes
+//	@Description	Past and scheduled price changes of a subscription, oldest first. The price before the first change is the subscription price.
+//	@Tags			subscriptions
+//	@Produce		json
+//	@Param			id	path		string	true	"Subscription ID (UUID)"
+//	@Success		200	{object}	response.Response{data=[]response.PriceChangeResponse}
+//	@Failure		400	{object}	response.Problem	"Invalid ID"
+//	@Failure		404	{object}	response.Problem	"Subscription not found"
+//	@Failure		500	{object}	response.Problem	"Internal error"
+//	@Router			/api/v1/subscription/{id}/prices [get]
+func New(subscription Subscription) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.subscriptions.prices.New"
+		ctx := r.Context()
+		log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+		id, ok := v1.ExtractID(w, r, log)
+		if !ok {
+			return
+		}
+
+		changes, err := subscription.PriceChanges(ctx, id)
+		if err != nil {
+			v1.RenderError(w, r, log, err)
+			return
+		}
+
+		data := make([]response.PriceChangeResponse, 0, len(changes))
+		for _, c := range changes {
+			data = append(data, response.ToPriceChangeResponse(c))
+		}
+
+		render.JSON(
+			w, r, response.Response{
+				Status: response.StatusOK,
+				Data:   data,
+			},
+		)
+	}
+}
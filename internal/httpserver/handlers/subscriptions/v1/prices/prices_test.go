@@ -0,0 +1,97 @@
+package pricesv1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	pricesv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/prices"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+	subSrv "github.com/salivare/subscriptions-service/internal/services/subscription"
+)
+
+type stubSubscription struct {
+	changes []models.PriceChange
+	err     error
+
+	gotID uuid.UUID
+}
+
+func (s *stubSubscription) PriceChanges(_ context.Context, id uuid.UUID) ([]models.PriceChange, error) {
+	s.gotID = id
+	return s.changes, s.err
+}
+
+func TestNew(t *testing.T) {
+	changes := []models.PriceChange{
+		{EffectiveFrom: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), Price: 500},
+		{EffectiveFrom: time.Date(2099, time.January, 1, 0, 0, 0, 0, time.UTC), Price: 600},
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "ok", id: uuid.NewString(), wantStatus: http.StatusOK},
+		{name: "invalid id", id: "not-a-uuid", wantStatus: http.StatusBadRequest, wantCode: response.CodeInvalidID},
+		{
+			name:       "not found",
+			id:         uuid.NewString(),
+			err:        subSrv.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   response.CodeSubscriptionNotFound,
+		},
+		{
+			name:       "storage failure",
+			id:         uuid.NewString(),
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubSubscription{changes: changes, err: tt.err}
+
+				r := router.New()
+				r.GET("/api/v1/subscription/{id}/prices", pricesv1.New(stub))
+
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/subscription/"+tt.id+"/prices", nil)
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					return
+				}
+
+				var got []response.PriceChangeResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.Equal(t, tt.id, stub.gotID.String())
+
+				require.Len(t, got, 2)
+				assert.Equal(t, "03-2024", got[0].EffectiveFrom)
+				assert.Equal(t, int64(500), got[0].Price)
+				assert.False(t, got[0].Scheduled)
+				assert.Equal(t, "01-2099", got[1].EffectiveFrom)
+				assert.True(t, got[1].Scheduled)
+			},
+		)
+	}
+}
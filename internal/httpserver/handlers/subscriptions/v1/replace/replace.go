@@ -0,0 +1,85 @@
+package replacev1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/request"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Subscription service interface
+type Subscription interface {
+	Replace(ctx context.Context, sub models.Subscription) (models.Subscription, bool, error)
+}
+
+// New creates a handler that replaces a subscription or creates it under the given id.
+//
+//	@Summary		Replace subscription
+//	@Description	Idempotent full replacement (PUT): every field is set from the body, omitted optional fields are reset. If no subscription has the id, it is created with it. The owner of an existing subscription cannot be changed, use transfer.
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Subscription ID (UUID), chosen by the client"
+//	@Param			body	body		request.CreateRequest			true	"Subscription data"
+//	@Success		200		{object}	response.SubscriptionResponse	"Replaced subscription"
+//	@Success		201		{object}	response.SubscriptionResponse	"Created subscription"
+//	@Failure		400		{object}	response.Problem				"Invalid input or owner change"
+//	@Failure		409		{object}	response.Problem				"Subscription already exists or overlaps another one"
+//	@Failure		500		{object}	response.Problem				"Internal error"
+//	@Router			/api/v1/subscription/{id} [put]
+func New(subscription Subscription) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.subscriptions.replace.New"
+		ctx := r.Context()
+		log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+		id, ok := v1.ExtractID(w, r, log)
+		if !ok {
+			return
+		}
+
+		var reqBody request.CreateRequest
+		if err := render.Bind(r, &reqBody); err != nil {
+			log.ErrorContext(ctx, "invalid json", slogx.Err(err))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidJSON, "invalid json"))
+			return
+		}
+
+		if !request.ValidateStruct(w, r, &reqBody) {
+			return
+		}
+
+		sub, err := reqBody.ToModel()
+		if err != nil {
+			log.ErrorContext(ctx, "failed to convert request to model", slogx.Err(err))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidRequest, err.Error()))
+			return
+		}
+		sub.ID = id
+
+		stored, created, err := subscription.Replace(ctx, sub)
+		if err != nil {
+			v1.RenderError(w, r, log, err)
+			return
+		}
+
+		status := http.StatusOK
+		if created {
+			status = http.StatusCreated
+		}
+
+		render.JSON(
+			w, r, response.Response{
+				Status: response.StatusOK,
+				Code:   status,
+				Data:   response.ToSubscriptionResponse(stored),
+			},
+		)
+	}
+}
@@ -0,0 +1,137 @@
+package replacev1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	replacev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/replace"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+	subSrv "github.com/salivare/subscriptions-service/internal/services/subscription"
+)
+
+type stubSubscription struct {
+	created bool
+	err     error
+
+	gotSub models.Subscription
+}
+
+func (s *stubSubscription) Replace(_ context.Context, sub models.Subscription) (models.Subscription, bool, error) {
+	s.gotSub = sub
+	return sub, s.created, s.err
+}
+
+func TestNew(t *testing.T) {
+	const valid = `{"service_name": "Netflix", "price": 400, "user_id": "60601fee-2bf1-4721-ae6f-7636e79a0cba", "start_date": "07-2025"}`
+
+	tests := []struct {
+		name       string
+		id         string
+		body       string
+		created    bool
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "replaced", id: uuid.NewString(), body: valid, wantStatus: http.StatusOK},
+		{name: "created", id: uuid.NewString(), body: valid, created: true, wantStatus: http.StatusCreated},
+		{
+			name:       "invalid id",
+			id:         "not-a-uuid",
+			body:       valid,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidID,
+		},
+		{
+			name:       "invalid json",
+			id:         uuid.NewString(),
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidJSON,
+		},
+		{
+			name:       "missing fields",
+			id:         uuid.NewString(),
+			body:       `{"price": 400}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeValidationFailed,
+		},
+		{
+			name:       "invalid start date",
+			id:         uuid.NewString(),
+			body:       `{"service_name": "Netflix", "price": 400, "user_id": "60601fee-2bf1-4721-ae6f-7636e79a0cba", "start_date": "2025-07"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidRequest,
+		},
+		{
+			name:       "owner change",
+			id:         uuid.NewString(),
+			body:       valid,
+			err:        subSrv.ErrInvalidInput,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidRequest,
+		},
+		{
+			name:       "conflict",
+			id:         uuid.NewString(),
+			body:       valid,
+			err:        subSrv.ErrAlreadyExists,
+			wantStatus: http.StatusConflict,
+			wantCode:   response.CodeSubscriptionExists,
+		},
+		{
+			name:       "overlap",
+			id:         uuid.NewString(),
+			body:       valid,
+			err:        subSrv.ErrOverlap,
+			wantStatus: http.StatusConflict,
+			wantCode:   response.CodeSubscriptionOverlap,
+		},
+		{
+			name:       "storage failure",
+			id:         uuid.NewString(),
+			body:       valid,
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubSubscription{created: tt.created, err: tt.err}
+
+				r := router.New()
+				r.PUT("/api/v1/subscription/{id}", replacev1.New(stub))
+
+				req := httptest.NewRequest(http.MethodPut, "/api/v1/subscription/"+tt.id, strings.NewReader(tt.body))
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					return
+				}
+
+				var got response.SubscriptionResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+
+				assert.Equal(t, tt.id, stub.gotSub.ID.String(), "the path id is used")
+				assert.Equal(t, "Netflix", stub.gotSub.ServiceName)
+				assert.Equal(t, response.ToSubscriptionResponse(stub.gotSub), got)
+			},
+		)
+	}
+}
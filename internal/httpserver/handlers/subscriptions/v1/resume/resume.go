@@ -0,0 +1,83 @@
+package resumev1
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/request"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Subscription service interface
+type Subscription interface {
+	Resume(ctx context.Context, id uuid.UUID, month *time.Time) (models.Subscription, error)
+}
+
+// New creates a handler that resumes a subscription.
+//
+//	@Summary		Resume subscription
+//	@Description	Ends the open pause; from is the first month billed again (default: current month, or the first paused month if the pause has not started yet). The body is optional.
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Subscription ID (UUID)"
+//	@Param			body	body		request.ResumeRequest	false	"First month billed again"
+//	@Success		200		{object}	response.SubscriptionResponse
+//	@Failure		400		{object}	response.Problem	"Invalid month or month before the pause"
+//	@Failure		404		{object}	response.Problem	"Subscription not found"
+//	@Failure		409		{object}	response.Problem	"Subscription is not paused"
+//	@Failure		500		{object}	response.Problem	"Internal error"
+//	@Router			/api/v1/subscription/{id}/resume [post]
+func New(subscription Subscription) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.subscriptions.resume.New"
+		ctx := r.Context()
+		log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+		id, ok := v1.ExtractID(w, r, log)
+		if !ok {
+			return
+		}
+
+		// The body is optional, an empty one means the default month.
+		var reqBody request.ResumeRequest
+		if err := render.Bind(r, &reqBody); err != nil && !errors.Is(err, io.EOF) {
+			log.ErrorContext(ctx, "invalid json", slogx.Err(err))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidJSON, "invalid json"))
+			return
+		}
+
+		if !request.ValidateStruct(w, r, &reqBody) {
+			return
+		}
+
+		month, err := reqBody.Month()
+		if err != nil {
+			log.ErrorContext(ctx, "invalid month", slogx.Err(err))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidRequest, err.Error()))
+			return
+		}
+
+		sub, err := subscription.Resume(ctx, id, month)
+		if err != nil {
+			v1.RenderError(w, r, log, err)
+			return
+		}
+
+		render.JSON(
+			w, r, response.Response{
+				Status: response.StatusOK,
+				Data:   response.ToSubscriptionResponse(sub),
+			},
+		)
+	}
+}
@@ -0,0 +1,137 @@
+package resumev1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	resumev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/resume"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+	subSrv "github.com/salivare/subscriptions-service/internal/services/subscription"
+)
+
+type stubSubscription struct {
+	err       error
+	gotID     uuid.UUID
+	gotMonth  *time.Time
+	callCount int
+}
+
+func (s *stubSubscription) Resume(_ context.Context, id uuid.UUID, month *time.Time) (models.Subscription, error) {
+	s.callCount++
+	s.gotID = id
+	s.gotMonth = month
+
+	if s.err != nil {
+		return models.Subscription{}, s.err
+	}
+
+	return models.Subscription{ID: id, StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, nil
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		body       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantMonth  *time.Time
+	}{
+		{name: "empty body", id: uuid.NewString(), wantStatus: http.StatusOK},
+		{
+			name:       "with month",
+			id:         uuid.NewString(),
+			body:       `{"from": "03-2025"}`,
+			wantStatus: http.StatusOK,
+			wantMonth:  ptr(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		{name: "invalid id", id: "not-a-uuid", wantStatus: http.StatusBadRequest, wantCode: response.CodeInvalidID},
+		{
+			name:       "invalid json",
+			id:         uuid.NewString(),
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidJSON,
+		},
+		{
+			name:       "invalid month",
+			id:         uuid.NewString(),
+			body:       `{"from": "2025-03"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeValidationFailed,
+		},
+		{
+			name:       "before the pause",
+			id:         uuid.NewString(),
+			err:        subSrv.ErrInvalidDateRange,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidDateRange,
+		},
+		{
+			name:       "not paused",
+			id:         uuid.NewString(),
+			err:        subSrv.ErrNotPaused,
+			wantStatus: http.StatusConflict,
+			wantCode:   response.CodeNotPaused,
+		},
+		{
+			name:       "not found",
+			id:         uuid.NewString(),
+			err:        subSrv.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   response.CodeSubscriptionNotFound,
+		},
+		{
+			name:       "storage failure",
+			id:         uuid.NewString(),
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubSubscription{err: tt.err}
+
+				r := router.New()
+				r.POST("/api/v1/subscription/{id}/resume", resumev1.New(stub))
+
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/subscription/"+tt.id+"/resume", strings.NewReader(tt.body))
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					return
+				}
+
+				var got response.SubscriptionResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.Equal(t, tt.id, got.ID.String())
+				assert.NotNil(t, got.Pauses)
+
+				assert.Equal(t, tt.id, stub.gotID.String())
+				assert.Equal(t, tt.wantMonth, stub.gotMonth)
+			},
+		)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -2,34 +2,24 @@ package savev1
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 	"net/http"
 	"time"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/salivare-io/slogx"
 	"github.com/salivare/subscriptions-service/internal/domain/models"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
 	"github.com/salivare/subscriptions-service/internal/httpserver/render"
 	"github.com/salivare/subscriptions-service/internal/httpserver/request"
 	"github.com/salivare/subscriptions-service/internal/httpserver/response"
-	subSrv "github.com/salivare/subscriptions-service/internal/services/subscription"
 )
 
-type Response struct {
-	response.Response
-}
-
 type CreateResponse struct {
 	ID        uuid.UUID `json:"id"`
 	CreatedAt string    `json:"created_at"`
 }
 
-func (r Response) StatusCode() int {
-	return r.Response.StatusCode()
-}
-
 // Subscription service interface
 type Subscription interface {
 	Save(ctx context.Context, subscription models.Subscription) (uuid.UUID, time.Time, error)
@@ -44,9 +34,9 @@ type Subscription interface {
 //	@Produce		json
 //	@Param			request	body		request.CreateRequest	true	"Subscription data"
 //	@Success		200		{object}	CreateResponse
-//	@Failure		400		{object}	Response	"Invalid request"
-//	@Failure		409		{object}	Response	"Subscription already exists"
-//	@Failure		500		{object}	Response	"Internal server error"
+//	@Failure		400		{object}	response.Problem	"Invalid request"
+//	@Failure		409		{object}	response.Problem	"Subscription already exists or overlaps another one"
+//	@Failure		500		{object}	response.Problem	"Internal server error"
 //	@Router			/api/v1/subscription [post]
 func New(subscription Subscription) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -57,51 +47,25 @@ func New(subscription Subscription) http.HandlerFunc {
 		var req request.CreateRequest
 		if err := render.Bind(r, &req); err != nil {
 			log.ErrorContext(ctx, "invalid json", slogx.Err(err))
-			render.JSON(
-				w, r, Response{
-					Response: response.Error("invalid json"),
-				},
-			)
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidJSON, "invalid json"))
 			return
 		}
 
-		if err := validator.New().Struct(req); err != nil {
-			var validateError validator.ValidationErrors
-			errors.As(err, &validateError)
-
-			log.ErrorContext(ctx, "invalid request", slogx.Err(err))
-			render.JSON(w, r, request.ValidationError(validateError))
+		if !request.ValidateStruct(w, r, &req) {
+			log.ErrorContext(ctx, "invalid request")
 			return
 		}
 
 		sub, err := req.ToModel()
 		if err != nil {
 			log.ErrorContext(ctx, "failed to convert request to model", slogx.Err(err))
-			render.JSON(
-				w, r, Response{
-					Response: response.Error("invalid subscription data"),
-				},
-			)
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidRequest, "invalid subscription data"))
 			return
 		}
 
 		id, createAt, err := subscription.Save(ctx, sub)
 		if err != nil {
-			if errors.Is(err, subSrv.ErrAlreadyExists) {
-				render.JSON(
-					w, r, Response{
-						Response: response.Conflict(err.Error()),
-					},
-				)
-				return
-			}
-
-			log.ErrorContext(ctx, "failed to save subscription", slogx.Err(err))
-			render.JSON(
-				w, r, Response{
-					Response: response.Internal("internal error"),
-				},
-			)
+			v1.RenderError(w, r, log, err)
 			return
 		}
 
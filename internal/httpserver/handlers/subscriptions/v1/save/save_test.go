@@ -0,0 +1,177 @@
+package savev1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	savev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/save"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	subSrv "github.com/salivare/subscriptions-service/internal/services/subscription"
+)
+
+var (
+	savedID   = uuid.New()
+	createdAt = time.Date(2025, time.July, 3, 10, 30, 0, 0, time.UTC)
+)
+
+type stubSubscription struct {
+	err error
+
+	gotSub models.Subscription
+}
+
+func (s *stubSubscription) Save(_ context.Context, sub models.Subscription) (uuid.UUID, time.Time, error) {
+	s.gotSub = sub
+	if s.err != nil {
+		return uuid.Nil, time.Time{}, s.err
+	}
+
+	return savedID, createdAt, nil
+}
+
+func TestNew(t *testing.T) {
+	const valid = `{
+        "service_name": "Netflix",
+        "price": 400,
+        "user_id": "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+        "start_date": "07-2025"
+    }`
+
+	tests := []struct {
+		name            string
+		body            string
+		err             error
+		wantStatus      int
+		wantCode        string
+		wantFields      []string
+		wantTrialMonths int
+		wantTrialPrice  int64
+	}{
+		{name: "ok", body: valid, wantStatus: http.StatusOK},
+		{name: "invalid json", body: `{`, wantStatus: http.StatusBadRequest, wantCode: response.CodeInvalidJSON},
+		{
+			name:       "validation failed",
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeValidationFailed,
+			wantFields: []string{"service_name", "price", "user_id", "start_date"},
+		},
+		{
+			name: "invalid date",
+			body: `{
+                "service_name": "Netflix",
+                "price": 400,
+                "user_id": "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+                "start_date": "2025-07"
+            }`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidRequest,
+		},
+		{
+			name: "trial",
+			body: `{
+                "service_name": "Netflix",
+                "price": 400,
+                "user_id": "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+                "start_date": "07-2025",
+                "trial_months": 1,
+                "trial_price": 99
+            }`,
+			wantStatus:      http.StatusOK,
+			wantTrialMonths: 1,
+			wantTrialPrice:  99,
+		},
+		{
+			name: "negative trial",
+			body: `{
+                "service_name": "Netflix",
+                "price": 400,
+                "user_id": "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+                "start_date": "07-2025",
+                "trial_months": -1
+            }`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeValidationFailed,
+			wantFields: []string{"trial_months"},
+		},
+		{
+			name: "trial price without trial",
+			body: `{
+                "service_name": "Netflix",
+                "price": 400,
+                "user_id": "60601fee-2bf1-4721-ae6f-7636e79a0cba",
+                "start_date": "07-2025",
+                "trial_price": 99
+            }`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeValidationFailed,
+			wantFields: []string{"trial_price"},
+		},
+		{
+			name:       "already exists",
+			body:       valid,
+			err:        subSrv.ErrAlreadyExists,
+			wantStatus: http.StatusConflict,
+			wantCode:   response.CodeSubscriptionExists,
+		},
+		{
+			name:       "storage failure",
+			body:       valid,
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubSubscription{err: tt.err}
+				h := savev1.New(stub)
+
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/subscription", strings.NewReader(tt.body))
+				rec := httptest.NewRecorder()
+
+				h.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					p := handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+
+					fields := make([]string, len(p.Errors))
+					for i, e := range p.Errors {
+						fields[i] = e.Field
+					}
+					if tt.wantFields != nil {
+						assert.ElementsMatch(t, tt.wantFields, fields)
+					}
+					return
+				}
+
+				var got savev1.CreateResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.Equal(t, savedID, got.ID)
+				assert.Equal(t, createdAt.Format(time.DateTime), got.CreatedAt)
+
+				assert.Equal(t, "Netflix", stub.gotSub.ServiceName)
+				require.NotNil(t, stub.gotSub.Price)
+				assert.Equal(t, int64(400), *stub.gotSub.Price)
+				assert.Equal(t, uuid.MustParse("60601fee-2bf1-4721-ae6f-7636e79a0cba"), stub.gotSub.UserID)
+				assert.Equal(t, time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC), stub.gotSub.StartDate)
+				assert.Nil(t, stub.gotSub.EndDate)
+				assert.Equal(t, tt.wantTrialMonths, stub.gotSub.TrialMonths)
+				assert.Equal(t, tt.wantTrialPrice, stub.gotSub.TrialPrice)
+			},
+		)
+	}
+}
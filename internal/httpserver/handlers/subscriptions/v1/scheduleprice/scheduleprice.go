@@ -0,0 +1,79 @@
+package schedulepricev1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/request"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Subscription service interface
+type Subscription interface {
+	SchedulePriceChange(ctx context.Context, id uuid.UUID, effectiveFrom time.Time, price int64) (models.PriceChange, error)
+}
+
+// New creates a handler scheduling a price change.
+//
+//	@Summary		Schedule price change
+//	@Description	Sets a new price from a future month on, keeping the subscription identity. Scheduling the same month again replaces the price.
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Subscription ID (UUID)"
+//	@Param			body	body		request.SchedulePriceRequest	true	"New price"
+//	@Success		200		{object}	response.PriceChangeResponse
+//	@Failure		400		{object}	response.Problem	"Invalid input or month"
+//	@Failure		404		{object}	response.Problem	"Subscription not found"
+//	@Failure		500		{object}	response.Problem	"Internal error"
+//	@Router			/api/v1/subscription/{id}/prices [post]
+func New(subscription Subscription) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.subscriptions.scheduleprice.New"
+		ctx := r.Context()
+		log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+		id, ok := v1.ExtractID(w, r, log)
+		if !ok {
+			return
+		}
+
+		var reqBody request.SchedulePriceRequest
+		if err := render.Bind(r, &reqBody); err != nil {
+			log.ErrorContext(ctx, "invalid json", slogx.Err(err))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidJSON, "invalid json"))
+			return
+		}
+
+		if !request.ValidateStruct(w, r, &reqBody) {
+			return
+		}
+
+		month, err := reqBody.Month()
+		if err != nil {
+			log.ErrorContext(ctx, "invalid month", slogx.Err(err))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidRequest, err.Error()))
+			return
+		}
+
+		change, err := subscription.SchedulePriceChange(ctx, id, month, *reqBody.Price)
+		if err != nil {
+			v1.RenderError(w, r, log, err)
+			return
+		}
+
+		render.JSON(
+			w, r, response.Response{
+				Status: response.StatusOK,
+				Data:   response.ToPriceChangeResponse(change),
+			},
+		)
+	}
+}
@@ -0,0 +1,141 @@
+package schedulepricev1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	schedulepricev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/scheduleprice"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+	subSrv "github.com/salivare/subscriptions-service/internal/services/subscription"
+)
+
+type stubSubscription struct {
+	err error
+
+	gotID            uuid.UUID
+	gotEffectiveFrom time.Time
+	gotPrice         int64
+}
+
+func (s *stubSubscription) SchedulePriceChange(
+	_ context.Context,
+	id uuid.UUID,
+	effectiveFrom time.Time,
+	price int64,
+) (models.PriceChange, error) {
+	s.gotID, s.gotEffectiveFrom, s.gotPrice = id, effectiveFrom, price
+	if s.err != nil {
+		return models.PriceChange{}, s.err
+	}
+
+	return models.PriceChange{SubscriptionID: id, EffectiveFrom: effectiveFrom, Price: price, CreatedAt: time.Now()}, nil
+}
+
+func TestNew(t *testing.T) {
+	const valid = `{"effective_from": "01-2099", "price": 500}`
+
+	tests := []struct {
+		name       string
+		id         string
+		body       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "ok", id: uuid.NewString(), body: valid, wantStatus: http.StatusOK},
+		{
+			name:       "invalid id",
+			id:         "not-a-uuid",
+			body:       valid,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidID,
+		},
+		{
+			name:       "invalid json",
+			id:         uuid.NewString(),
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidJSON,
+		},
+		{
+			name:       "missing price",
+			id:         uuid.NewString(),
+			body:       `{"effective_from": "01-2099"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeValidationFailed,
+		},
+		{
+			name:       "invalid month",
+			id:         uuid.NewString(),
+			body:       `{"effective_from": "2099-01", "price": 500}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeValidationFailed,
+		},
+		{
+			name:       "in the past",
+			id:         uuid.NewString(),
+			body:       valid,
+			err:        subSrv.ErrPriceChangeInPast,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidDateRange,
+		},
+		{
+			name:       "not found",
+			id:         uuid.NewString(),
+			body:       valid,
+			err:        subSrv.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   response.CodeSubscriptionNotFound,
+		},
+		{
+			name:       "storage failure",
+			id:         uuid.NewString(),
+			body:       valid,
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubSubscription{err: tt.err}
+
+				r := router.New()
+				r.POST("/api/v1/subscription/{id}/prices", schedulepricev1.New(stub))
+
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/subscription/"+tt.id+"/prices", strings.NewReader(tt.body))
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					return
+				}
+
+				var got response.PriceChangeResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.Equal(t, "01-2099", got.EffectiveFrom)
+				assert.Equal(t, int64(500), got.Price)
+				assert.True(t, got.Scheduled)
+
+				assert.Equal(t, tt.id, stub.gotID.String())
+				assert.Equal(t, time.Date(2099, time.January, 1, 0, 0, 0, 0, time.UTC), stub.gotEffectiveFrom)
+				assert.Equal(t, int64(500), stub.gotPrice)
+			},
+		)
+	}
+}
@@ -2,16 +2,15 @@ package sumv1
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 	"net/http"
 
 	"github.com/salivare-io/slogx"
 	"github.com/salivare/subscriptions-service/internal/domain/models"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
 	"github.com/salivare/subscriptions-service/internal/httpserver/render"
 	"github.com/salivare/subscriptions-service/internal/httpserver/request"
 	"github.com/salivare/subscriptions-service/internal/httpserver/response"
-	"github.com/salivare/subscriptions-service/internal/services/subscription"
 )
 
 // Subscription service interface
@@ -28,8 +27,8 @@ type Subscription interface {
 //	@Produce		json
 //	@Param			request	body		request.SumRequest	true	"Filters"
 //	@Success		200		{object}	response.SumResponse
-//	@Failure		400		{object}	response.Response	"Invalid request"
-//	@Failure		500		{object}	response.Response	"Internal error"
+//	@Failure		400		{object}	response.Problem	"Invalid request"
+//	@Failure		500		{object}	response.Problem	"Internal error"
 //	@Router			/api/v1/subscription/sum [post]
 func New(s Subscription) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -40,7 +39,7 @@ func New(s Subscription) http.HandlerFunc {
 		var reqBody request.SumRequest
 		if err := render.Bind(r, &reqBody); err != nil {
 			log.ErrorContext(ctx, "invalid json", slogx.Err(err))
-			render.JSON(w, r, response.Error("invalid json"))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidJSON, "invalid json"))
 			return
 		}
 
@@ -49,12 +48,11 @@ func New(s Subscription) http.HandlerFunc {
 		}
 
 		if reqBody.StartDateFrom == nil && reqBody.EndDateFrom == nil {
-			render.JSON(
-				w, r, response.Response{
-					Status: response.StatusError,
-					Error:  "either start_date_from or end_date_from must be provided",
-					Code:   http.StatusBadRequest,
-				},
+			render.Problem(
+				w, r, response.BadRequest(
+					response.CodeMissingRequiredFilter,
+					"either start_date_from or end_date_from must be provided",
+				),
 			)
 			return
 		}
@@ -62,22 +60,13 @@ func New(s Subscription) http.HandlerFunc {
 		filter, err := reqBody.ToFilter()
 		if err != nil {
 			log.ErrorContext(ctx, "invalid filter", slogx.Err(err))
-			render.JSON(w, r, response.Error(err.Error()))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidRequest, err.Error()))
 			return
 		}
 
 		total, err := s.Sum(ctx, filter)
 		if err != nil {
-			if errors.Is(err, subscription.ErrStartDateInFuture) ||
-				errors.Is(err, subscription.ErrEndDateInFuture) {
-
-				log.WarnContext(ctx, "invalid date range", slog.Any("error", err))
-				render.JSON(w, r, response.Error(err.Error()))
-				return
-			}
-
-			log.ErrorContext(ctx, "failed to calculate sum", slog.Any("error", err))
-			render.JSON(w, r, response.Error("internal error"))
+			v1.RenderError(w, r, log, err)
 			return
 		}
 
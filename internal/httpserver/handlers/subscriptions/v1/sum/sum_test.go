@@ -0,0 +1,122 @@
+package sumv1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	sumv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/sum"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	subSrv "github.com/salivare/subscriptions-service/internal/services/subscription"
+)
+
+type stubSubscription struct {
+	err error
+
+	gotFilter models.SumFilter
+}
+
+func (s *stubSubscription) Sum(_ context.Context, f models.SumFilter) (int64, error) {
+	s.gotFilter = f
+	return 1200, s.err
+}
+
+func TestNew(t *testing.T) {
+	const valid = `{"start_date_from": "01-2024", "start_date_to": "12-2024"}`
+
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{name: "ok", body: valid, wantStatus: http.StatusOK},
+		{name: "invalid json", body: `{`, wantStatus: http.StatusBadRequest, wantCode: response.CodeInvalidJSON},
+		{
+			name:       "validation failed",
+			body:       `{"user_id": "not-a-uuid", "start_date_from": "01-2024"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeValidationFailed,
+		},
+		{
+			name:       "missing required filter",
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeMissingRequiredFilter,
+		},
+		{
+			name:       "start date in future",
+			body:       valid,
+			err:        subSrv.ErrStartDateInFuture,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidDateRange,
+		},
+		{
+			name:       "end date in future",
+			body:       valid,
+			err:        subSrv.ErrEndDateInFuture,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidDateRange,
+		},
+		{
+			name:       "inverted range",
+			body:       valid,
+			err:        fmt.Errorf("%w: start_date_to must be >= start_date_from", subSrv.ErrInvalidDateRange),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidDateRange,
+			wantDetail: subSrv.ErrInvalidDateRange.Error(),
+		},
+		{
+			name:       "storage failure",
+			body:       valid,
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+			wantDetail: "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubSubscription{err: tt.err}
+				h := sumv1.New(stub)
+
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/subscription/sum", strings.NewReader(tt.body))
+				rec := httptest.NewRecorder()
+
+				h.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					p := handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					if tt.wantDetail != "" {
+						assert.Equal(t, tt.wantDetail, p.Detail)
+					}
+					return
+				}
+
+				var got response.SumResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.Equal(t, int64(1200), got.Total)
+
+				require.NotNil(t, stub.gotFilter.StartDateFrom)
+				require.NotNil(t, stub.gotFilter.StartDateTo)
+				assert.Equal(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), *stub.gotFilter.StartDateFrom)
+				assert.Equal(t, time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC), *stub.gotFilter.StartDateTo)
+				assert.Nil(t, stub.gotFilter.UserID)
+			},
+		)
+	}
+}
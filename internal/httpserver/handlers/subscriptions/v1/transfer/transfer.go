@@ -0,0 +1,90 @@
+package transferv1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/request"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Subscription service interface
+type Subscription interface {
+	Transfer(ctx context.Context, id, userID uuid.UUID, month *time.Time) (models.Subscription, models.Subscription, error)
+}
+
+// New creates a handler that transfers a subscription to another user.
+//
+//	@Summary		Transfer subscription
+//	@Description	Ends the subscription for its owner the month before effective_from (default: current month) and creates its continuation for user_id in one transaction. The continuation keeps the service, end date and price in effect, the rest of the trial and later scheduled price changes.
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Subscription ID (UUID)"
+//	@Param			body	body		request.TransferRequest	true	"New owner and first month"
+//	@Success		200		{object}	response.TransferResponse
+//	@Failure		400		{object}	response.Problem	"Invalid request or month outside the subscription period"
+//	@Failure		404		{object}	response.Problem	"Subscription not found"
+//	@Failure		409		{object}	response.Problem	"The new owner already has the subscription, or it is paused"
+//	@Failure		500		{object}	response.Problem	"Internal error"
+//	@Router			/api/v1/subscription/{id}/transfer [post]
+func New(subscription Subscription) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.subscriptions.transfer.New"
+		ctx := r.Context()
+		log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+		id, ok := v1.ExtractID(w, r, log)
+		if !ok {
+			return
+		}
+
+		var reqBody request.TransferRequest
+		if err := render.Bind(r, &reqBody); err != nil {
+			log.ErrorContext(ctx, "invalid json", slogx.Err(err))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidJSON, "invalid json"))
+			return
+		}
+
+		if !request.ValidateStruct(w, r, &reqBody) {
+			return
+		}
+
+		userID, err := reqBody.Target()
+		if err != nil {
+			log.ErrorContext(ctx, "invalid user id", slogx.Err(err))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidRequest, err.Error()))
+			return
+		}
+
+		month, err := reqBody.Month()
+		if err != nil {
+			log.ErrorContext(ctx, "invalid month", slogx.Err(err))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidRequest, err.Error()))
+			return
+		}
+
+		ended, continuation, err := subscription.Transfer(ctx, id, userID, month)
+		if err != nil {
+			v1.RenderError(w, r, log, err)
+			return
+		}
+
+		render.JSON(
+			w, r, response.Response{
+				Status: response.StatusOK,
+				Data: response.TransferResponse{
+					Ended:        response.ToSubscriptionResponse(ended),
+					Continuation: response.ToSubscriptionResponse(continuation),
+				},
+			},
+		)
+	}
+}
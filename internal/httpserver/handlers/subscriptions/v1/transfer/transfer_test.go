@@ -0,0 +1,169 @@
+package transferv1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	transferv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/transfer"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+	subSrv "github.com/salivare/subscriptions-service/internal/services/subscription"
+)
+
+type stubSubscription struct {
+	err       error
+	gotID     uuid.UUID
+	gotUser   uuid.UUID
+	gotMonth  *time.Time
+	callCount int
+}
+
+func (s *stubSubscription) Transfer(
+	_ context.Context,
+	id, userID uuid.UUID,
+	month *time.Time,
+) (models.Subscription, models.Subscription, error) {
+	s.callCount++
+	s.gotID = id
+	s.gotUser = userID
+	s.gotMonth = month
+
+	if s.err != nil {
+		return models.Subscription{}, models.Subscription{}, s.err
+	}
+
+	return models.Subscription{ID: id}, models.Subscription{ID: uuid.New(), UserID: userID}, nil
+}
+
+func TestNew(t *testing.T) {
+	userID := uuid.New()
+	valid := fmt.Sprintf(`{"user_id": %q}`, userID)
+
+	tests := []struct {
+		name       string
+		id         string
+		body       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantMonth  *time.Time
+	}{
+		{name: "current month", id: uuid.NewString(), body: valid, wantStatus: http.StatusOK},
+		{
+			name:       "with month",
+			id:         uuid.NewString(),
+			body:       fmt.Sprintf(`{"user_id": %q, "effective_from": "03-2025"}`, userID),
+			wantStatus: http.StatusOK,
+			wantMonth:  ptr(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:       "invalid id",
+			id:         "not-a-uuid",
+			body:       valid,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidID,
+		},
+		{
+			name:       "invalid json",
+			id:         uuid.NewString(),
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidJSON,
+		},
+		{name: "empty body", id: uuid.NewString(), wantStatus: http.StatusBadRequest, wantCode: response.CodeInvalidJSON},
+		{
+			name:       "missing user",
+			id:         uuid.NewString(),
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeValidationFailed,
+		},
+		{
+			name:       "invalid month",
+			id:         uuid.NewString(),
+			body:       fmt.Sprintf(`{"user_id": %q, "effective_from": "2025-03"}`, userID),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeValidationFailed,
+		},
+		{
+			name:       "outside period",
+			id:         uuid.NewString(),
+			body:       valid,
+			err:        subSrv.ErrOutsidePeriod,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidDateRange,
+		},
+		{
+			name:       "not found",
+			id:         uuid.NewString(),
+			body:       valid,
+			err:        subSrv.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   response.CodeSubscriptionNotFound,
+		},
+		{
+			name:       "conflict",
+			id:         uuid.NewString(),
+			body:       valid,
+			err:        subSrv.ErrAlreadyExists,
+			wantStatus: http.StatusConflict,
+			wantCode:   response.CodeSubscriptionExists,
+		},
+		{
+			name:       "storage failure",
+			id:         uuid.NewString(),
+			body:       valid,
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubSubscription{err: tt.err}
+
+				r := router.New()
+				r.POST("/api/v1/subscription/{id}/transfer", transferv1.New(stub))
+
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/subscription/"+tt.id+"/transfer", strings.NewReader(tt.body))
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					if tt.err == nil {
+						assert.Zero(t, stub.callCount, "the request is rejected before the service")
+					}
+					return
+				}
+
+				var got response.TransferResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.Equal(t, tt.id, got.Ended.ID.String())
+				assert.Equal(t, userID, got.Continuation.UserID)
+
+				assert.Equal(t, tt.id, stub.gotID.String())
+				assert.Equal(t, userID, stub.gotUser)
+				assert.Equal(t, tt.wantMonth, stub.gotMonth)
+			},
+		)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,83 @@
+package trialsv1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/format"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Subscription service interface
+type Subscription interface {
+	TrialsEnding(ctx context.Context, month time.Time, userID *uuid.UUID) ([]models.Subscription, error)
+}
+
+// New creates a handler listing subscriptions whose trial ends in a month.
+//
+//	@Summary		Trials ending in a month
+//	@Description	Subscriptions whose last trial month is the given month, the current month by default. Subscriptions cancelled before the trial ends are not listed.
+//	@Tags			subscriptions
+//	@Produce		json
+//	@Param			month	query		string	false	"Month, MM-YYYY"
+//	@Param			user_id	query		string	false	"User ID (UUID)"
+//	@Success		200		{object}	response.Response{data=[]response.SubscriptionResponse}
+//	@Failure		400		{object}	response.Problem	"Invalid month or user_id"
+//	@Failure		500		{object}	response.Problem	"Internal error"
+//	@Router			/api/v1/subscription/trials/ending [get]
+func New(subscription Subscription) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.subscriptions.trials.New"
+		ctx := r.Context()
+		log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+		query := r.URL.Query()
+
+		month := models.MonthOf(time.Now())
+		if s := query.Get("month"); s != "" {
+			t, err := time.Parse(format.MonthYear, s)
+			if err != nil {
+				log.WarnContext(ctx, "invalid month", slogx.Err(err))
+				render.Problem(w, r, response.BadRequest(response.CodeInvalidRequest, "month must be MM-YYYY"))
+				return
+			}
+			month = t
+		}
+
+		var userID *uuid.UUID
+		if s := query.Get("user_id"); s != "" {
+			id, err := uuid.Parse(s)
+			if err != nil {
+				log.WarnContext(ctx, "invalid user_id", slogx.Err(err))
+				render.Problem(w, r, response.BadRequest(response.CodeInvalidRequest, "invalid user_id"))
+				return
+			}
+			userID = &id
+		}
+
+		subs, err := subscription.TrialsEnding(ctx, month, userID)
+		if err != nil {
+			v1.RenderError(w, r, log, err)
+			return
+		}
+
+		data := make([]response.SubscriptionResponse, 0, len(subs))
+		for _, sub := range subs {
+			data = append(data, response.ToSubscriptionResponse(sub))
+		}
+
+		render.JSON(
+			w, r, response.Response{
+				Status: response.StatusOK,
+				Data:   data,
+			},
+		)
+	}
+}
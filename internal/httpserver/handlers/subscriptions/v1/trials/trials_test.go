@@ -0,0 +1,119 @@
+package trialsv1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	trialsv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/trials"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+)
+
+type stubSubscription struct {
+	subs []models.Subscription
+	err  error
+
+	month  time.Time
+	userID *uuid.UUID
+}
+
+func (s *stubSubscription) TrialsEnding(_ context.Context, month time.Time, userID *uuid.UUID) ([]models.Subscription, error) {
+	s.month = month
+	s.userID = userID
+	return s.subs, s.err
+}
+
+func TestNew(t *testing.T) {
+	price := int64(400)
+	userID := uuid.New()
+	sub := models.Subscription{
+		ID:          uuid.New(),
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      userID,
+		StartDate:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		TrialMonths: 3,
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantMonth  time.Time
+		wantUser   *uuid.UUID
+	}{
+		{
+			name:       "month and user",
+			query:      "?month=03-2024&user_id=" + userID.String(),
+			wantStatus: http.StatusOK,
+			wantMonth:  time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			wantUser:   &userID,
+		},
+		{
+			name:       "defaults to the current month",
+			wantStatus: http.StatusOK,
+			wantMonth:  models.MonthOf(time.Now()),
+		},
+		{
+			name:       "invalid month",
+			query:      "?month=2024-03",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidRequest,
+		},
+		{
+			name:       "invalid user_id",
+			query:      "?user_id=nope",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidRequest,
+		},
+		{
+			name:       "storage failure",
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubSubscription{subs: []models.Subscription{sub}, err: tt.err}
+
+				r := router.New()
+				r.GET("/api/v1/subscription/trials/ending", trialsv1.New(stub))
+
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/subscription/trials/ending"+tt.query, nil)
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					return
+				}
+
+				var got []response.SubscriptionResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				require.Len(t, got, 1)
+				assert.Equal(t, sub.ID, got[0].ID)
+				require.NotNil(t, got[0].TrialEndDate)
+				assert.Equal(t, "03-2024", *got[0].TrialEndDate)
+
+				assert.True(t, tt.wantMonth.Equal(stub.month))
+				assert.Equal(t, tt.wantUser, stub.userID)
+			},
+		)
+	}
+}
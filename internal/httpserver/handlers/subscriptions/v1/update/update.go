@@ -2,9 +2,11 @@ package updatev1
 
 import (
 	"context"
-	"errors"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/salivare-io/slogx"
@@ -13,27 +15,39 @@ import (
 	"github.com/salivare/subscriptions-service/internal/httpserver/render"
 	"github.com/salivare/subscriptions-service/internal/httpserver/request"
 	"github.com/salivare/subscriptions-service/internal/httpserver/response"
-	subSrv "github.com/salivare/subscriptions-service/internal/services/subscription"
+	"github.com/salivare/subscriptions-service/internal/jsonpatch"
 )
 
-// Subscription service interface
+// acceptPatch lists the media types PATCH accepts, advertised in the Accept-Patch header.
+var acceptPatch = strings.Join(
+	[]string{"application/json", jsonpatch.MergePatchContentType, jsonpatch.JSONPatchContentType}, ", ",
+)
+
+// Subscription service interface. Patch applies merge patches and JSON patches.
 type Subscription interface {
 	Update(ctx context.Context, id uuid.UUID, updateReq request.UpdateRequest) (models.Subscription, error)
+	Patch(ctx context.Context, id uuid.UUID, apply func(doc []byte) ([]byte, error)) (models.Subscription, error)
 }
 
 // New creates a handler for update a subscription.
 //
 //	@Summary		Update subscription
 //	@Description	Partially update subscription fields (PATCH). Any field may be omitted.
+//	@Description	With application/merge-patch+json (RFC 7396) null removes a field: "end_date": null makes the subscription open-ended.
+//	@Description	With application/json-patch+json (RFC 6902) the body is a list of operations; a failed "test" operation rejects the whole patch with 409.
 //	@Tags			subscriptions
 //	@Accept			json
+//	@Accept			application/merge-patch+json
+//	@Accept			application/json-patch+json
 //	@Produce		json
 //	@Param			id		path		string							true	"Subscription ID (UUID)"
 //	@Param			body	body		request.UpdateRequest			true	"Fields to update"
 //	@Success		200		{object}	response.SubscriptionResponse	"Updated subscription"
-//	@Failure		400		{object}	response.Response				"Invalid input"
-//	@Failure		404		{object}	response.Response				"Subscription not found"
-//	@Failure		500		{object}	response.Response				"Internal error"
+//	@Failure		400		{object}	response.Problem				"Invalid input"
+//	@Failure		404		{object}	response.Problem				"Subscription not found"
+//	@Failure		409		{object}	response.Problem				"Subscription already exists, overlaps another one or a test operation failed"
+//	@Failure		415		{object}	response.Problem				"Unsupported content type"
+//	@Failure		500		{object}	response.Problem				"Internal error"
 //	@Router			/api/v1/subscription/{id} [patch]
 func New(subscription Subscription) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -46,33 +60,69 @@ func New(subscription Subscription) http.HandlerFunc {
 			return
 		}
 
-		var reqBody request.UpdateRequest
-		if err := render.Bind(r, &reqBody); err != nil {
-			log.ErrorContext(ctx, "invalid json", slogx.Err(err))
-			render.JSON(w, r, response.Error("invalid json"))
-			return
+		mediaType := "application/json"
+		if ct := r.Header.Get("Content-Type"); ct != "" {
+			var err error
+			if mediaType, _, err = mime.ParseMediaType(ct); err != nil {
+				mediaType = ct
+			}
 		}
 
-		if !request.ValidateStruct(w, r, &reqBody) {
-			return
-		}
+		var (
+			updated models.Subscription
+			err     error
+		)
 
-		updated, err := subscription.Update(ctx, id, reqBody)
-		if err != nil {
-			if errors.Is(err, subSrv.ErrNotFound) {
-				log.ErrorContext(ctx, "subscription not found", slogx.Err(err))
-				render.JSON(
-					w, r, response.Response{
-						Status: response.StatusError,
-						Error:  response.ErrNotFound,
-						Code:   http.StatusNotFound,
-					},
-				)
+		switch mediaType {
+		case "application/json":
+			var reqBody request.UpdateRequest
+			if err := render.Bind(r, &reqBody); err != nil {
+				log.ErrorContext(ctx, "invalid json", slogx.Err(err))
+				render.Problem(w, r, response.BadRequest(response.CodeInvalidJSON, "invalid json"))
+				return
+			}
+
+			if !request.ValidateStruct(w, r, &reqBody) {
+				return
+			}
+
+			updated, err = subscription.Update(ctx, id, reqBody)
+		case jsonpatch.MergePatchContentType:
+			body, ok := readBody(w, r, log)
+			if !ok {
 				return
 			}
 
-			log.ErrorContext(ctx, "failed to update subscription", slogx.Err(err))
-			render.JSON(w, r, response.Internal("internal error"))
+			updated, err = subscription.Patch(ctx, id, func(doc []byte) ([]byte, error) {
+				return jsonpatch.MergePatch(doc, body)
+			})
+		case jsonpatch.JSONPatchContentType:
+			body, ok := readBody(w, r, log)
+			if !ok {
+				return
+			}
+
+			patch, decodeErr := jsonpatch.Decode(body)
+			if decodeErr != nil {
+				log.WarnContext(ctx, "invalid json patch", slogx.Err(decodeErr))
+				render.Problem(w, r, response.BadRequest(response.CodeInvalidPatch, decodeErr.Error()))
+				return
+			}
+
+			updated, err = subscription.Patch(ctx, id, patch.Apply)
+		default:
+			log.WarnContext(ctx, "unsupported content type", slog.String("content_type", mediaType))
+			w.Header().Set("Accept-Patch", acceptPatch)
+			render.Problem(w, r, response.NewProblem(
+				http.StatusUnsupportedMediaType,
+				response.CodeUnsupportedMediaType,
+				"content type must be one of "+acceptPatch,
+			))
+			return
+		}
+
+		if err != nil {
+			v1.RenderError(w, r, log, err)
 			return
 		}
 
@@ -84,3 +134,14 @@ func New(subscription Subscription) http.HandlerFunc {
 		)
 	}
 }
+
+func readBody(w http.ResponseWriter, r *http.Request, log *slogx.Logger) ([]byte, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.ErrorContext(r.Context(), "failed to read body", slogx.Err(err))
+		render.Problem(w, r, response.BadRequest(response.CodeInvalidJSON, "failed to read body"))
+		return nil, false
+	}
+
+	return body, true
+}
@@ -0,0 +1,283 @@
+package updatev1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	updatev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1/update"
+	"github.com/salivare/subscriptions-service/internal/httpserver/request"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+	subSrv "github.com/salivare/subscriptions-service/internal/services/subscription"
+)
+
+// stored is the document the stub's Patch applies the patch to.
+const stored = `{"price": 400, "end_date": "12-2024"}`
+
+type stubSubscription struct {
+	err error
+
+	gotID  uuid.UUID
+	gotReq *request.UpdateRequest
+	gotDoc []byte
+}
+
+func (s *stubSubscription) Update(_ context.Context, id uuid.UUID, req request.UpdateRequest) (models.Subscription, error) {
+	s.gotID = id
+	s.gotReq = &req
+	return models.Subscription{ID: id}, s.err
+}
+
+func (s *stubSubscription) Patch(
+	_ context.Context,
+	id uuid.UUID,
+	apply func(doc []byte) ([]byte, error),
+) (models.Subscription, error) {
+	s.gotID = id
+
+	doc, err := apply([]byte(stored))
+	if err != nil {
+		return models.Subscription{}, err
+	}
+	s.gotDoc = doc
+
+	return models.Subscription{ID: id}, s.err
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestNew(t *testing.T) {
+	const valid = `{"price": 500}`
+
+	tests := []struct {
+		name        string
+		id          string
+		contentType string
+		body        string
+		err         error
+		wantStatus  int
+		wantCode    string
+		wantReq     *request.UpdateRequest
+		wantDoc     string
+	}{
+		{
+			name:       "ok",
+			id:         uuid.NewString(),
+			body:       valid,
+			wantStatus: http.StatusOK,
+			wantReq:    &request.UpdateRequest{Price: ptr(int64(500))},
+		},
+		{
+			name:       "free",
+			id:         uuid.NewString(),
+			body:       `{"price": 0}`,
+			wantStatus: http.StatusOK,
+			wantReq:    &request.UpdateRequest{Price: ptr(int64(0))},
+		},
+		{
+			name:       "end date",
+			id:         uuid.NewString(),
+			body:       `{"end_date": "12-2024"}`,
+			wantStatus: http.StatusOK,
+			wantReq:    &request.UpdateRequest{EndDate: ptr("12-2024")},
+		},
+		{
+			name:       "end date cleared",
+			id:         uuid.NewString(),
+			body:       `{"end_date": ""}`,
+			wantStatus: http.StatusOK,
+			wantReq:    &request.UpdateRequest{EndDate: ptr("")},
+		},
+		{
+			name:       "invalid id",
+			id:         "not-a-uuid",
+			body:       valid,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidID,
+		},
+		{
+			name:       "invalid json",
+			id:         uuid.NewString(),
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidJSON,
+		},
+		{
+			name:       "validation failed",
+			id:         uuid.NewString(),
+			body:       `{"price": -1}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeValidationFailed,
+		},
+		{
+			name:       "invalid end date",
+			id:         uuid.NewString(),
+			body:       `{"end_date": "2024-12"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeValidationFailed,
+		},
+		{
+			name:       "invalid patch",
+			id:         uuid.NewString(),
+			body:       valid,
+			err:        fmt.Errorf("%w: invalid start_date", subSrv.ErrInvalidInput),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidRequest,
+		},
+		{
+			name:       "not found",
+			id:         uuid.NewString(),
+			body:       valid,
+			err:        subSrv.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   response.CodeSubscriptionNotFound,
+		},
+		{
+			name:       "conflict",
+			id:         uuid.NewString(),
+			body:       valid,
+			err:        subSrv.ErrAlreadyExists,
+			wantStatus: http.StatusConflict,
+			wantCode:   response.CodeSubscriptionExists,
+		},
+		{
+			name:       "storage failure",
+			id:         uuid.NewString(),
+			body:       valid,
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+		{
+			name:        "json with charset",
+			id:          uuid.NewString(),
+			contentType: "application/json; charset=utf-8",
+			body:        valid,
+			wantStatus:  http.StatusOK,
+			wantReq:     &request.UpdateRequest{Price: ptr(int64(500))},
+		},
+		{
+			name:        "merge patch",
+			id:          uuid.NewString(),
+			contentType: "application/merge-patch+json",
+			body:        `{"end_date": null}`,
+			wantStatus:  http.StatusOK,
+			wantDoc:     `{"price": 400}`,
+		},
+		{
+			name:        "invalid merge patch",
+			id:          uuid.NewString(),
+			contentType: "application/merge-patch+json",
+			body:        `{`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    response.CodeInvalidPatch,
+		},
+		{
+			name:        "json patch",
+			id:          uuid.NewString(),
+			contentType: "application/json-patch+json",
+			body:        `[{"op": "test", "path": "/price", "value": 400}, {"op": "replace", "path": "/price", "value": 500}]`,
+			wantStatus:  http.StatusOK,
+			wantDoc:     `{"price": 500, "end_date": "12-2024"}`,
+		},
+		{
+			name:        "json patch test failed",
+			id:          uuid.NewString(),
+			contentType: "application/json-patch+json",
+			body:        `[{"op": "test", "path": "/price", "value": 300}, {"op": "replace", "path": "/price", "value": 500}]`,
+			wantStatus:  http.StatusConflict,
+			wantCode:    response.CodePatchTestFailed,
+		},
+		{
+			name:        "json patch missing path",
+			id:          uuid.NewString(),
+			contentType: "application/json-patch+json",
+			body:        `[{"op": "remove", "path": "/trial_months"}]`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    response.CodeInvalidPatch,
+		},
+		{
+			name:        "json patch unknown op",
+			id:          uuid.NewString(),
+			contentType: "application/json-patch+json",
+			body:        `[{"op": "merge", "path": "/price"}]`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    response.CodeInvalidPatch,
+		},
+		{
+			name:        "json patch concurrent modification",
+			id:          uuid.NewString(),
+			contentType: "application/json-patch+json",
+			body:        `[{"op": "remove", "path": "/end_date"}]`,
+			err:         subSrv.ErrModified,
+			wantStatus:  http.StatusConflict,
+			wantCode:    response.CodeSubscriptionModified,
+		},
+		{
+			name:        "json patch storage failure",
+			id:          uuid.NewString(),
+			contentType: "application/json-patch+json",
+			body:        `[{"op": "remove", "path": "/end_date"}]`,
+			err:         errors.New("boom"),
+			wantStatus:  http.StatusInternalServerError,
+			wantCode:    response.CodeInternalError,
+		},
+		{
+			name:        "unsupported media type",
+			id:          uuid.NewString(),
+			contentType: "text/plain",
+			body:        valid,
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantCode:    response.CodeUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubSubscription{err: tt.err}
+
+				r := router.New()
+				r.PATCH("/api/v1/subscription/{id}", updatev1.New(stub))
+
+				req := httptest.NewRequest(http.MethodPatch, "/api/v1/subscription/"+tt.id, strings.NewReader(tt.body))
+				if tt.contentType != "" {
+					req.Header.Set("Content-Type", tt.contentType)
+				}
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					if tt.wantStatus == http.StatusUnsupportedMediaType {
+						assert.Contains(t, rec.Header().Get("Accept-Patch"), "application/merge-patch+json")
+					}
+					return
+				}
+
+				var got response.SubscriptionResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.Equal(t, tt.id, got.ID.String())
+				assert.Equal(t, tt.id, stub.gotID.String())
+
+				if tt.wantDoc != "" {
+					assert.Nil(t, stub.gotReq, "patches do not go through Update")
+					assert.JSONEq(t, tt.wantDoc, string(stub.gotDoc))
+					return
+				}
+				assert.Equal(t, tt.wantReq, stub.gotReq)
+			},
+		)
+	}
+}
@@ -11,17 +11,26 @@ import (
 )
 
 func ExtractID(w http.ResponseWriter, r *http.Request, log *slogx.Logger) (uuid.UUID, bool) {
-	idStr := router.PathValue(r, "id")
+	return extractUUID(w, r, log, "id")
+}
+
+// ExtractUserID parses the {user_id} path parameter, writing a problem if it is not a UUID.
+func ExtractUserID(w http.ResponseWriter, r *http.Request, log *slogx.Logger) (uuid.UUID, bool) {
+	return extractUUID(w, r, log, "user_id")
+}
+
+func extractUUID(w http.ResponseWriter, r *http.Request, log *slogx.Logger, name string) (uuid.UUID, bool) {
+	idStr := router.PathValue(r, name)
 	if idStr == "" {
-		log.ErrorContext(r.Context(), "missing id in path")
-		render.JSON(w, r, response.Error("id is required"))
+		log.ErrorContext(r.Context(), "missing "+name+" in path")
+		render.Problem(w, r, response.BadRequest(response.CodeInvalidID, name+" is required"))
 		return uuid.UUID{}, false
 	}
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		log.ErrorContext(r.Context(), "invalid uuid", slogx.Err(err))
-		render.JSON(w, r, response.Error("invalid id"))
+		render.Problem(w, r, response.BadRequest(response.CodeInvalidID, "invalid "+name))
 		return uuid.UUID{}, false
 	}
 
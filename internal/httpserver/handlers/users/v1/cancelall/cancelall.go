@@ -0,0 +1,88 @@
+package cancelallv1
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/format"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/request"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Subscription service interface
+type Subscription interface {
+	CancelAll(ctx context.Context, userID uuid.UUID, month *time.Time) (time.Time, []uuid.UUID, error)
+}
+
+// New creates a handler that cancels every open-ended subscription of a user.
+//
+//	@Summary		Cancel all user subscriptions
+//	@Description	Sets end_date (default: current month) on every subscription of the user that has none, in one statement. A subscription that starts later ends in its first month. Subscriptions that already have an end date are left alone.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			user_id	path		string						true	"User ID (UUID)"
+//	@Param			body	body		request.CancelAllRequest	false	"Last month of the subscriptions"
+//	@Success		200		{object}	response.Response{data=response.CancelAllResponse}
+//	@Failure		400		{object}	response.Problem	"Invalid user_id or end_date before the current month"
+//	@Failure		500		{object}	response.Problem	"Internal error"
+//	@Router			/api/v1/users/{user_id}/subscriptions/cancel-all [post]
+func New(subscription Subscription) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.users.cancelall.New"
+		ctx := r.Context()
+		log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+		userID, ok := v1.ExtractUserID(w, r, log)
+		if !ok {
+			return
+		}
+
+		// The body is optional, an empty one means the current month.
+		var reqBody request.CancelAllRequest
+		if err := render.Bind(r, &reqBody); err != nil && !errors.Is(err, io.EOF) {
+			log.ErrorContext(ctx, "invalid json", slogx.Err(err))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidJSON, "invalid json"))
+			return
+		}
+
+		if !request.ValidateStruct(w, r, &reqBody) {
+			return
+		}
+
+		month, err := reqBody.Month()
+		if err != nil {
+			log.ErrorContext(ctx, "invalid month", slogx.Err(err))
+			render.Problem(w, r, response.BadRequest(response.CodeInvalidRequest, err.Error()))
+			return
+		}
+
+		end, ids, err := subscription.CancelAll(ctx, userID, month)
+		if err != nil {
+			v1.RenderError(w, r, log, err)
+			return
+		}
+
+		if ids == nil {
+			ids = []uuid.UUID{}
+		}
+
+		render.JSON(
+			w, r, response.Response{
+				Status: response.StatusOK,
+				Data: response.CancelAllResponse{
+					EndDate:   end.Format(format.MonthYear),
+					Cancelled: ids,
+				},
+			},
+		)
+	}
+}
@@ -0,0 +1,144 @@
+package cancelallv1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	cancelallv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/users/v1/cancelall"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+	subSrv "github.com/salivare/subscriptions-service/internal/services/subscription"
+)
+
+type stubSubscription struct {
+	ids      []uuid.UUID
+	err      error
+	gotUser  uuid.UUID
+	gotMonth *time.Time
+}
+
+func (s *stubSubscription) CancelAll(_ context.Context, userID uuid.UUID, month *time.Time) (time.Time, []uuid.UUID, error) {
+	s.gotUser = userID
+	s.gotMonth = month
+
+	end := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	if month != nil {
+		end = *month
+	}
+
+	return end, s.ids, s.err
+}
+
+func TestNew(t *testing.T) {
+	userID := uuid.NewString()
+	cancelled := uuid.New()
+
+	tests := []struct {
+		name       string
+		userID     string
+		body       string
+		ids        []uuid.UUID
+		err        error
+		wantStatus int
+		wantCode   string
+		wantMonth  *time.Time
+		want       response.CancelAllResponse
+	}{
+		{
+			name:       "default month",
+			userID:     userID,
+			ids:        []uuid.UUID{cancelled},
+			wantStatus: http.StatusOK,
+			want:       response.CancelAllResponse{EndDate: "06-2024", Cancelled: []uuid.UUID{cancelled}},
+		},
+		{
+			name:       "explicit month",
+			userID:     userID,
+			body:       `{"end_date": "09-2024"}`,
+			wantStatus: http.StatusOK,
+			wantMonth:  ptr(time.Date(2024, time.September, 1, 0, 0, 0, 0, time.UTC)),
+			want:       response.CancelAllResponse{EndDate: "09-2024", Cancelled: []uuid.UUID{}},
+		},
+		{
+			name:       "invalid user id",
+			userID:     "not-a-uuid",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidID,
+		},
+		{
+			name:       "invalid json",
+			userID:     userID,
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidJSON,
+		},
+		{
+			name:       "invalid month",
+			userID:     userID,
+			body:       `{"end_date": "2024-09"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeValidationFailed,
+		},
+		{
+			name:       "month in the past",
+			userID:     userID,
+			body:       `{"end_date": "01-2020"}`,
+			err:        fmt.Errorf("%w: end_date cannot be before the current month", subSrv.ErrInvalidDateRange),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidDateRange,
+		},
+		{
+			name:       "storage failure",
+			userID:     userID,
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				srv := &stubSubscription{ids: tt.ids, err: tt.err}
+
+				r := router.New()
+				r.POST("/api/v1/users/{user_id}/subscriptions/cancel-all", cancelallv1.New(srv))
+
+				req := httptest.NewRequest(
+					http.MethodPost,
+					"/api/v1/users/"+tt.userID+"/subscriptions/cancel-all",
+					strings.NewReader(tt.body),
+				)
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					return
+				}
+
+				var got response.CancelAllResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.Equal(t, tt.want, got)
+
+				assert.Equal(t, userID, srv.gotUser.String())
+				assert.Equal(t, tt.wantMonth, srv.gotMonth)
+			},
+		)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,57 @@
+package erasev1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Privacy service interface
+type Privacy interface {
+	Erase(ctx context.Context, userID uuid.UUID) (models.Erasure, error)
+}
+
+// New creates a handler erasing everything held about a user.
+//
+//	@Summary		Erase user
+//	@Description	Hard-deletes every subscription, pause, price change and budget of the user in one transaction and appends a record to the erasure log.
+//	@Description	The record keeps only the SHA-256 of the user id and is hash-chained to the previous one. A user without data is erased too, so every request leaves a record.
+//	@Tags			users
+//	@Produce		json
+//	@Param			user_id	path		string	true	"User ID (UUID)"
+//	@Success		200		{object}	response.Response{data=response.ErasureResponse}
+//	@Failure		400		{object}	response.Problem	"Invalid user_id"
+//	@Failure		500		{object}	response.Problem	"Internal error"
+//	@Router			/api/v1/users/{user_id} [delete]
+func New(privacy Privacy) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.users.erase.New"
+		ctx := r.Context()
+		log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+		userID, ok := v1.ExtractUserID(w, r, log)
+		if !ok {
+			return
+		}
+
+		erasure, err := privacy.Erase(ctx, userID)
+		if err != nil {
+			v1.RenderError(w, r, log, err)
+			return
+		}
+
+		render.JSON(
+			w, r, response.Response{
+				Status: response.StatusOK,
+				Data:   response.ToErasureResponse(erasure),
+			},
+		)
+	}
+}
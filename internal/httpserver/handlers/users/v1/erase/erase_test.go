@@ -0,0 +1,96 @@
+package erasev1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	erasev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/users/v1/erase"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+)
+
+type stubPrivacy struct {
+	err error
+
+	gotUser uuid.UUID
+}
+
+func (s *stubPrivacy) Erase(_ context.Context, userID uuid.UUID) (models.Erasure, error) {
+	s.gotUser = userID
+	if s.err != nil {
+		return models.Erasure{}, s.err
+	}
+
+	at := time.Date(2025, time.March, 2, 10, 0, 0, 123456000, time.UTC)
+	return models.NewErasure(models.Erasure{}, userID, 2, 1, at), nil
+}
+
+func TestNew(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name       string
+		userID     string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "ok", userID: userID.String(), wantStatus: http.StatusOK},
+		{
+			name:       "invalid user id",
+			userID:     "not-a-uuid",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidID,
+		},
+		{
+			name:       "storage failure",
+			userID:     userID.String(),
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubPrivacy{err: tt.err}
+
+				r := router.New()
+				r.DELETE("/api/v1/users/{user_id}", erasev1.New(stub))
+
+				req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/"+tt.userID, nil)
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					return
+				}
+
+				assert.NotContains(t, rec.Body.String(), userID.String(), "only the hash of the user id is returned")
+
+				var got response.ErasureResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.Equal(t, int64(1), got.Seq)
+				assert.Equal(t, models.HashUserID(userID), got.UserHash)
+				assert.Equal(t, 2, got.Subscriptions)
+				assert.Equal(t, 1, got.Budgets)
+				assert.Equal(t, "2025-03-02T10:00:00.123456Z", got.ErasedAt)
+				assert.NotEmpty(t, got.Hash)
+
+				assert.Equal(t, userID, stub.gotUser)
+			},
+		)
+	}
+}
@@ -0,0 +1,57 @@
+package erasurev1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Privacy service interface
+type Privacy interface {
+	Erasure(ctx context.Context, userID uuid.UUID) (models.Erasure, error)
+}
+
+// New creates a handler proving that a user was erased.
+//
+//	@Summary		Erasure proof
+//	@Description	Verifies the whole erasure log and returns the latest record of the user, found by the hash of user_id.
+//	@Tags			users
+//	@Produce		json
+//	@Param			user_id	path		string	true	"User ID (UUID)"
+//	@Success		200		{object}	response.Response{data=response.ErasureResponse}
+//	@Failure		400		{object}	response.Problem	"Invalid user_id"
+//	@Failure		404		{object}	response.Problem	"User was never erased"
+//	@Failure		500		{object}	response.Problem	"Internal error or broken erasure log"
+//	@Router			/api/v1/users/{user_id}/erasure [get]
+func New(privacy Privacy) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.users.erasure.New"
+		ctx := r.Context()
+		log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+		userID, ok := v1.ExtractUserID(w, r, log)
+		if !ok {
+			return
+		}
+
+		erasure, err := privacy.Erasure(ctx, userID)
+		if err != nil {
+			v1.RenderError(w, r, log, err)
+			return
+		}
+
+		render.JSON(
+			w, r, response.Response{
+				Status: response.StatusOK,
+				Data:   response.ToErasureResponse(erasure),
+			},
+		)
+	}
+}
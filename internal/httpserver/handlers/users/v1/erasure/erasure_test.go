@@ -0,0 +1,91 @@
+package erasurev1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	erasurev1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/users/v1/erasure"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+	"github.com/salivare/subscriptions-service/internal/services/privacy"
+)
+
+type stubPrivacy struct {
+	err error
+
+	gotUser uuid.UUID
+}
+
+func (s *stubPrivacy) Erasure(_ context.Context, userID uuid.UUID) (models.Erasure, error) {
+	s.gotUser = userID
+	return models.Erasure{Seq: 7, UserHash: models.HashUserID(userID)}, s.err
+}
+
+func TestNew(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name       string
+		userID     string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "ok", userID: userID.String(), wantStatus: http.StatusOK},
+		{
+			name:       "invalid user id",
+			userID:     "not-a-uuid",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidID,
+		},
+		{
+			name:       "never erased",
+			userID:     userID.String(),
+			err:        privacy.ErrNotErased,
+			wantStatus: http.StatusNotFound,
+			wantCode:   response.CodeErasureNotFound,
+		},
+		{
+			name:       "broken log",
+			userID:     userID.String(),
+			err:        errors.New("erasure 3: hash does not match"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubPrivacy{err: tt.err}
+
+				r := router.New()
+				r.GET("/api/v1/users/{user_id}/erasure", erasurev1.New(stub))
+
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/users/"+tt.userID+"/erasure", nil)
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					return
+				}
+
+				var got response.ErasureResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.Equal(t, int64(7), got.Seq)
+				assert.Equal(t, models.HashUserID(userID), got.UserHash)
+				assert.Equal(t, userID, stub.gotUser)
+			},
+		)
+	}
+}
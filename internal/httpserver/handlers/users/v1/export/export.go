@@ -0,0 +1,58 @@
+package exportv1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Privacy service interface
+type Privacy interface {
+	Export(ctx context.Context, userID uuid.UUID) (models.UserExport, error)
+}
+
+// New creates a handler exporting everything held about a user.
+//
+//	@Summary		Export user data
+//	@Description	Machine-readable archive of every subscription of the user with its pauses and price history, the budgets and the summary of the current month. Served as an attachment.
+//	@Tags			users
+//	@Produce		json
+//	@Param			user_id	path		string	true	"User ID (UUID)"
+//	@Success		200		{object}	response.Response{data=response.UserExportResponse}
+//	@Failure		400		{object}	response.Problem	"Invalid user_id"
+//	@Failure		500		{object}	response.Problem	"Internal error"
+//	@Router			/api/v1/users/{user_id}/export [get]
+func New(privacy Privacy) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.users.export.New"
+		ctx := r.Context()
+		log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+		userID, ok := v1.ExtractUserID(w, r, log)
+		if !ok {
+			return
+		}
+
+		export, err := privacy.Export(ctx, userID)
+		if err != nil {
+			v1.RenderError(w, r, log, err)
+			return
+		}
+
+		w.Header().Set("Content-Disposition", `attachment; filename="user-`+userID.String()+`.json"`)
+
+		render.JSON(
+			w, r, response.Response{
+				Status: response.StatusOK,
+				Data:   response.ToUserExportResponse(export),
+			},
+		)
+	}
+}
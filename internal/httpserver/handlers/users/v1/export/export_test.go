@@ -0,0 +1,117 @@
+package exportv1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	exportv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/users/v1/export"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+)
+
+type stubPrivacy struct {
+	export models.UserExport
+	err    error
+
+	gotUser uuid.UUID
+}
+
+func (s *stubPrivacy) Export(_ context.Context, userID uuid.UUID) (models.UserExport, error) {
+	s.gotUser = userID
+	s.export.UserID = userID
+	return s.export, s.err
+}
+
+func TestNew(t *testing.T) {
+	userID := uuid.New()
+	start := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	price := int64(400)
+
+	export := models.UserExport{
+		ExportedAt: time.Date(2025, time.March, 2, 10, 0, 0, 0, time.UTC),
+		Subscriptions: []models.SubscriptionExport{{
+			Subscription: models.Subscription{ServiceName: "Netflix", Price: &price, UserID: userID, StartDate: start},
+			PriceChanges: []models.PriceChange{{EffectiveFrom: start.AddDate(0, 3, 0), Price: 500}},
+		}},
+		Budgets: []models.Budget{{UserID: userID, Amount: 1000}},
+		Summary: models.UserSummary{UserID: userID, Month: start, ActiveCount: 1, MonthlyCost: 400},
+	}
+
+	tests := []struct {
+		name       string
+		userID     string
+		export     models.UserExport
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "ok", userID: userID.String(), export: export, wantStatus: http.StatusOK},
+		{name: "nothing held", userID: userID.String(), wantStatus: http.StatusOK},
+		{
+			name:       "invalid user id",
+			userID:     "not-a-uuid",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidID,
+		},
+		{
+			name:       "storage failure",
+			userID:     userID.String(),
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubPrivacy{export: tt.export, err: tt.err}
+
+				r := router.New()
+				r.GET("/api/v1/users/{user_id}/export", exportv1.New(stub))
+
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/users/"+tt.userID+"/export", nil)
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					assert.Empty(t, rec.Header().Get("Content-Disposition"))
+					return
+				}
+
+				var got response.UserExportResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.Equal(
+					t, `attachment; filename="user-`+userID.String()+`.json"`, rec.Header().Get("Content-Disposition"),
+				)
+				assert.Equal(t, userID, stub.gotUser)
+
+				want := tt.export
+				want.UserID = userID
+				assert.Equal(t, response.ToUserExportResponse(want), got)
+				assert.NotNil(t, got.Subscriptions, "an empty export is rendered with []")
+				assert.NotNil(t, got.Budgets, "an empty export is rendered with []")
+
+				if len(tt.export.Subscriptions) > 0 {
+					assert.Equal(t, "2025-03-02T10:00:00Z", got.ExportedAt)
+					require.Len(t, got.Subscriptions, 1)
+					require.Len(t, got.Subscriptions[0].PriceChanges, 1)
+					assert.Equal(t, "09-2024", got.Subscriptions[0].PriceChanges[0].EffectiveFrom)
+					assert.Equal(t, int64(500), got.Subscriptions[0].PriceChanges[0].Price)
+				}
+			},
+		)
+	}
+}
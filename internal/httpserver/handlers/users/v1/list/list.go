@@ -0,0 +1,61 @@
+package listv1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Subscription service interface
+type Subscription interface {
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]models.Subscription, error)
+}
+
+// New creates a handler listing the subscriptions of a user.
+//
+//	@Summary		List user subscriptions
+//	@Description	Every subscription of the user, including ended ones, ordered by start date and service.
+//	@Tags			users
+//	@Produce		json
+//	@Param			user_id	path		string	true	"User ID (UUID)"
+//	@Success		200		{object}	response.Response{data=[]response.SubscriptionResponse}
+//	@Failure		400		{object}	response.Problem	"Invalid user_id"
+//	@Failure		500		{object}	response.Problem	"Internal error"
+//	@Router			/api/v1/users/{user_id}/subscriptions [get]
+func New(subscription Subscription) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.users.list.New"
+		ctx := r.Context()
+		log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+		userID, ok := v1.ExtractUserID(w, r, log)
+		if !ok {
+			return
+		}
+
+		subs, err := subscription.ListByUser(ctx, userID)
+		if err != nil {
+			v1.RenderError(w, r, log, err)
+			return
+		}
+
+		data := make([]response.SubscriptionResponse, 0, len(subs))
+		for _, sub := range subs {
+			data = append(data, response.ToSubscriptionResponse(sub))
+		}
+
+		render.JSON(
+			w, r, response.Response{
+				Status: response.StatusOK,
+				Data:   data,
+			},
+		)
+	}
+}
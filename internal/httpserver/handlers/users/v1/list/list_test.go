@@ -0,0 +1,100 @@
+package listv1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	listv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/users/v1/list"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+)
+
+type stubSubscription struct {
+	subs   []models.Subscription
+	err    error
+	userID uuid.UUID
+}
+
+func (s *stubSubscription) ListByUser(_ context.Context, userID uuid.UUID) ([]models.Subscription, error) {
+	s.userID = userID
+	return s.subs, s.err
+}
+
+func TestNew(t *testing.T) {
+	price := int64(400)
+	userID := uuid.New()
+	sub := models.Subscription{
+		ID:          uuid.New(),
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      userID,
+		StartDate:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name       string
+		userID     string
+		subs       []models.Subscription
+		err        error
+		wantStatus int
+		wantCode   string
+		want       []response.SubscriptionResponse
+	}{
+		{
+			name:       "ok",
+			userID:     userID.String(),
+			subs:       []models.Subscription{sub},
+			wantStatus: http.StatusOK,
+			want:       []response.SubscriptionResponse{response.ToSubscriptionResponse(sub)},
+		},
+		{name: "empty", userID: userID.String(), wantStatus: http.StatusOK, want: []response.SubscriptionResponse{}},
+		{
+			name:       "invalid user id",
+			userID:     "not-a-uuid",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidID,
+		},
+		{
+			name:       "storage failure",
+			userID:     userID.String(),
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				srv := &stubSubscription{subs: tt.subs, err: tt.err}
+
+				r := router.New()
+				r.GET("/api/v1/users/{user_id}/subscriptions", listv1.New(srv))
+
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/users/"+tt.userID+"/subscriptions", nil)
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					return
+				}
+
+				var got []response.SubscriptionResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.Equal(t, tt.want, got, "an empty list is rendered as []")
+				assert.Equal(t, userID, srv.userID)
+			},
+		)
+	}
+}
@@ -0,0 +1,56 @@
+package summaryv1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	v1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/subscriptions/v1"
+	"github.com/salivare/subscriptions-service/internal/httpserver/render"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// Subscription service interface
+type Subscription interface {
+	Summary(ctx context.Context, userID uuid.UUID) (models.UserSummary, error)
+}
+
+// New creates a handler summarizing the current month of a user.
+//
+//	@Summary		User summary
+//	@Description	Active subscriptions (paused ones excluded), the cost of the current month and the service that costs the most in it. Costs are priced like the sum: pauses, trials and scheduled prices apply.
+//	@Tags			users
+//	@Produce		json
+//	@Param			user_id	path		string	true	"User ID (UUID)"
+//	@Success		200		{object}	response.Response{data=response.UserSummaryResponse}
+//	@Failure		400		{object}	response.Problem	"Invalid user_id"
+//	@Failure		500		{object}	response.Problem	"Internal error"
+//	@Router			/api/v1/users/{user_id}/summary [get]
+func New(subscription Subscription) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.users.summary.New"
+		ctx := r.Context()
+		log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+		userID, ok := v1.ExtractUserID(w, r, log)
+		if !ok {
+			return
+		}
+
+		summary, err := subscription.Summary(ctx, userID)
+		if err != nil {
+			v1.RenderError(w, r, log, err)
+			return
+		}
+
+		render.JSON(
+			w, r, response.Response{
+				Status: response.StatusOK,
+				Data:   response.ToUserSummaryResponse(summary),
+			},
+		)
+	}
+}
@@ -0,0 +1,112 @@
+package summaryv1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	summaryv1 "github.com/salivare/subscriptions-service/internal/httpserver/handlers/users/v1/summary"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+)
+
+type stubSubscription struct {
+	summary models.UserSummary
+	err     error
+
+	gotUser uuid.UUID
+}
+
+func (s *stubSubscription) Summary(_ context.Context, userID uuid.UUID) (models.UserSummary, error) {
+	s.gotUser = userID
+	s.summary.UserID = userID
+	return s.summary, s.err
+}
+
+func TestNew(t *testing.T) {
+	userID := uuid.New()
+	month := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		userID     string
+		summary    models.UserSummary
+		err        error
+		wantStatus int
+		wantCode   string
+		want       response.UserSummaryResponse
+	}{
+		{
+			name:   "ok",
+			userID: userID.String(),
+			summary: models.UserSummary{
+				Month:         month,
+				ActiveCount:   2,
+				MonthlyCost:   600,
+				MostExpensive: &models.ServiceCost{ServiceName: "Netflix", Cost: 400},
+			},
+			wantStatus: http.StatusOK,
+			want: response.UserSummaryResponse{
+				UserID:        userID,
+				Month:         "06-2024",
+				ActiveCount:   2,
+				MonthlyCost:   600,
+				MostExpensive: &response.ServiceCostResponse{ServiceName: "Netflix", Cost: 400},
+			},
+		},
+		{
+			name:       "nothing billed",
+			userID:     userID.String(),
+			summary:    models.UserSummary{Month: month},
+			wantStatus: http.StatusOK,
+			want:       response.UserSummaryResponse{UserID: userID, Month: "06-2024"},
+		},
+		{
+			name:       "invalid user id",
+			userID:     "not-a-uuid",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   response.CodeInvalidID,
+		},
+		{
+			name:       "storage failure",
+			userID:     userID.String(),
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   response.CodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				stub := &stubSubscription{summary: tt.summary, err: tt.err}
+
+				r := router.New()
+				r.GET("/api/v1/users/{user_id}/summary", summaryv1.New(stub))
+
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/users/"+tt.userID+"/summary", nil)
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				if tt.wantCode != "" {
+					handlertest.Problem(t, rec, tt.wantStatus, tt.wantCode)
+					return
+				}
+
+				var got response.UserSummaryResponse
+				handlertest.Data(t, rec, tt.wantStatus, &got)
+				assert.Equal(t, tt.want, got)
+				assert.Equal(t, userID, stub.gotUser)
+			},
+		)
+	}
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/salivare/subscriptions-service/internal/storage"
+)
+
+// HeaderReadYourWrites lets a client pin every read of the request to the primary database.
+const HeaderReadYourWrites = "X-Read-Your-Writes"
+
+// ReadYourWrites routes reads to the primary when the request carries "X-Read-Your-Writes: true".
+func ReadYourWrites(next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if pin, _ := strconv.ParseBool(r.Header.Get(HeaderReadYourWrites)); pin {
+				r = r.WithContext(storage.WithPrimary(r.Context()))
+			}
+
+			next.ServeHTTP(w, r)
+		},
+	)
+}
@@ -0,0 +1,253 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/salivare/subscriptions-service/internal/config"
+)
+
+// CORS answers preflight requests and decorates cross-origin responses according to cfg.
+// Preflights never reach the route handlers.
+func CORS(cfg config.CORSConfig) func(http.Handler) http.Handler {
+	c := newCORSPolicy(cfg)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				c.serve(w, r, next)
+			},
+		)
+	}
+}
+
+// ReloadableCORS is the CORS middleware with a policy that can be swapped at runtime.
+// While disabled it passes every request through untouched.
+type ReloadableCORS struct {
+	policy atomic.Pointer[corsPolicy]
+}
+
+// NewReloadableCORS ReloadableCORS constructor.
+func NewReloadableCORS(cfg config.CORSConfig) *ReloadableCORS {
+	c := &ReloadableCORS{}
+	c.Update(cfg)
+
+	return c
+}
+
+// Update atomically replaces the policy; requests in flight keep the old one.
+func (c *ReloadableCORS) Update(cfg config.CORSConfig) {
+	if !cfg.Enabled {
+		c.policy.Store(nil)
+		return
+	}
+
+	c.policy.Store(newCORSPolicy(cfg))
+}
+
+// Middleware implements the router middleware signature.
+func (c *ReloadableCORS) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			policy := c.policy.Load()
+			if policy == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			policy.serve(w, r, next)
+		},
+	)
+}
+
+type corsPolicy struct {
+	anyOrigin        bool
+	origins          map[string]struct{}
+	wildcards        []wildcardOrigin
+	methods          map[string]struct{}
+	methodList       string
+	anyHeader        bool
+	headers          map[string]struct{}
+	exposedHeaders   []string
+	allowCredentials bool
+	maxAge           string
+}
+
+// wildcardOrigin matches "scheme://*.domain" origins.
+type wildcardOrigin struct {
+	prefix string
+	suffix string
+}
+
+func newCORSPolicy(cfg config.CORSConfig) *corsPolicy {
+	c := &corsPolicy{
+		origins:          make(map[string]struct{}),
+		methods:          make(map[string]struct{}),
+		headers:          make(map[string]struct{}),
+		exposedHeaders:   cfg.ExposedHeaders,
+		allowCredentials: cfg.AllowCredentials,
+	}
+
+	for _, o := range cfg.AllowedOrigins {
+		o = strings.ToLower(strings.TrimSpace(o))
+
+		switch {
+		case o == "*":
+			c.anyOrigin = true
+		case strings.Contains(o, "://*."):
+			prefix, suffix, _ := strings.Cut(o, "*")
+			c.wildcards = append(c.wildcards, wildcardOrigin{prefix: prefix, suffix: suffix})
+		default:
+			c.origins[o] = struct{}{}
+		}
+	}
+
+	methods := make([]string, 0, len(cfg.AllowedMethods))
+	for _, m := range cfg.AllowedMethods {
+		m = strings.ToUpper(strings.TrimSpace(m))
+		c.methods[m] = struct{}{}
+		methods = append(methods, m)
+	}
+	c.methodList = strings.Join(methods, ", ")
+
+	for _, h := range cfg.AllowedHeaders {
+		h = strings.TrimSpace(h)
+		if h == "*" {
+			c.anyHeader = true
+			continue
+		}
+		c.headers[http.CanonicalHeaderKey(h)] = struct{}{}
+	}
+
+	if cfg.MaxAge > 0 {
+		c.maxAge = strconv.Itoa(int(cfg.MaxAge.Seconds()))
+	}
+
+	return c
+}
+
+func (c *corsPolicy) serve(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	w.Header().Add("Vary", "Origin")
+
+	if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+		c.preflight(w, r, origin)
+		return
+	}
+
+	if c.originAllowed(origin) {
+		c.setOrigin(w, origin)
+
+		if len(c.exposedHeaders) > 0 {
+			w.Header().Set("Access-Control-Expose-Headers", strings.Join(c.exposedHeaders, ", "))
+		}
+	}
+
+	next.ServeHTTP(w, r)
+}
+
+func (c *corsPolicy) preflight(w http.ResponseWriter, r *http.Request, origin string) {
+	h := w.Header()
+	h.Add("Vary", "Access-Control-Request-Method")
+	h.Add("Vary", "Access-Control-Request-Headers")
+
+	method := strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))
+	requested := parseHeaderList(r.Header.Get("Access-Control-Request-Headers"))
+
+	if !c.originAllowed(origin) || !c.methodAllowed(method) || !c.headersAllowed(requested) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	c.setOrigin(w, origin)
+	h.Set("Access-Control-Allow-Methods", c.methodList)
+
+	if len(requested) > 0 {
+		h.Set("Access-Control-Allow-Headers", strings.Join(requested, ", "))
+	}
+
+	if c.maxAge != "" {
+		h.Set("Access-Control-Max-Age", c.maxAge)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *corsPolicy) setOrigin(w http.ResponseWriter, origin string) {
+	if c.anyOrigin && !c.allowCredentials {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+	}
+
+	if c.allowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func (c *corsPolicy) originAllowed(origin string) bool {
+	if c.anyOrigin {
+		return true
+	}
+
+	origin = strings.ToLower(origin)
+
+	if _, ok := c.origins[origin]; ok {
+		return true
+	}
+
+	for _, w := range c.wildcards {
+		if len(origin) > len(w.prefix)+len(w.suffix) &&
+			strings.HasPrefix(origin, w.prefix) &&
+			strings.HasSuffix(origin, w.suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *corsPolicy) methodAllowed(method string) bool {
+	if method == http.MethodOptions {
+		return true
+	}
+
+	_, ok := c.methods[method]
+	return ok
+}
+
+func (c *corsPolicy) headersAllowed(headers []string) bool {
+	if c.anyHeader {
+		return true
+	}
+
+	for _, h := range headers {
+		if _, ok := c.headers[http.CanonicalHeaderKey(h)]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseHeaderList(v string) []string {
+	if v == "" {
+		return nil
+	}
+
+	var headers []string
+	for _, h := range strings.Split(v, ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			headers = append(headers, h)
+		}
+	}
+
+	return headers
+}
@@ -0,0 +1,162 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/config"
+	"github.com/salivare/subscriptions-service/internal/httpserver/middleware"
+)
+
+func TestCORS(t *testing.T) {
+	cfg := config.CORSConfig{
+		Enabled:          true,
+		AllowedOrigins:   []string{"https://app.example.com", "https://*.dashboard.example.com"},
+		AllowedMethods:   []string{"GET", "POST", "PATCH"},
+		AllowedHeaders:   []string{"Content-Type", "X-Request-ID"},
+		ExposedHeaders:   []string{"X-Request-ID"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+
+	tests := []struct {
+		name          string
+		method        string
+		origin        string
+		reqMethod     string
+		reqHeaders    string
+		wantStatus    int
+		wantOrigin    string
+		wantMaxAge    string
+		wantNextCalls bool
+	}{
+		{
+			name:          "no origin",
+			method:        http.MethodGet,
+			wantStatus:    http.StatusOK,
+			wantNextCalls: true,
+		},
+		{
+			name:          "simple request from allowed origin",
+			method:        http.MethodGet,
+			origin:        "https://app.example.com",
+			wantStatus:    http.StatusOK,
+			wantOrigin:    "https://app.example.com",
+			wantNextCalls: true,
+		},
+		{
+			name:          "simple request from unknown origin",
+			method:        http.MethodGet,
+			origin:        "https://evil.example.org",
+			wantStatus:    http.StatusOK,
+			wantNextCalls: true,
+		},
+		{
+			name:       "preflight from wildcard subdomain",
+			method:     http.MethodOptions,
+			origin:     "https://eu.dashboard.example.com",
+			reqMethod:  http.MethodPatch,
+			reqHeaders: "content-type, x-request-id",
+			wantStatus: http.StatusNoContent,
+			wantOrigin: "https://eu.dashboard.example.com",
+			wantMaxAge: "600",
+		},
+		{
+			name:       "preflight from bare wildcard domain",
+			method:     http.MethodOptions,
+			origin:     "https://dashboard.example.com",
+			reqMethod:  http.MethodGet,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "preflight with disallowed method",
+			method:     http.MethodOptions,
+			origin:     "https://app.example.com",
+			reqMethod:  http.MethodDelete,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "preflight with disallowed header",
+			method:     http.MethodOptions,
+			origin:     "https://app.example.com",
+			reqMethod:  http.MethodPost,
+			reqHeaders: "X-Debug",
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				nextCalled := false
+				next := http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						nextCalled = true
+						w.WriteHeader(http.StatusOK)
+					},
+				)
+
+				req := httptest.NewRequest(tt.method, "/api/v1/subscription", nil)
+				if tt.origin != "" {
+					req.Header.Set("Origin", tt.origin)
+				}
+				if tt.reqMethod != "" {
+					req.Header.Set("Access-Control-Request-Method", tt.reqMethod)
+				}
+				if tt.reqHeaders != "" {
+					req.Header.Set("Access-Control-Request-Headers", tt.reqHeaders)
+				}
+				rec := httptest.NewRecorder()
+
+				middleware.CORS(cfg)(next).ServeHTTP(rec, req)
+
+				assert.Equal(t, tt.wantStatus, rec.Code)
+				assert.Equal(t, tt.wantNextCalls, nextCalled)
+				assert.Equal(t, tt.wantOrigin, rec.Header().Get("Access-Control-Allow-Origin"))
+				assert.Equal(t, tt.wantMaxAge, rec.Header().Get("Access-Control-Max-Age"))
+
+				if tt.wantOrigin != "" {
+					assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+				}
+			},
+		)
+	}
+}
+
+func TestReloadableCORS_Update(t *testing.T) {
+	cfg := config.CORSConfig{
+		Enabled:        true,
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{"GET"},
+	}
+
+	c := middleware.NewReloadableCORS(cfg)
+	h := c.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	allowOrigin := func(origin string) string {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/subscription", nil)
+		req.Header.Set("Origin", origin)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		return rec.Header().Get("Access-Control-Allow-Origin")
+	}
+
+	assert.Equal(t, "https://app.example.com", allowOrigin("https://app.example.com"))
+	assert.Empty(t, allowOrigin("https://admin.example.com"))
+
+	cfg.AllowedOrigins = []string{"https://admin.example.com"}
+	c.Update(cfg)
+
+	assert.Empty(t, allowOrigin("https://app.example.com"))
+	assert.Equal(t, "https://admin.example.com", allowOrigin("https://admin.example.com"))
+
+	cfg.Enabled = false
+	c.Update(cfg)
+
+	assert.Empty(t, allowOrigin("https://admin.example.com"))
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
 )
 
 // LogFieldRequestID constant to add request_id field.
@@ -53,6 +54,7 @@ func Logger(log *slogx.Logger) func(next http.Handler) http.Handler {
 				defer func() {
 					entry.Info(
 						"request completed",
+						slog.String("route", router.RoutePattern(r.Context())),
 						slog.Int("status", ww.StatusCode()),
 						slog.Int("bytes", ww.BytesWritten()),
 						slog.Duration("duration", time.Since(t1)),
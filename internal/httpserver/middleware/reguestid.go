@@ -5,25 +5,22 @@ import (
 	"net/http"
 
 	"github.com/google/uuid"
-)
-
-type ctxKey string
 
-const requestIDKey ctxKey = "request_id"
+	"github.com/salivare/subscriptions-service/internal/httpserver/requestid"
+)
 
 // RequestID receives request_id.
 func RequestID(next http.Handler) http.Handler {
 	return http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
-			id := r.Header.Get("X-Request-ID")
+			id := r.Header.Get(requestid.Header)
 			if id == "" {
 				id = uuid.NewString()
 			}
 
-			ctx := context.WithValue(r.Context(), requestIDKey, id)
-			r = r.WithContext(ctx)
+			r = r.WithContext(requestid.WithID(r.Context(), id))
 
-			w.Header().Set("X-Request-ID", id)
+			w.Header().Set(requestid.Header, id)
 
 			next.ServeHTTP(w, r)
 		},
@@ -31,6 +28,5 @@ func RequestID(next http.Handler) http.Handler {
 }
 
 func GetRequestID(ctx context.Context) string {
-	v, _ := ctx.Value(requestIDKey).(string)
-	return v
+	return requestid.FromContext(ctx)
 }
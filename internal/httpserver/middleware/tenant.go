@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/salivare-io/slogx"
+
+	"github.com/salivare/subscriptions-service/internal/config"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/tenant"
+)
+
+// LogFieldTenantID constant to add tenant_id field.
+const LogFieldTenantID = "tenant_id"
+
+// Tenant resolves the tenant of every request and puts it into the context, where
+// the storage picks it up. With tenancy disabled every request gets the default tenant.
+//
+// Otherwise the tenant is read from the configured header as is. The service does not
+// authenticate callers, so it trusts the header: a gateway in front of it must
+// authenticate the caller, drop any tenant header the client sent and set its own.
+// Row-level security isolates tenants only as far as that gateway can be trusted.
+func Tenant(cfg config.TenancyConfig) func(http.Handler) http.Handler {
+	defaultTenant := cfg.DefaultTenant
+	if defaultTenant == "" {
+		defaultTenant = tenant.Default
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				id := defaultTenant
+
+				if cfg.Enabled {
+					var p *response.Problem
+					if id, p = resolveTenant(cfg, r); p != nil {
+						slogx.FromContext(r.Context()).WarnContext(r.Context(), "tenant rejected", slog.String("detail", p.Detail))
+						writeProblem(w, r, *p)
+						return
+					}
+				}
+
+				ctx := tenant.WithID(r.Context(), id)
+				ctx = slogx.ToContext(ctx, slogx.FromContext(ctx).With(slog.String(LogFieldTenantID, id)))
+
+				next.ServeHTTP(w, r.WithContext(ctx))
+			},
+		)
+	}
+}
+
+func resolveTenant(cfg config.TenancyConfig, r *http.Request) (string, *response.Problem) {
+	id := strings.TrimSpace(r.Header.Get(cfg.Header))
+
+	if id == "" {
+		p := response.BadRequest(response.CodeTenantRequired, "tenant is required: set the "+cfg.Header+" header")
+		return "", &p
+	}
+
+	if err := tenant.Validate(id); err != nil {
+		p := response.BadRequest(response.CodeInvalidTenant, err.Error())
+		return "", &p
+	}
+
+	return id, nil
+}
+
+// writeProblem renders p like render.Problem, which this package cannot import.
+func writeProblem(w http.ResponseWriter, r *http.Request, p response.Problem) {
+	p.Instance = r.URL.Path
+	p.RequestID = GetRequestID(r.Context())
+
+	w.Header().Set("Content-Type", p.ContentType())
+	w.WriteHeader(p.StatusCode())
+	_ = json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,83 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/config"
+	"github.com/salivare/subscriptions-service/internal/httpserver/middleware"
+	"github.com/salivare/subscriptions-service/internal/tenant"
+)
+
+func TestTenant(t *testing.T) {
+	enabled := config.TenancyConfig{Enabled: true, Header: "X-Tenant-ID", DefaultTenant: "default"}
+
+	tests := []struct {
+		name       string
+		cfg        config.TenancyConfig
+		header     string
+		auth       string
+		wantStatus int
+		wantCode   string
+		wantTenant string
+	}{
+		{
+			name:       "disabled uses the default tenant",
+			cfg:        config.TenancyConfig{DefaultTenant: "main"},
+			header:     "acme",
+			wantStatus: http.StatusOK,
+			wantTenant: "main",
+		},
+		{name: "disabled without default", cfg: config.TenancyConfig{}, wantStatus: http.StatusOK, wantTenant: tenant.Default},
+		{name: "header", cfg: enabled, header: "acme", wantStatus: http.StatusOK, wantTenant: "acme"},
+		{
+			name:       "bearer token does not name the tenant",
+			cfg:        enabled,
+			header:     "acme",
+			auth:       "Bearer e30.eyJ0ZW5hbnRfaWQiOiJnbG9iZXgifQ.sig",
+			wantStatus: http.StatusOK,
+			wantTenant: "acme",
+		},
+		{
+			name:       "only the configured header counts",
+			cfg:        config.TenancyConfig{Enabled: true, Header: "X-Business-Unit"},
+			header:     "acme",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "TENANT_REQUIRED",
+		},
+		{name: "missing", cfg: enabled, wantStatus: http.StatusBadRequest, wantCode: "TENANT_REQUIRED"},
+		{name: "invalid id", cfg: enabled, header: "acme corp", wantStatus: http.StatusBadRequest, wantCode: "INVALID_TENANT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				var got string
+				next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					got, _ = tenant.FromContext(r.Context())
+				})
+
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/subscription/sum", nil)
+				if tt.header != "" {
+					req.Header.Set("X-Tenant-ID", tt.header)
+				}
+				if tt.auth != "" {
+					req.Header.Set("Authorization", tt.auth)
+				}
+				rec := httptest.NewRecorder()
+
+				middleware.Tenant(tt.cfg)(next).ServeHTTP(rec, req)
+
+				require.Equal(t, tt.wantStatus, rec.Code)
+				assert.Equal(t, tt.wantTenant, got)
+				if tt.wantCode != "" {
+					assert.Contains(t, rec.Body.String(), `"code":"`+tt.wantCode+`"`)
+				}
+			},
+		)
+	}
+}
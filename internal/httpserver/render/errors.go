@@ -0,0 +1,51 @@
+package render
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/salivare-io/slogx"
+
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+)
+
+// ErrorMapping maps a service error that is the client's fault to a status and code.
+type ErrorMapping struct {
+	Target error
+	Status int
+	Code   string
+	// Detail replaces the message of Target in the problem, if set.
+	Detail string
+}
+
+// ErrorMappings is the error table of a handler group, checked in order.
+type ErrorMappings []ErrorMapping
+
+// Problem returns the problem of the first mapping err matches with errors.Is.
+// Its detail never comes from err itself, whose wrapping may name internal operations.
+// Anything not listed is reported as 500.
+func (ms ErrorMappings) Problem(err error) response.Problem {
+	for _, m := range ms {
+		if errors.Is(err, m.Target) {
+			detail := m.Detail
+			if detail == "" {
+				detail = m.Target.Error()
+			}
+
+			return response.NewProblem(m.Status, m.Code, detail)
+		}
+	}
+
+	return response.Internal()
+}
+
+// Error logs err at a level matching the status of p and writes p.
+func Error(w http.ResponseWriter, r *http.Request, log *slogx.Logger, err error, p response.Problem) {
+	if p.Status >= http.StatusInternalServerError {
+		log.ErrorContext(r.Context(), "request failed", slogx.Err(err))
+	} else {
+		log.WarnContext(r.Context(), "request rejected", slogx.Err(err))
+	}
+
+	Problem(w, r, p)
+}
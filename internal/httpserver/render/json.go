@@ -5,14 +5,23 @@ import (
 	"net/http"
 
 	"github.com/salivare/subscriptions-service/internal/httpserver/middleware"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
 )
 
 type StatusCoder interface {
 	StatusCode() int
 }
 
+type ContentTyper interface {
+	ContentType() string
+}
+
 func JSON(w http.ResponseWriter, r *http.Request, v any) {
-	w.Header().Set("Content-Type", "application/json")
+	contentType := "application/json"
+	if ct, ok := v.(ContentTyper); ok {
+		contentType = ct.ContentType()
+	}
+	w.Header().Set("Content-Type", contentType)
 
 	if reqID := middleware.GetRequestID(r.Context()); reqID != "" {
 		w.Header().Set("X-Request-ID", reqID)
@@ -27,6 +36,19 @@ func JSON(w http.ResponseWriter, r *http.Request, v any) {
 	_ = json.NewEncoder(w).Encode(v)
 }
 
+// Problem writes an RFC 7807 error body, filling in the request ID and the request path as instance.
+func Problem(w http.ResponseWriter, r *http.Request, p response.Problem) {
+	if p.RequestID == "" {
+		p.RequestID = middleware.GetRequestID(r.Context())
+	}
+
+	if p.Instance == "" {
+		p.Instance = r.URL.Path
+	}
+
+	JSON(w, r, p)
+}
+
 func Bind(r *http.Request, v any) error {
 	return json.NewDecoder(r.Body).Decode(v)
 }
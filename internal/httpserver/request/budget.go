@@ -0,0 +1,32 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+)
+
+type CreateBudgetRequest struct {
+	UserID string `json:"user_id" validate:"required,uuid"`
+	// ServiceName limits the budget to one service; omit it for the overall budget.
+	ServiceName *string `json:"service_name" validate:"omitempty,min=1"`
+	Amount      *int64  `json:"amount" validate:"required,min=0"`
+}
+
+type UpdateBudgetRequest struct {
+	Amount *int64 `json:"amount" validate:"required,min=0"`
+}
+
+func (r CreateBudgetRequest) ToModel() (models.Budget, error) {
+	userID, err := uuid.Parse(r.UserID)
+	if err != nil {
+		return models.Budget{}, fmt.Errorf("invalid user_id: %w", err)
+	}
+
+	return models.Budget{
+		UserID:      userID,
+		ServiceName: r.ServiceName,
+		Amount:      *r.Amount,
+	}, nil
+}
@@ -0,0 +1,107 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/format"
+)
+
+// SubscriptionDocument is the JSON document merge patches and JSON patches are applied to.
+// A member removed by the patch takes its zero value, so a null end_date means open-ended.
+// ID and UpdatedAt are read-only and may only be used in test operations. UpdatedAt keeps
+// its full precision, so a test on it checks the version the patch is applied to.
+type SubscriptionDocument struct {
+	ID           uuid.UUID `json:"id"`
+	ServiceName  string    `json:"service_name"`
+	Price        *int64    `json:"price"`
+	UserID       string    `json:"user_id"`
+	StartDate    string    `json:"start_date"`
+	EndDate      *string   `json:"end_date"`
+	TrialMonths  int64     `json:"trial_months"`
+	TrialPrice   int64     `json:"trial_price"`
+	AllowOverlap bool      `json:"allow_overlap"`
+	UpdatedAt    string    `json:"updated_at"`
+}
+
+// DocumentOf returns the patchable document of sub.
+func DocumentOf(sub models.Subscription) SubscriptionDocument {
+	var endDate *string
+	if sub.EndDate != nil {
+		s := sub.EndDate.Format(format.MonthYear)
+		endDate = &s
+	}
+
+	return SubscriptionDocument{
+		ID:           sub.ID,
+		ServiceName:  sub.ServiceName,
+		Price:        sub.Price,
+		UserID:       sub.UserID.String(),
+		StartDate:    sub.StartDate.Format(format.MonthYear),
+		EndDate:      endDate,
+		TrialMonths:  int64(sub.TrialMonths),
+		TrialPrice:   sub.TrialPrice,
+		AllowOverlap: sub.AllowOverlap,
+		UpdatedAt:    sub.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// DecodeDocument decodes a patched document, rejecting members it does not know.
+func DecodeDocument(data []byte) (SubscriptionDocument, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var doc SubscriptionDocument
+	if err := dec.Decode(&doc); err != nil {
+		return SubscriptionDocument{}, fmt.Errorf("invalid document: %w", err)
+	}
+
+	return doc, nil
+}
+
+// ApplyTo replaces the fields of sub with those of the document. Like UpdateRequest it
+// cannot change the owner; the read-only members must be left as they were.
+func (d SubscriptionDocument) ApplyTo(sub *models.Subscription) error {
+	current := DocumentOf(*sub)
+
+	if d.ID != current.ID {
+		return errors.New("id is read-only")
+	}
+
+	if d.UpdatedAt != current.UpdatedAt {
+		return errors.New("updated_at is read-only")
+	}
+
+	uid, err := uuid.Parse(d.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user_id: %w", err)
+	}
+	if uid != sub.UserID {
+		return errors.New("user_id cannot be changed, transfer the subscription instead")
+	}
+
+	start, err := parseMonthYear(d.StartDate)
+	if err != nil {
+		return fmt.Errorf("invalid start_date: %w", err)
+	}
+
+	end, err := parseOptionalMonth("end_date", d.EndDate)
+	if err != nil {
+		return err
+	}
+
+	sub.ServiceName = d.ServiceName
+	sub.Price = d.Price
+	sub.StartDate = start
+	sub.EndDate = end
+	sub.TrialMonths = int(d.TrialMonths)
+	sub.TrialPrice = d.TrialPrice
+	sub.AllowOverlap = d.AllowOverlap
+
+	return nil
+}
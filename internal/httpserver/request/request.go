@@ -1,6 +1,7 @@
 package request
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -15,14 +16,25 @@ type CreateRequest struct {
 	UserID      string `json:"user_id" validate:"required,uuid"`
 	StartDate   string `json:"start_date" validate:"required"`
 	EndDate     string `json:"end_date"`
+	// TrialMonths months from start_date are billed at TrialPrice, 0 for a free trial.
+	// TrialPrice must stay 0 without TrialMonths.
+	TrialMonths int64 `json:"trial_months" validate:"min=0,max=120"`
+	TrialPrice  int64 `json:"trial_price" validate:"min=0,excluded_if=TrialMonths 0"`
+	// AllowOverlap lets the period overlap another subscription of the same user and service.
+	AllowOverlap bool `json:"allow_overlap"`
 }
 
+// UpdateRequest changes only the fields it sets; an empty end_date clears the end date.
+// UserID may only repeat the current owner, see TransferRequest.
 type UpdateRequest struct {
-	ServiceName *string `json:"service_name" validate:"omitempty,min=1"`
-	Price       *int64  `json:"price" validate:"omitempty,min=1"`
-	UserID      *string `json:"user_id" validate:"omitempty,uuid4"`
-	StartDate   *string `json:"start_date" validate:"omitempty,datetime=01-2006"`
-	EndDate     *string `json:"end_date" validate:"omitempty,datetime=02-2006"`
+	ServiceName  *string `json:"service_name" validate:"omitempty,min=1"`
+	Price        *int64  `json:"price" validate:"omitempty,min=0"`
+	UserID       *string `json:"user_id" validate:"omitempty,uuid4"`
+	StartDate    *string `json:"start_date" validate:"omitempty,datetime=01-2006"`
+	EndDate      *string `json:"end_date" validate:"omitempty,eq=|datetime=01-2006"`
+	TrialMonths  *int64  `json:"trial_months" validate:"omitempty,min=0,max=120"`
+	TrialPrice   *int64  `json:"trial_price" validate:"omitempty,min=0"`
+	AllowOverlap *bool   `json:"allow_overlap"`
 }
 
 type SumRequest struct {
@@ -34,10 +46,89 @@ type SumRequest struct {
 
 	EndDateFrom *string `json:"end_date_from" validate:"omitempty,datetime=01-2006"`
 	EndDateTo   *string `json:"end_date_to" validate:"omitempty,datetime=01-2006"`
+
+	// AsOf is the month whose prices are summed, the current month by default. It decides
+	// whether the trial price applies; subscriptions not active in it count with their nearest active month.
+	AsOf *string `json:"as_of" validate:"omitempty,datetime=01-2006"`
+}
+
+type SchedulePriceRequest struct {
+	EffectiveFrom string `json:"effective_from" validate:"required,datetime=01-2006"`
+	Price         *int64 `json:"price" validate:"required,min=0"`
+}
+
+// Month returns the first month the new price applies to.
+func (r SchedulePriceRequest) Month() (time.Time, error) {
+	t, err := parseMonthYear(r.EffectiveFrom)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid effective_from: %w", err)
+	}
+
+	return t, nil
+}
+
+// TransferRequest moves a subscription to UserID from EffectiveFrom on, the current month by default.
+type TransferRequest struct {
+	UserID        string  `json:"user_id" validate:"required,uuid"`
+	EffectiveFrom *string `json:"effective_from" validate:"omitempty,datetime=01-2006"`
+}
+
+// Target returns the new owner.
+func (r TransferRequest) Target() (uuid.UUID, error) {
+	id, err := uuid.Parse(r.UserID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user_id: %w", err)
+	}
+
+	return id, nil
+}
+
+// Month returns the first month of the new owner, or nil for the current one.
+func (r TransferRequest) Month() (*time.Time, error) {
+	return parseOptionalMonth("effective_from", r.EffectiveFrom)
+}
+
+// PauseRequest is the optional body of a pause; From defaults to the current month.
+type PauseRequest struct {
+	From *string `json:"from" validate:"omitempty,datetime=01-2006"`
+}
+
+// Month returns the first paused month, or nil for the current one.
+func (r PauseRequest) Month() (*time.Time, error) {
+	return parseOptionalMonth("from", r.From)
+}
+
+// ResumeRequest is the optional body of a resume; From is the first month billed again.
+type ResumeRequest struct {
+	From *string `json:"from" validate:"omitempty,datetime=01-2006"`
+}
+
+// Month returns the first month billed again, or nil for the default.
+func (r ResumeRequest) Month() (*time.Time, error) {
+	return parseOptionalMonth("from", r.From)
+}
+
+// CancelAllRequest is the optional body of a cancel-all; EndDate defaults to the current month.
+type CancelAllRequest struct {
+	EndDate *string `json:"end_date" validate:"omitempty,datetime=01-2006"`
+}
+
+// Month returns the last month of the cancelled subscriptions, or nil for the current one.
+func (r CancelAllRequest) Month() (*time.Time, error) {
+	return parseOptionalMonth("end_date", r.EndDate)
 }
 
 func (r CreateRequest) ToModel() (models.Subscription, error) {
-	return convert(r.ServiceName, r.Price, r.UserID, r.StartDate, r.EndDate)
+	sub, err := convert(r.ServiceName, r.Price, r.UserID, r.StartDate, r.EndDate)
+	if err != nil {
+		return models.Subscription{}, err
+	}
+
+	sub.TrialMonths = int(r.TrialMonths)
+	sub.TrialPrice = r.TrialPrice
+	sub.AllowOverlap = r.AllowOverlap
+
+	return sub, nil
 }
 
 func (r SumRequest) ToFilter() (models.SumFilter, error) {
@@ -70,6 +161,10 @@ func (r SumRequest) ToFilter() (models.SumFilter, error) {
 	if err != nil {
 		return models.SumFilter{}, fmt.Errorf("invalid end_date_to: %w", err)
 	}
+	asOf, err := parse(r.AsOf)
+	if err != nil {
+		return models.SumFilter{}, fmt.Errorf("invalid as_of: %w", err)
+	}
 
 	return models.SumFilter{
 		UserID:        r.UserID,
@@ -78,10 +173,22 @@ func (r SumRequest) ToFilter() (models.SumFilter, error) {
 		StartDateTo:   startTo,
 		EndDateFrom:   endFrom,
 		EndDateTo:     endTo,
+		AsOf:          asOf,
 	}, nil
 }
 
+// ApplyTo patches sub. Ownership cannot be changed by a patch, only by a transfer.
 func (r UpdateRequest) ApplyTo(sub *models.Subscription) error {
+	if r.UserID != nil {
+		uid, err := uuid.Parse(*r.UserID)
+		if err != nil {
+			return fmt.Errorf("invalid user_id: %w", err)
+		}
+		if uid != sub.UserID {
+			return errors.New("user_id cannot be changed, transfer the subscription instead")
+		}
+	}
+
 	if r.ServiceName != nil {
 		sub.ServiceName = *r.ServiceName
 	}
@@ -110,6 +217,18 @@ func (r UpdateRequest) ApplyTo(sub *models.Subscription) error {
 		}
 	}
 
+	if r.TrialMonths != nil {
+		sub.TrialMonths = int(*r.TrialMonths)
+	}
+
+	if r.TrialPrice != nil {
+		sub.TrialPrice = *r.TrialPrice
+	}
+
+	if r.AllowOverlap != nil {
+		sub.AllowOverlap = *r.AllowOverlap
+	}
+
 	return nil
 }
 
@@ -142,6 +261,19 @@ func convert(service string, price *int64, userID string, start string, end stri
 	}, nil
 }
 
+func parseOptionalMonth(field string, s *string) (*time.Time, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	t, err := parseMonthYear(*s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", field, err)
+	}
+
+	return &t, nil
+}
+
 func parseMonthYear(s string) (time.Time, error) {
 	t, err := time.Parse(format.MonthYear, s)
 	if err != nil {
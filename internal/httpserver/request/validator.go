@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
@@ -11,37 +12,74 @@ import (
 	"github.com/salivare/subscriptions-service/internal/httpserver/response"
 )
 
-func ValidationError(errs validator.ValidationErrors) response.Response {
-	var errMsgs []string
+// ValidationError converts validator errors into a problem with one entry per failed field.
+func ValidationError(errs validator.ValidationErrors) response.Problem {
+	fields := make([]response.FieldError, 0, len(errs))
 
 	for _, err := range errs {
-		switch err.ActualTag() {
-		case "required":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is a required field", err.Field()))
-		default:
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not valid", err.Field()))
-		}
+		fields = append(
+			fields, response.FieldError{
+				Field:   err.Field(),
+				Rule:    err.ActualTag(),
+				Param:   err.Param(),
+				Message: fieldMessage(err),
+			},
+		)
 	}
 
-	return response.Response{
-		Status: response.StatusError,
-		Error:  strings.Join(errMsgs, ", "),
-		Code:   http.StatusBadRequest,
+	p := response.BadRequest(response.CodeValidationFailed, "request validation failed")
+	p.Errors = fields
+
+	return p
+}
+
+func fieldMessage(err validator.FieldError) string {
+	switch err.ActualTag() {
+	case "required":
+		return fmt.Sprintf("field %s is a required field", err.Field())
+	case "min":
+		return fmt.Sprintf("field %s must be at least %s", err.Field(), err.Param())
+	case "uuid", "uuid4":
+		return fmt.Sprintf("field %s must be a valid UUID", err.Field())
+	case "datetime":
+		return fmt.Sprintf("field %s must match the %s layout", err.Field(), err.Param())
+	case "excluded_if":
+		return fmt.Sprintf("field %s must be empty when %s", err.Field(), err.Param())
+	default:
+		return fmt.Sprintf("field %s is not valid", err.Field())
 	}
 }
 
-var validate = validator.New()
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+
+	v.RegisterTagNameFunc(
+		func(fld reflect.StructField) string {
+			name, _, _ := strings.Cut(fld.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return fld.Name
+			}
+			return name
+		},
+	)
+
+	return v
+}
 
 func ValidateStruct(w http.ResponseWriter, r *http.Request, req interface{}) bool {
 	if err := validate.Struct(req); err != nil {
 		var vErr validator.ValidationErrors
 		if errors.As(err, &vErr) {
-			resp := ValidationError(vErr)
-			render.JSON(w, r, resp)
+			render.Problem(w, r, ValidationError(vErr))
 			return false
 		}
 
-		render.JSON(w, r, response.Error("invalid request"))
+		render.Problem(w, r, response.BadRequest(response.CodeInvalidRequest, "invalid request"))
 		return false
 	}
 
@@ -0,0 +1,21 @@
+// Package requestid carries the id of a request through the context. It has no
+// dependencies, so the router can read the id without importing the middleware.
+package requestid
+
+import "context"
+
+// Header is the request and response header that carries the request id.
+const Header = "X-Request-ID"
+
+type ctxKey struct{}
+
+// WithID returns a context that carries the request id.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the request id carried by ctx, or "" if there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
@@ -0,0 +1,73 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/format"
+)
+
+type BudgetResponse struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	ServiceName *string   `json:"service_name"`
+	Amount      int64     `json:"amount"`
+	CreatedAt   string    `json:"created_at"`
+	UpdatedAt   string    `json:"updated_at"`
+}
+
+// BudgetMonthResponse is the evaluation of every budget of a user in one month.
+type BudgetMonthResponse struct {
+	Month    string                 `json:"month"`
+	Exceeded bool                   `json:"exceeded"`
+	Budgets  []BudgetStatusResponse `json:"budgets"`
+}
+
+type BudgetStatusResponse struct {
+	BudgetID    uuid.UUID `json:"budget_id"`
+	ServiceName *string   `json:"service_name"`
+	Amount      int64     `json:"amount"`
+	Spent       int64     `json:"spent"`
+	Remaining   int64     `json:"remaining"`
+	Exceeded    bool      `json:"exceeded"`
+}
+
+func ToBudgetResponse(b models.Budget) BudgetResponse {
+	return BudgetResponse{
+		ID:          b.ID,
+		UserID:      b.UserID,
+		ServiceName: b.ServiceName,
+		Amount:      b.Amount,
+		CreatedAt:   b.CreatedAt.Format(time.DateTime),
+		UpdatedAt:   b.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+// ToBudgetMonthResponses groups statuses, ordered by month, into one entry per month.
+func ToBudgetMonthResponses(statuses []models.BudgetStatus) []BudgetMonthResponse {
+	res := make([]BudgetMonthResponse, 0)
+
+	for _, s := range statuses {
+		month := s.Month.Format(format.MonthYear)
+
+		if len(res) == 0 || res[len(res)-1].Month != month {
+			res = append(res, BudgetMonthResponse{Month: month, Budgets: []BudgetStatusResponse{}})
+		}
+
+		last := &res[len(res)-1]
+		last.Exceeded = last.Exceeded || s.Exceeded()
+		last.Budgets = append(
+			last.Budgets, BudgetStatusResponse{
+				BudgetID:    s.Budget.ID,
+				ServiceName: s.Budget.ServiceName,
+				Amount:      s.Budget.Amount,
+				Spent:       s.Spent,
+				Remaining:   s.Remaining(),
+				Exceeded:    s.Exceeded(),
+			},
+		)
+	}
+
+	return res
+}
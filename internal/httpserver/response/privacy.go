@@ -0,0 +1,75 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+)
+
+// UserExportResponse is the archive of everything held about a user.
+type UserExportResponse struct {
+	UserID        uuid.UUID                    `json:"user_id"`
+	ExportedAt    string                       `json:"exported_at"`
+	Subscriptions []SubscriptionExportResponse `json:"subscriptions"`
+	Budgets       []BudgetResponse             `json:"budgets"`
+	Summary       UserSummaryResponse          `json:"summary"`
+}
+
+// SubscriptionExportResponse is a subscription with its past and scheduled prices.
+type SubscriptionExportResponse struct {
+	SubscriptionResponse
+	PriceChanges []PriceChangeResponse `json:"price_changes"`
+}
+
+// ErasureResponse is an erasure record. Hash is the SHA-256 of the other fields, with
+// erased_at in RFC 3339, so the record can be checked without the service.
+type ErasureResponse struct {
+	Seq           int64  `json:"seq"`
+	UserHash      string `json:"user_hash"`
+	Subscriptions int    `json:"subscriptions"`
+	Budgets       int    `json:"budgets"`
+	ErasedAt      string `json:"erased_at"`
+	PrevHash      string `json:"prev_hash"`
+	Hash          string `json:"hash"`
+}
+
+func ToUserExportResponse(e models.UserExport) UserExportResponse {
+	subs := make([]SubscriptionExportResponse, 0, len(e.Subscriptions))
+	for _, s := range e.Subscriptions {
+		changes := make([]PriceChangeResponse, 0, len(s.PriceChanges))
+		for _, c := range s.PriceChanges {
+			changes = append(changes, ToPriceChangeResponse(c))
+		}
+
+		subs = append(subs, SubscriptionExportResponse{
+			SubscriptionResponse: ToSubscriptionResponse(s.Subscription),
+			PriceChanges:         changes,
+		})
+	}
+
+	budgets := make([]BudgetResponse, 0, len(e.Budgets))
+	for _, b := range e.Budgets {
+		budgets = append(budgets, ToBudgetResponse(b))
+	}
+
+	return UserExportResponse{
+		UserID:        e.UserID,
+		ExportedAt:    e.ExportedAt.Format(time.RFC3339),
+		Subscriptions: subs,
+		Budgets:       budgets,
+		Summary:       ToUserSummaryResponse(e.Summary),
+	}
+}
+
+func ToErasureResponse(e models.Erasure) ErasureResponse {
+	return ErasureResponse{
+		Seq:           e.Seq,
+		UserHash:      e.UserHash,
+		Subscriptions: e.Subscriptions,
+		Budgets:       e.Budgets,
+		ErasedAt:      e.ErasedAt.Format(time.RFC3339Nano),
+		PrevHash:      e.PrevHash,
+		Hash:          e.Hash,
+	}
+}
@@ -0,0 +1,94 @@
+package response
+
+import (
+	"net/http"
+)
+
+// ProblemContentType is the media type of RFC 7807 error bodies.
+const ProblemContentType = "application/problem+json"
+
+// Machine-readable error codes carried in Problem.Code.
+const (
+	CodeInvalidJSON           = "INVALID_JSON"
+	CodeInvalidRequest        = "INVALID_REQUEST"
+	CodeInvalidID             = "INVALID_ID"
+	CodeValidationFailed      = "VALIDATION_FAILED"
+	CodeInvalidDateRange      = "INVALID_DATE_RANGE"
+	CodeSubscriptionNotFound  = "SUBSCRIPTION_NOT_FOUND"
+	CodeSubscriptionExists    = "SUBSCRIPTION_ALREADY_EXISTS"
+	CodeAlreadyPaused         = "SUBSCRIPTION_ALREADY_PAUSED"
+	CodeNotPaused             = "SUBSCRIPTION_NOT_PAUSED"
+	CodeSubscriptionOverlap   = "SUBSCRIPTION_OVERLAP"
+	CodeBudgetNotFound        = "BUDGET_NOT_FOUND"
+	CodeBudgetExists          = "BUDGET_ALREADY_EXISTS"
+	CodeErasureNotFound       = "ERASURE_NOT_FOUND"
+	CodeInternalError         = "INTERNAL_ERROR"
+	CodeMissingRequiredFilter = "MISSING_REQUIRED_FILTER"
+	CodeMethodNotAllowed      = "METHOD_NOT_ALLOWED"
+	CodeInvalidPatch          = "INVALID_PATCH"
+	CodePatchTestFailed       = "PATCH_TEST_FAILED"
+	CodeSubscriptionModified  = "SUBSCRIPTION_MODIFIED"
+	CodeUnsupportedMediaType  = "UNSUPPORTED_MEDIA_TYPE"
+	CodeTenantRequired        = "TENANT_REQUIRED"
+	CodeInvalidTenant         = "INVALID_TENANT"
+)
+
+// Problem is an RFC 7807 problem details object extended with a stable error code,
+// the request ID and per-field validation errors.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes a single field that failed validation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+func (p Problem) StatusCode() int {
+	if p.Status == 0 {
+		return http.StatusInternalServerError
+	}
+
+	return p.Status
+}
+
+func (p Problem) ContentType() string {
+	return ProblemContentType
+}
+
+// NewProblem builds a problem with the title taken from the HTTP status text.
+func NewProblem(status int, code, detail string) Problem {
+	return Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+func BadRequest(code, detail string) Problem {
+	return NewProblem(http.StatusBadRequest, code, detail)
+}
+
+func NotFound(code, detail string) Problem {
+	return NewProblem(http.StatusNotFound, code, detail)
+}
+
+func Conflict(code, detail string) Problem {
+	return NewProblem(http.StatusConflict, code, detail)
+}
+
+func Internal() Problem {
+	return NewProblem(http.StatusInternalServerError, CodeInternalError, "internal error")
+}
@@ -9,29 +9,68 @@ import (
 	"github.com/salivare/subscriptions-service/internal/format"
 )
 
-const (
-	StatusOK    = "OK"
-	StatusError = "Error"
-)
-
-const ErrNotFound = "subscription not found"
+const StatusOK = "OK"
 
+// Response is the envelope of successful responses. Errors are rendered as Problem.
 type Response struct {
 	Status string      `json:"status"`
-	Error  interface{} `json:"error,omitempty"`
 	Data   interface{} `json:"data,omitempty"`
 	Code   int         `json:"-"`
 }
 
 type SubscriptionResponse struct {
-	ID          uuid.UUID `json:"id"`
-	ServiceName string    `json:"service_name"`
-	Price       *int64    `json:"price"`
-	UserID      uuid.UUID `json:"user_id"`
-	StartDate   string    `json:"start_date"`
-	EndDate     *string   `json:"end_date"`
-	CreatedAt   string    `json:"created_at"`
-	UpdatedAt   string    `json:"updated_at"`
+	ID           uuid.UUID       `json:"id"`
+	ServiceName  string          `json:"service_name"`
+	Price        *int64          `json:"price"`
+	UserID       uuid.UUID       `json:"user_id"`
+	StartDate    string          `json:"start_date"`
+	EndDate      *string         `json:"end_date"`
+	TrialMonths  int             `json:"trial_months"`
+	TrialPrice   int64           `json:"trial_price"`
+	TrialEndDate *string         `json:"trial_end_date,omitempty"`
+	AllowOverlap bool            `json:"allow_overlap"`
+	Pauses       []PauseResponse `json:"pauses"`
+	CreatedAt    string          `json:"created_at"`
+	UpdatedAt    string          `json:"updated_at"`
+}
+
+type PauseResponse struct {
+	PausedFrom  string  `json:"paused_from"`
+	ResumedFrom *string `json:"resumed_from"`
+}
+
+type PriceChangeResponse struct {
+	EffectiveFrom string `json:"effective_from"`
+	Price         int64  `json:"price"`
+	Scheduled     bool   `json:"scheduled"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// TransferResponse holds both halves of a transfer: the subscription ended for the old
+// owner and its continuation for the new one.
+type TransferResponse struct {
+	Ended        SubscriptionResponse `json:"ended"`
+	Continuation SubscriptionResponse `json:"continuation"`
+}
+
+// UserSummaryResponse describes the subscriptions of a user in Month.
+type UserSummaryResponse struct {
+	UserID        uuid.UUID            `json:"user_id"`
+	Month         string               `json:"month"`
+	ActiveCount   int                  `json:"active_count"`
+	MonthlyCost   int64                `json:"monthly_cost"`
+	MostExpensive *ServiceCostResponse `json:"most_expensive"`
+}
+
+type ServiceCostResponse struct {
+	ServiceName string `json:"service_name"`
+	Cost        int64  `json:"cost"`
+}
+
+// CancelAllResponse lists the subscriptions that now end with EndDate.
+type CancelAllResponse struct {
+	EndDate   string      `json:"end_date"`
+	Cancelled []uuid.UUID `json:"cancelled"`
 }
 
 type SumResponse struct {
@@ -53,45 +92,75 @@ func OK() Response {
 	}
 }
 
-func Error(msg string) Response {
-	return Response{
-		Status: StatusError,
-		Error:  msg,
-		Code:   http.StatusBadRequest,
+func ToSubscriptionResponse(m models.Subscription) SubscriptionResponse {
+	var endDate *string
+	if m.EndDate != nil {
+		s := m.EndDate.Format(format.MonthYear)
+		endDate = &s
+	}
+
+	var trialEnd *string
+	if t := m.TrialEnd(); t != nil {
+		s := t.Format(format.MonthYear)
+		trialEnd = &s
+	}
+
+	return SubscriptionResponse{
+		ID:           m.ID,
+		ServiceName:  m.ServiceName,
+		Price:        m.Price,
+		UserID:       m.UserID,
+		StartDate:    m.StartDate.Format(format.MonthYear),
+		EndDate:      endDate,
+		TrialMonths:  m.TrialMonths,
+		TrialPrice:   m.TrialPrice,
+		TrialEndDate: trialEnd,
+		AllowOverlap: m.AllowOverlap,
+		Pauses:       toPauseResponses(m.Pauses),
+		CreatedAt:    m.CreatedAt.Format(time.DateTime),
+		UpdatedAt:    m.UpdatedAt.Format(time.DateTime),
 	}
 }
 
-func Conflict(msg string) Response {
-	return Response{
-		Status: StatusError,
-		Error:  msg,
-		Code:   http.StatusConflict,
+func toPauseResponses(pauses []models.Pause) []PauseResponse {
+	res := make([]PauseResponse, 0, len(pauses))
+
+	for _, p := range pauses {
+		var resumed *string
+		if p.ResumedFrom != nil {
+			s := p.ResumedFrom.Format(format.MonthYear)
+			resumed = &s
+		}
+
+		res = append(res, PauseResponse{
+			PausedFrom:  p.PausedFrom.Format(format.MonthYear),
+			ResumedFrom: resumed,
+		})
 	}
+
+	return res
 }
 
-func Internal(msg string) Response {
-	return Response{
-		Status: StatusError,
-		Error:  msg,
-		Code:   http.StatusInternalServerError,
+func ToPriceChangeResponse(c models.PriceChange) PriceChangeResponse {
+	return PriceChangeResponse{
+		EffectiveFrom: c.EffectiveFrom.Format(format.MonthYear),
+		Price:         c.Price,
+		Scheduled:     c.EffectiveFrom.After(models.MonthOf(time.Now())),
+		CreatedAt:     c.CreatedAt.Format(time.DateTime),
 	}
 }
 
-func ToSubscriptionResponse(m models.Subscription) SubscriptionResponse {
-	var endDate *string
-	if m.EndDate != nil {
-		s := m.EndDate.Format(format.MonthYear)
-		endDate = &s
+func ToUserSummaryResponse(s models.UserSummary) UserSummaryResponse {
+	var most *ServiceCostResponse
+	if s.MostExpensive != nil {
+		most = &ServiceCostResponse{ServiceName: s.MostExpensive.ServiceName, Cost: s.MostExpensive.Cost}
 	}
 
-	return SubscriptionResponse{
-		ID:          m.ID,
-		ServiceName: m.ServiceName,
-		Price:       m.Price,
-		UserID:      m.UserID,
-		StartDate:   m.StartDate.Format(format.MonthYear),
-		EndDate:     endDate,
-		CreatedAt:   m.CreatedAt.Format(time.DateTime),
-		UpdatedAt:   m.UpdatedAt.Format(time.DateTime),
+	return UserSummaryResponse{
+		UserID:        s.UserID,
+		Month:         s.Month.Format(format.MonthYear),
+		ActiveCount:   s.ActiveCount,
+		MonthlyCost:   s.MonthlyCost,
+		MostExpensive: most,
 	}
 }
@@ -0,0 +1,64 @@
+package router
+
+import (
+	"net/http"
+)
+
+// Group registers routes under a common prefix with a shared middleware chain.
+// Middlewares are bound when a route is registered, so Use only affects routes added afterwards.
+type Group struct {
+	router      *Router
+	prefix      string
+	middlewares []Middleware
+}
+
+// Group creates a nested group that inherits the prefix and middlewares of g.
+func (g *Group) Group(prefix string, mw ...Middleware) *Group {
+	return &Group{
+		router:      g.router,
+		prefix:      g.prefix + prefix,
+		middlewares: g.chain(mw),
+	}
+}
+
+func (g *Group) Use(mw Middleware) {
+	g.middlewares = append(g.middlewares, mw)
+}
+
+func (g *Group) chain(mw []Middleware) []Middleware {
+	chain := make([]Middleware, 0, len(g.middlewares)+len(mw))
+	chain = append(chain, g.middlewares...)
+	return append(chain, mw...)
+}
+
+func (g *Group) add(method, pattern string, h http.Handler, mw []Middleware) {
+	g.router.add(method, g.prefix+pattern, h, g.chain(mw))
+}
+
+func (g *Group) GET(pattern string, h http.HandlerFunc, mw ...Middleware) {
+	g.add(http.MethodGet, pattern, h, mw)
+}
+
+func (g *Group) HEAD(pattern string, h http.HandlerFunc, mw ...Middleware) {
+	g.add(http.MethodHead, pattern, h, mw)
+}
+
+func (g *Group) OPTIONS(pattern string, h http.HandlerFunc, mw ...Middleware) {
+	g.add(http.MethodOptions, pattern, h, mw)
+}
+
+func (g *Group) POST(pattern string, h http.HandlerFunc, mw ...Middleware) {
+	g.add(http.MethodPost, pattern, h, mw)
+}
+
+func (g *Group) PUT(pattern string, h http.HandlerFunc, mw ...Middleware) {
+	g.add(http.MethodPut, pattern, h, mw)
+}
+
+func (g *Group) PATCH(pattern string, h http.HandlerFunc, mw ...Middleware) {
+	g.add(http.MethodPatch, pattern, h, mw)
+}
+
+func (g *Group) DELETE(pattern string, h http.HandlerFunc, mw ...Middleware) {
+	g.add(http.MethodDelete, pattern, h, mw)
+}
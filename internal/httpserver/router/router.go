@@ -2,8 +2,13 @@ package router
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"sort"
 	"strings"
+
+	"github.com/salivare/subscriptions-service/internal/httpserver/requestid"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
 )
 
 type Middleware func(http.Handler) http.Handler
@@ -29,6 +34,7 @@ func New() *Router {
 	}
 }
 
+// Use registers a middleware applied to every request, including 405 and automatic OPTIONS replies.
 func (r *Router) Use(mw Middleware) {
 	r.middlewares = append(r.middlewares, mw)
 }
@@ -41,51 +47,161 @@ func (r *Router) HandleFunc(pattern string, h http.HandlerFunc) {
 	r.mux.HandleFunc(pattern, h)
 }
 
-func (r *Router) add(method, pattern string, h http.Handler) {
+// Group creates a route group whose routes share the prefix and middlewares.
+func (r *Router) Group(prefix string, mw ...Middleware) *Group {
+	return &Group{
+		router:      r,
+		prefix:      prefix,
+		middlewares: mw,
+	}
+}
+
+func (r *Router) add(method, pattern string, h http.Handler, mw []Middleware) {
 	if _, ok := r.routes[method]; !ok {
 		r.routes[method] = []route{}
 	}
 
-	parts := strings.Split(pattern, "/")
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i](h)
+	}
 
 	r.routes[method] = append(
 		r.routes[method], route{
 			pattern: pattern,
 			handler: h,
-			parts:   parts,
+			parts:   strings.Split(pattern, "/"),
 		},
 	)
 }
 
-func (r *Router) GET(pattern string, h http.HandlerFunc) {
-	r.add(http.MethodGet, pattern, h)
+func (r *Router) GET(pattern string, h http.HandlerFunc, mw ...Middleware) {
+	r.add(http.MethodGet, pattern, h, mw)
+}
+
+func (r *Router) HEAD(pattern string, h http.HandlerFunc, mw ...Middleware) {
+	r.add(http.MethodHead, pattern, h, mw)
 }
 
-func (r *Router) POST(pattern string, h http.HandlerFunc) {
-	r.add(http.MethodPost, pattern, h)
+func (r *Router) OPTIONS(pattern string, h http.HandlerFunc, mw ...Middleware) {
+	r.add(http.MethodOptions, pattern, h, mw)
 }
 
-func (r *Router) PATCH(pattern string, h http.HandlerFunc) {
-	r.add(http.MethodPatch, pattern, h)
+func (r *Router) POST(pattern string, h http.HandlerFunc, mw ...Middleware) {
+	r.add(http.MethodPost, pattern, h, mw)
 }
 
-func (r *Router) DELETE(pattern string, h http.HandlerFunc) {
-	r.add(http.MethodDelete, pattern, h)
+func (r *Router) PUT(pattern string, h http.HandlerFunc, mw ...Middleware) {
+	r.add(http.MethodPut, pattern, h, mw)
 }
 
+func (r *Router) PATCH(pattern string, h http.HandlerFunc, mw ...Middleware) {
+	r.add(http.MethodPatch, pattern, h, mw)
+}
+
+func (r *Router) DELETE(pattern string, h http.HandlerFunc, mw ...Middleware) {
+	r.add(http.MethodDelete, pattern, h, mw)
+}
+
+// ServeHTTP dispatches the request to the matching route.
+// HEAD falls back to the GET route, OPTIONS without an explicit route is answered with
+// the Allow header, a known path with an unsupported method yields 405 and
+// everything else goes to the underlying ServeMux.
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	methodRoutes := r.routes[req.Method]
+	path := req.URL.Path
+
+	if rt, params, ok := r.find(req.Method, path); ok {
+		r.serve(w, req, rt, params)
+		return
+	}
 
-	for _, rt := range methodRoutes {
-		ok, params := matchRoute(rt.pattern, req.URL.Path)
-		if ok {
-			req = withPathParams(req, params)
-			r.applyMiddleware(rt.handler).ServeHTTP(w, req)
+	if req.Method == http.MethodHead {
+		if rt, params, ok := r.find(http.MethodGet, path); ok {
+			r.serve(w, req, rt, params)
 			return
 		}
 	}
 
-	r.applyMiddleware(r.mux).ServeHTTP(w, req)
+	allowed := r.allowedMethods(path)
+	if len(allowed) == 0 {
+		r.applyMiddleware(r.mux).ServeHTTP(w, req)
+		return
+	}
+
+	allow := strings.Join(allowed, ", ")
+
+	if req.Method == http.MethodOptions {
+		r.applyMiddleware(
+			http.HandlerFunc(
+				func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Allow", allow)
+					w.WriteHeader(http.StatusNoContent)
+				},
+			),
+		).ServeHTTP(w, req)
+		return
+	}
+
+	r.applyMiddleware(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set("Allow", allow)
+				methodNotAllowed(w, req)
+			},
+		),
+	).ServeHTTP(w, req)
+}
+
+func (r *Router) find(method, path string) (route, map[string]string, bool) {
+	pathParts := strings.Split(path, "/")
+
+	for _, rt := range r.routes[method] {
+		if ok, params := matchParts(rt.parts, pathParts); ok {
+			return rt, params, true
+		}
+	}
+
+	return route{}, nil, false
+}
+
+func (r *Router) serve(w http.ResponseWriter, req *http.Request, rt route, params map[string]string) {
+	req = withPathParams(req, params)
+	req = req.WithContext(context.WithValue(req.Context(), patternKey{}, rt.pattern))
+	r.applyMiddleware(rt.handler).ServeHTTP(w, req)
+}
+
+func (r *Router) allowedMethods(path string) []string {
+	var allowed []string
+
+	for method := range r.routes {
+		if _, _, ok := r.find(method, path); ok {
+			allowed = append(allowed, method)
+		}
+	}
+
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	has := func(m string) bool {
+		for _, a := range allowed {
+			if a == m {
+				return true
+			}
+		}
+		return false
+	}
+
+	if has(http.MethodGet) && !has(http.MethodHead) {
+		allowed = append(allowed, http.MethodHead)
+	}
+
+	if !has(http.MethodOptions) {
+		allowed = append(allowed, http.MethodOptions)
+	}
+
+	sort.Strings(allowed)
+
+	return allowed
 }
 
 func (r *Router) applyMiddleware(h http.Handler) http.Handler {
@@ -99,6 +215,20 @@ func (r *Router) Mux() *http.ServeMux {
 	return r.mux
 }
 
+func methodNotAllowed(w http.ResponseWriter, req *http.Request) {
+	p := response.NewProblem(
+		http.StatusMethodNotAllowed,
+		response.CodeMethodNotAllowed,
+		"method "+req.Method+" is not allowed for "+req.URL.Path,
+	)
+	p.Instance = req.URL.Path
+	p.RequestID = requestid.FromContext(req.Context())
+
+	w.Header().Set("Content-Type", p.ContentType())
+	w.WriteHeader(p.StatusCode())
+	_ = json.NewEncoder(w).Encode(p)
+}
+
 func withPathParams(r *http.Request, params map[string]string) *http.Request {
 	ctx := r.Context()
 	for k, v := range params {
@@ -109,6 +239,8 @@ func withPathParams(r *http.Request, params map[string]string) *http.Request {
 
 type contextKey string
 
+type patternKey struct{}
+
 func PathValue(r *http.Request, key string) string {
 	if v, ok := r.Context().Value(contextKey(key)).(string); ok {
 		return v
@@ -116,14 +248,18 @@ func PathValue(r *http.Request, key string) string {
 	return ""
 }
 
+// RoutePattern returns the pattern of the route that matched the request, e.g. "/api/v1/subscription/{id}".
+// It is empty for requests served by the fallback ServeMux.
+func RoutePattern(ctx context.Context) string {
+	v, _ := ctx.Value(patternKey{}).(string)
+	return v
+}
+
 func QueryValue(r *http.Request, key string) string {
 	return r.URL.Query().Get(key)
 }
 
-func matchRoute(pattern string, path string) (bool, map[string]string) {
-	patternParts := strings.Split(pattern, "/")
-	pathParts := strings.Split(path, "/")
-
+func matchParts(patternParts, pathParts []string) (bool, map[string]string) {
 	if len(patternParts) != len(pathParts) {
 		return false, nil
 	}
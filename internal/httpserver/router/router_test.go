@@ -0,0 +1,171 @@
+package router_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/httpserver/handlers/handlertest"
+	"github.com/salivare/subscriptions-service/internal/httpserver/middleware"
+	"github.com/salivare/subscriptions-service/internal/httpserver/requestid"
+	"github.com/salivare/subscriptions-service/internal/httpserver/response"
+	"github.com/salivare/subscriptions-service/internal/httpserver/router"
+)
+
+func TestRouter_ServeHTTP(t *testing.T) {
+	var gotPattern, gotID string
+
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		gotPattern = router.RoutePattern(r.Context())
+		gotID = router.PathValue(r, "id")
+		w.WriteHeader(http.StatusOK)
+	}
+
+	tagged := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add("X-Chain", "group")
+				next.ServeHTTP(w, r)
+			},
+		)
+	}
+
+	routeTagged := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add("X-Chain", "route")
+				next.ServeHTTP(w, r)
+			},
+		)
+	}
+
+	r := router.New()
+	api := r.Group("/api/v1", tagged)
+	api.GET("/subscription/{id}", ok)
+	api.DELETE("/subscription/{id}", ok, routeTagged)
+	api.POST("/subscription", ok)
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		wantStatus  int
+		wantAllow   string
+		wantPattern string
+		wantChain   []string
+	}{
+		{
+			name:        "matched route",
+			method:      http.MethodGet,
+			path:        "/api/v1/subscription/42",
+			wantStatus:  http.StatusOK,
+			wantPattern: "/api/v1/subscription/{id}",
+			wantChain:   []string{"group"},
+		},
+		{
+			name:        "per-route middleware runs after group middleware",
+			method:      http.MethodDelete,
+			path:        "/api/v1/subscription/42",
+			wantStatus:  http.StatusOK,
+			wantPattern: "/api/v1/subscription/{id}",
+			wantChain:   []string{"group", "route"},
+		},
+		{
+			name:        "head falls back to get",
+			method:      http.MethodHead,
+			path:        "/api/v1/subscription/42",
+			wantStatus:  http.StatusOK,
+			wantPattern: "/api/v1/subscription/{id}",
+			wantChain:   []string{"group"},
+		},
+		{
+			name:       "automatic options",
+			method:     http.MethodOptions,
+			path:       "/api/v1/subscription/42",
+			wantStatus: http.StatusNoContent,
+			wantAllow:  "DELETE, GET, HEAD, OPTIONS",
+		},
+		{
+			name:       "method not allowed",
+			method:     http.MethodPut,
+			path:       "/api/v1/subscription",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantAllow:  "OPTIONS, POST",
+		},
+		{
+			name:       "unknown path",
+			method:     http.MethodGet,
+			path:       "/api/v2/subscription",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				gotPattern, gotID = "", ""
+
+				req := httptest.NewRequest(tt.method, tt.path, nil)
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				assert.Equal(t, tt.wantStatus, rec.Code)
+				assert.Equal(t, tt.wantAllow, rec.Header().Get("Allow"))
+				assert.Equal(t, tt.wantPattern, gotPattern)
+				assert.Equal(t, tt.wantChain, rec.Header().Values("X-Chain"))
+
+				if tt.wantPattern != "" {
+					assert.Equal(t, "42", gotID)
+				}
+			},
+		)
+	}
+}
+
+func TestRouter_MethodNotAllowedProblem(t *testing.T) {
+	ok := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	// contextOnly stores the id in the context without echoing it in the response.
+	contextOnly := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				next.ServeHTTP(w, r.WithContext(requestid.WithID(r.Context(), "from-context")))
+			},
+		)
+	}
+
+	tests := []struct {
+		name   string
+		use    router.Middleware
+		header string
+		want   string
+	}{
+		{name: "request id middleware", use: middleware.RequestID, header: "abc-123", want: "abc-123"},
+		{name: "id only in the context", use: contextOnly, want: "from-context"},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				r := router.New()
+				r.Use(tt.use)
+				r.POST("/api/v1/subscription", ok)
+
+				req := httptest.NewRequest(http.MethodPut, "/api/v1/subscription", nil)
+				if tt.header != "" {
+					req.Header.Set(requestid.Header, tt.header)
+				}
+				rec := httptest.NewRecorder()
+
+				r.ServeHTTP(rec, req)
+
+				p := handlertest.Problem(t, rec, http.StatusMethodNotAllowed, response.CodeMethodNotAllowed)
+				assert.Equal(t, tt.want, p.RequestID)
+				assert.Equal(t, "/api/v1/subscription", p.Instance)
+			},
+		)
+	}
+}
@@ -0,0 +1,435 @@
+// Package jsonpatch applies RFC 7396 JSON Merge Patch and RFC 6902 JSON Patch documents.
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Media types of the two patch formats.
+const (
+	MergePatchContentType = "application/merge-patch+json"
+	JSONPatchContentType  = "application/json-patch+json"
+)
+
+var (
+	// ErrInvalid is returned for a malformed patch or one that cannot be applied to the document,
+	// e.g. because a path does not exist.
+	ErrInvalid = errors.New("invalid patch")
+	// ErrTestFailed is returned when a test operation does not match the document.
+	ErrTestFailed = errors.New("patch test failed")
+)
+
+// MergePatch applies an RFC 7396 merge patch to doc: members of patch replace those of doc,
+// null removes a member and nested objects are merged recursively.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, fmt.Errorf("decode document: %w", err)
+	}
+
+	p, err := decode(patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+
+	return json.Marshal(merge(target, p))
+}
+
+func merge(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any, len(p))
+	}
+
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = merge(t[k], v)
+	}
+
+	return t
+}
+
+// Operation is a single RFC 6902 operation. Value is nil when the member is absent.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Patch is an RFC 6902 JSON Patch document.
+type Patch []Operation
+
+// Decode parses an RFC 6902 document and checks that every operation is well-formed.
+func Decode(data []byte) (Patch, error) {
+	var p Patch
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+
+	for i, op := range p {
+		switch op.Op {
+		case "add", "replace", "test":
+			if op.Value == nil {
+				return nil, fmt.Errorf("%w: operation %d (%s) has no value", ErrInvalid, i, op.Op)
+			}
+		case "move", "copy":
+			if _, err := parsePointer(op.From); err != nil {
+				return nil, fmt.Errorf("%w: operation %d: from: %w", ErrInvalid, i, err)
+			}
+		case "remove":
+		default:
+			return nil, fmt.Errorf("%w: operation %d has unknown op %q", ErrInvalid, i, op.Op)
+		}
+
+		if _, err := parsePointer(op.Path); err != nil {
+			return nil, fmt.Errorf("%w: operation %d: path: %w", ErrInvalid, i, err)
+		}
+	}
+
+	return p, nil
+}
+
+// Apply applies the operations in order. The result is all or nothing: if an operation
+// fails, the error is returned and doc is left as it was.
+func (p Patch) Apply(doc []byte) ([]byte, error) {
+	node, err := decode(doc)
+	if err != nil {
+		return nil, fmt.Errorf("decode document: %w", err)
+	}
+
+	for i, op := range p {
+		node, err = op.apply(node)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+
+	return json.Marshal(node)
+}
+
+func (op Operation) apply(doc any) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+
+	switch op.Op {
+	case "add":
+		value, err := decode(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+		}
+		return add(doc, path, value)
+	case "remove":
+		doc, _, err = remove(doc, path)
+		return doc, err
+	case "replace":
+		value, err := decode(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+		}
+		if doc, _, err = remove(doc, path); err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case "move":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+		}
+		if isPrefix(from, path) && len(from) < len(path) {
+			return nil, fmt.Errorf("%w: cannot move a value into itself", ErrInvalid)
+		}
+		doc, value, err := remove(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+		}
+		value, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		value, err = deepCopy(value)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case "test":
+		want, err := decode(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+		}
+		got, err := get(doc, path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrTestFailed, err)
+		}
+		if !equal(got, want) {
+			return nil, ErrTestFailed
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown op %q", ErrInvalid, op.Op)
+	}
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into unescaped reference tokens.
+func parsePointer(s string) ([]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(s, "/") {
+		return nil, fmt.Errorf("pointer %q must start with /", s)
+	}
+
+	tokens := strings.Split(s[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func get(doc any, path []string) (any, error) {
+	node := doc
+
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]any:
+			child, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: member %q does not exist", ErrInvalid, token)
+			}
+			node = child
+		case []any:
+			i, err := index(token, len(n))
+			if err != nil {
+				return nil, err
+			}
+			node = n[i]
+		default:
+			return nil, fmt.Errorf("%w: %q is not inside an object or array", ErrInvalid, token)
+		}
+	}
+
+	return node, nil
+}
+
+// add sets the value at path, inserting into arrays. It returns the new document.
+func add(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return update(doc, path, func(container any, token string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			c[token] = value
+			return c, nil
+		case []any:
+			i := len(c)
+			if token != "-" {
+				var err error
+				if i, err = index(token, len(c)+1); err != nil {
+					return nil, err
+				}
+			}
+			c = append(c, nil)
+			copy(c[i+1:], c[i:])
+			c[i] = value
+			return c, nil
+		default:
+			return nil, fmt.Errorf("%w: %q is not inside an object or array", ErrInvalid, token)
+		}
+	})
+}
+
+// remove deletes the value at path. It returns the new document and the removed value.
+func remove(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalid)
+	}
+
+	var removed any
+
+	doc, err := update(doc, path, func(container any, token string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			v, ok := c[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: member %q does not exist", ErrInvalid, token)
+			}
+			removed = v
+			delete(c, token)
+			return c, nil
+		case []any:
+			i, err := index(token, len(c))
+			if err != nil {
+				return nil, err
+			}
+			removed = c[i]
+			return append(c[:i], c[i+1:]...), nil
+		default:
+			return nil, fmt.Errorf("%w: %q is not inside an object or array", ErrInvalid, token)
+		}
+	})
+
+	return doc, removed, err
+}
+
+// update walks to the container of the last token of path and replaces it with what fn returns.
+func update(node any, path []string, fn func(container any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(node, path[0])
+	}
+
+	token := path[0]
+
+	switch n := node.(type) {
+	case map[string]any:
+		child, ok := n[token]
+		if !ok {
+			return nil, fmt.Errorf("%w: member %q does not exist", ErrInvalid, token)
+		}
+		child, err := update(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = child
+		return n, nil
+	case []any:
+		i, err := index(token, len(n))
+		if err != nil {
+			return nil, err
+		}
+		child, err := update(n[i], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = child
+		return n, nil
+	default:
+		return nil, fmt.Errorf("%w: %q is not inside an object or array", ErrInvalid, token)
+	}
+}
+
+// index parses an array index that must be below limit; leading zeros are not allowed.
+func index(token string, limit int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: %q is not an array index", ErrInvalid, token)
+	}
+
+	if i >= limit {
+		return 0, fmt.Errorf("%w: index %d is out of range", ErrInvalid, i)
+	}
+
+	return i, nil
+}
+
+// equal compares decoded JSON values; numbers are equal if their values are. Integers
+// are compared exactly, since float64 loses precision above 2^53.
+func equal(a, b any) bool {
+	switch x := a.(type) {
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if x == y {
+			return true
+		}
+		ix, errX := x.Int64()
+		iy, errY := y.Int64()
+		if errX == nil && errY == nil {
+			return ix == iy
+		}
+		fx, errX := x.Float64()
+		fy, errY := y.Float64()
+		return errX == nil && errY == nil && fx == fy
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, v := range x {
+			w, ok := y[k]
+			if !ok || !equal(v, w) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+func deepCopy(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return decode(data)
+}
+
+// decode keeps numbers as json.Number, so integers survive a round trip unchanged.
+func decode(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	if dec.More() {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+
+	return v, nil
+}
@@ -0,0 +1,174 @@
+package jsonpatch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/jsonpatch"
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		wantErr error
+	}{
+		{name: "replace member", doc: `{"a": "b"}`, patch: `{"a": "c"}`, want: `{"a": "c"}`},
+		{name: "add member", doc: `{"a": "b"}`, patch: `{"b": "c"}`, want: `{"a": "b", "b": "c"}`},
+		{name: "null removes", doc: `{"a": "b", "b": "c"}`, patch: `{"a": null}`, want: `{"b": "c"}`},
+		{name: "null on missing member", doc: `{"a": "b"}`, patch: `{"c": null}`, want: `{"a": "b"}`},
+		{name: "arrays are replaced", doc: `{"a": [1, 2]}`, patch: `{"a": [3]}`, want: `{"a": [3]}`},
+		{
+			name:  "nested objects merge",
+			doc:   `{"a": {"b": "c", "d": "e"}}`,
+			patch: `{"a": {"d": null, "f": "g"}}`,
+			want:  `{"a": {"b": "c", "f": "g"}}`,
+		},
+		{name: "non-object patch replaces", doc: `{"a": "b"}`, patch: `["c"]`, want: `["c"]`},
+		{name: "large integers survive", doc: `{"a": 9007199254740993}`, patch: `{}`, want: `{"a": 9007199254740993}`},
+		{name: "malformed patch", doc: `{}`, patch: `{`, wantErr: jsonpatch.ErrInvalid},
+		{name: "trailing data", doc: `{}`, patch: `{} {}`, wantErr: jsonpatch.ErrInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				got, err := jsonpatch.MergePatch([]byte(tt.doc), []byte(tt.patch))
+				if tt.wantErr != nil {
+					require.ErrorIs(t, err, tt.wantErr)
+					return
+				}
+
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.want, string(got))
+			},
+		)
+	}
+}
+
+func TestPatch_Apply(t *testing.T) {
+	const doc = `{"a": 1, "b": {"c": [1, 2, 3]}, "d/e": "f", "g~h": null}`
+
+	tests := []struct {
+		name    string
+		patch   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "add member",
+			patch: `[{"op": "add", "path": "/x", "value": true}]`,
+			want:  `{"a": 1, "b": {"c": [1, 2, 3]}, "d/e": "f", "g~h": null, "x": true}`,
+		},
+		{
+			name:  "add inserts into array",
+			patch: `[{"op": "add", "path": "/b/c/1", "value": 9}]`,
+			want:  `{"a": 1, "b": {"c": [1, 9, 2, 3]}, "d/e": "f", "g~h": null}`,
+		},
+		{
+			name:  "add appends with dash",
+			patch: `[{"op": "add", "path": "/b/c/-", "value": 4}]`,
+			want:  `{"a": 1, "b": {"c": [1, 2, 3, 4]}, "d/e": "f", "g~h": null}`,
+		},
+		{
+			name:  "remove escaped members",
+			patch: `[{"op": "remove", "path": "/d~1e"}, {"op": "remove", "path": "/g~0h"}]`,
+			want:  `{"a": 1, "b": {"c": [1, 2, 3]}}`,
+		},
+		{
+			name:  "remove from array",
+			patch: `[{"op": "remove", "path": "/b/c/0"}]`,
+			want:  `{"a": 1, "b": {"c": [2, 3]}, "d/e": "f", "g~h": null}`,
+		},
+		{
+			name:  "replace",
+			patch: `[{"op": "replace", "path": "/a", "value": {"z": 0}}]`,
+			want:  `{"a": {"z": 0}, "b": {"c": [1, 2, 3]}, "d/e": "f", "g~h": null}`,
+		},
+		{
+			name:  "move",
+			patch: `[{"op": "move", "from": "/b/c", "path": "/c"}]`,
+			want:  `{"a": 1, "b": {}, "c": [1, 2, 3], "d/e": "f", "g~h": null}`,
+		},
+		{
+			name:  "copy is independent",
+			patch: `[{"op": "copy", "from": "/b", "path": "/x"}, {"op": "remove", "path": "/x/c/0"}]`,
+			want:  `{"a": 1, "b": {"c": [1, 2, 3]}, "x": {"c": [2, 3]}, "d/e": "f", "g~h": null}`,
+		},
+		{
+			name:  "test passes",
+			patch: `[{"op": "test", "path": "/a", "value": 1.0}, {"op": "test", "path": "/g~0h", "value": null}]`,
+			want:  doc,
+		},
+		{
+			name:    "test fails",
+			patch:   `[{"op": "replace", "path": "/a", "value": 2}, {"op": "test", "path": "/b/c", "value": [1, 2]}]`,
+			wantErr: jsonpatch.ErrTestFailed,
+		},
+		{
+			name:  "test compares large integers exactly",
+			patch: `[{"op": "add", "path": "/n", "value": 9007199254740993}, {"op": "test", "path": "/n", "value": 9007199254740993}]`,
+			want:  `{"a": 1, "b": {"c": [1, 2, 3]}, "d/e": "f", "g~h": null, "n": 9007199254740993}`,
+		},
+		{
+			name:    "test fails on large integers a float64 cannot tell apart",
+			patch:   `[{"op": "add", "path": "/n", "value": 9007199254740993}, {"op": "test", "path": "/n", "value": 9007199254740992}]`,
+			wantErr: jsonpatch.ErrTestFailed,
+		},
+		{
+			name:    "test on missing member fails",
+			patch:   `[{"op": "test", "path": "/missing", "value": 1}]`,
+			wantErr: jsonpatch.ErrTestFailed,
+		},
+		{name: "remove missing member", patch: `[{"op": "remove", "path": "/missing"}]`, wantErr: jsonpatch.ErrInvalid},
+		{name: "replace missing member", patch: `[{"op": "replace", "path": "/missing", "value": 1}]`, wantErr: jsonpatch.ErrInvalid},
+		{name: "add to missing parent", patch: `[{"op": "add", "path": "/x/y", "value": 1}]`, wantErr: jsonpatch.ErrInvalid},
+		{name: "index out of range", patch: `[{"op": "add", "path": "/b/c/5", "value": 1}]`, wantErr: jsonpatch.ErrInvalid},
+		{name: "leading zero index", patch: `[{"op": "remove", "path": "/b/c/01"}]`, wantErr: jsonpatch.ErrInvalid},
+		{name: "move into itself", patch: `[{"op": "move", "from": "/b", "path": "/b/x"}]`, wantErr: jsonpatch.ErrInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				p, err := jsonpatch.Decode([]byte(tt.patch))
+				require.NoError(t, err)
+
+				got, err := p.Apply([]byte(doc))
+				if tt.wantErr != nil {
+					require.ErrorIs(t, err, tt.wantErr)
+					return
+				}
+
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.want, string(got))
+			},
+		)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+	}{
+		{name: "not an array", patch: `{"op": "add"}`},
+		{name: "unknown op", patch: `[{"op": "merge", "path": "/a"}]`},
+		{name: "missing value", patch: `[{"op": "add", "path": "/a"}]`},
+		{name: "relative path", patch: `[{"op": "remove", "path": "a"}]`},
+		{name: "relative from", patch: `[{"op": "copy", "from": "a", "path": "/b"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				_, err := jsonpatch.Decode([]byte(tt.patch))
+				require.ErrorIs(t, err, jsonpatch.ErrInvalid)
+			},
+		)
+	}
+}
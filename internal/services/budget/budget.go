@@ -0,0 +1,315 @@
+package budget
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/format"
+	"github.com/salivare/subscriptions-service/internal/storage"
+)
+
+// MaxEvaluationMonths bounds the number of months a single evaluation covers.
+const MaxEvaluationMonths = 36
+
+// ExceededChannel is the channel budget exceeded events are published on.
+const ExceededChannel = "budget_exceeded"
+
+var (
+	ErrNotFound      = errors.New("budget not found")
+	ErrAlreadyExists = errors.New("budget already exists")
+	ErrInvalidPeriod = fmt.Errorf("evaluation period must be from <= to and at most %d months", MaxEvaluationMonths)
+)
+
+// Saver Save Signature interface
+type Saver interface {
+	SaveBudget(ctx context.Context, budget models.Budget) (models.Budget, error)
+}
+
+// Updater Update Signature interface
+type Updater interface {
+	UpdateBudget(ctx context.Context, id uuid.UUID, amount int64) (models.Budget, error)
+}
+
+// Deleter Delete Signature interface
+type Deleter interface {
+	DeleteBudget(ctx context.Context, id uuid.UUID) error
+}
+
+// Getter Get Signature interface
+type Getter interface {
+	BudgetByID(ctx context.Context, id uuid.UUID) (models.Budget, error)
+}
+
+// Lister lists the budgets of a user, the overall one first.
+type Lister interface {
+	BudgetsByUser(ctx context.Context, userID uuid.UUID) ([]models.Budget, error)
+}
+
+// Summer computes the actual cost budgets are compared with, per service.
+type Summer interface {
+	SumSubscriptionsByService(ctx context.Context, filter models.SumFilter) ([]models.ServiceCost, error)
+}
+
+// AlertMarker remembers which exceeded budget months were already reported, so that
+// every instance of the service sharing the storage reports each of them once.
+type AlertMarker interface {
+	// MarkBudgetAlert records the alert and reports whether this call recorded it.
+	MarkBudgetAlert(ctx context.Context, budgetID uuid.UUID, month time.Time) (bool, error)
+	// UnmarkBudgetAlert forgets the alert, so that a later evaluation reports it again.
+	UnmarkBudgetAlert(ctx context.Context, budgetID uuid.UUID, month time.Time) error
+}
+
+// Publisher delivers internal events to whoever listens on channel.
+type Publisher interface {
+	Notify(ctx context.Context, channel, payload string) error
+}
+
+// ExceededEvent is the payload published on ExceededChannel.
+type ExceededEvent struct {
+	BudgetID    uuid.UUID `json:"budget_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	ServiceName *string   `json:"service_name"`
+	Month       string    `json:"month"`
+	Amount      int64     `json:"amount"`
+	Spent       int64     `json:"spent"`
+}
+
+// Storage is everything the service reads and writes.
+type Storage interface {
+	Saver
+	Updater
+	Deleter
+	Getter
+	Lister
+	Summer
+	AlertMarker
+}
+
+type Service struct {
+	storage   Storage
+	publisher Publisher
+}
+
+// New Service constructor. publisher may be nil, exceeded budgets are then only logged.
+func New(storage Storage, publisher Publisher) *Service {
+	return &Service{
+		storage:   storage,
+		publisher: publisher,
+	}
+}
+
+func (s *Service) Save(ctx context.Context, b models.Budget) (models.Budget, error) {
+	const op = "services.budget.Save"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	saved, err := s.storage.SaveBudget(ctx, b)
+	if err != nil {
+		if errors.Is(err, storage.ErrBudgetExists) {
+			log.WarnContext(ctx, "budget already exists", slogx.Err(err))
+			return models.Budget{}, ErrAlreadyExists
+		}
+
+		log.ErrorContext(ctx, "failed to save budget", slogx.Err(err))
+		return models.Budget{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "budget saved", slog.String("id", saved.ID.String()))
+
+	return saved, nil
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (models.Budget, error) {
+	const op = "services.budget.Get"
+	log := slogx.FromContext(ctx).With(
+		slog.String("op", op),
+		slog.String("id", id.String()),
+	)
+
+	b, err := s.storage.BudgetByID(ctx, id)
+	if err != nil {
+		return models.Budget{}, s.notFound(ctx, op, log, err)
+	}
+
+	return b, nil
+}
+
+func (s *Service) List(ctx context.Context, userID uuid.UUID) ([]models.Budget, error) {
+	const op = "services.budget.List"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	budgets, err := s.storage.BudgetsByUser(ctx, userID)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list budgets", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return budgets, nil
+}
+
+func (s *Service) Update(ctx context.Context, id uuid.UUID, amount int64) (models.Budget, error) {
+	const op = "services.budget.Update"
+	log := slogx.FromContext(ctx).With(
+		slog.String("op", op),
+		slog.String("id", id.String()),
+	)
+
+	b, err := s.storage.UpdateBudget(ctx, id, amount)
+	if err != nil {
+		return models.Budget{}, s.notFound(ctx, op, log, err)
+	}
+
+	log.InfoContext(ctx, "budget updated")
+
+	return b, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+	const op = "services.budget.Delete"
+	log := slogx.FromContext(ctx).With(
+		slog.String("op", op),
+		slog.String("id", id.String()),
+	)
+
+	if err := s.storage.DeleteBudget(ctx, id); err != nil {
+		return s.notFound(ctx, op, log, err)
+	}
+
+	log.InfoContext(ctx, "budget deleted")
+
+	return nil
+}
+
+// Evaluate compares every budget of the user with the actual cost of each month
+// from from to to. The cost of a month is the sum of the subscriptions active in it,
+// priced as SumSubscriptions prices them, and is read with one grouped sum per month.
+// Statuses are ordered by month, then budget.
+func (s *Service) Evaluate(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]models.BudgetStatus, error) {
+	const op = "services.budget.Evaluate"
+	log := slogx.FromContext(ctx).With(
+		slog.String("op", op),
+		slog.String("user_id", userID.String()),
+	)
+
+	from, to = models.MonthOf(from), models.MonthOf(to)
+
+	if to.Before(from) || !from.AddDate(0, MaxEvaluationMonths, 0).After(to) {
+		return nil, ErrInvalidPeriod
+	}
+
+	budgets, err := s.storage.BudgetsByUser(ctx, userID)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list budgets", slogx.Err(err))
+		return nil, fmt.Errorf("%s: list: %w", op, err)
+	}
+
+	if len(budgets) == 0 {
+		return nil, nil
+	}
+
+	uid := userID.String()
+
+	var statuses []models.BudgetStatus
+	for month := from; !month.After(to); month = month.AddDate(0, 1, 0) {
+		costs, err := s.storage.SumSubscriptionsByService(
+			ctx, models.SumFilter{
+				UserID:     &uid,
+				AsOf:       &month,
+				ActiveOnly: true,
+			},
+		)
+		if err != nil {
+			log.ErrorContext(ctx, "failed to sum subscriptions", slogx.Err(err))
+			return nil, fmt.Errorf("%s: sum: %w", op, err)
+		}
+
+		var total int64
+		byService := make(map[string]int64, len(costs))
+		for _, c := range costs {
+			byService[c.ServiceName] = c.Cost
+			total += c.Cost
+		}
+
+		for _, b := range budgets {
+			spent := total
+			if b.ServiceName != nil {
+				spent = byService[*b.ServiceName]
+			}
+
+			status := models.BudgetStatus{Budget: b, Month: month, Spent: spent}
+			if status.Exceeded() {
+				s.exceeded(ctx, log, status)
+			}
+
+			statuses = append(statuses, status)
+		}
+	}
+
+	return statuses, nil
+}
+
+// exceeded logs the overspend and publishes an ExceededEvent the first time any
+// instance sees it. Failing to report does not fail the evaluation.
+func (s *Service) exceeded(ctx context.Context, log *slogx.Logger, status models.BudgetStatus) {
+	month := status.Month.Format(format.MonthYear)
+	log = log.With(slog.String("budget_id", status.Budget.ID.String()), slog.String("month", month))
+
+	marked, err := s.storage.MarkBudgetAlert(ctx, status.Budget.ID, status.Month)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to mark budget alert", slogx.Err(err))
+		return
+	}
+	if !marked {
+		return
+	}
+
+	log.WarnContext(
+		ctx, "budget exceeded",
+		slog.Int64("amount", status.Budget.Amount),
+		slog.Int64("spent", status.Spent),
+	)
+
+	if s.publisher == nil {
+		return
+	}
+
+	payload, err := json.Marshal(
+		ExceededEvent{
+			BudgetID:    status.Budget.ID,
+			UserID:      status.Budget.UserID,
+			ServiceName: status.Budget.ServiceName,
+			Month:       month,
+			Amount:      status.Budget.Amount,
+			Spent:       status.Spent,
+		},
+	)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to encode budget exceeded event", slogx.Err(err))
+		return
+	}
+
+	if err := s.publisher.Notify(ctx, ExceededChannel, string(payload)); err != nil {
+		log.ErrorContext(ctx, "failed to publish budget exceeded event", slogx.Err(err))
+
+		// Let a later evaluation try again.
+		if err := s.storage.UnmarkBudgetAlert(ctx, status.Budget.ID, status.Month); err != nil {
+			log.ErrorContext(ctx, "failed to unmark budget alert", slogx.Err(err))
+		}
+	}
+}
+
+func (s *Service) notFound(ctx context.Context, op string, log *slogx.Logger, err error) error {
+	if errors.Is(err, storage.ErrBudgetNotFound) {
+		log.WarnContext(ctx, "budget not found", slogx.Err(err))
+		return ErrNotFound
+	}
+
+	log.ErrorContext(ctx, "budget storage failed", slogx.Err(err))
+	return fmt.Errorf("%s: %w", op, err)
+}
@@ -0,0 +1,184 @@
+package budget_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/services/budget"
+	"github.com/salivare/subscriptions-service/internal/storage/memory"
+)
+
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []budget.ExceededEvent
+	// fail makes Notify fail instead of recording the event.
+	fail error
+}
+
+func (p *recordingPublisher) Notify(_ context.Context, channel, payload string) error {
+	if channel != budget.ExceededChannel {
+		return nil
+	}
+	if p.fail != nil {
+		return p.fail
+	}
+
+	var e budget.ExceededEvent
+	if err := json.Unmarshal([]byte(payload), &e); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, e)
+
+	return nil
+}
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func TestService_Evaluate(t *testing.T) {
+	ctx := context.Background()
+	s := memory.New()
+	pub := &recordingPublisher{}
+	srv := budget.New(s, pub)
+
+	userID := uuid.New()
+	netflixEnd := month(2025, time.February)
+
+	for _, sub := range []models.Subscription{
+		{ServiceName: "Netflix", Price: ptr(int64(400)), UserID: userID, StartDate: month(2025, time.January), EndDate: &netflixEnd},
+		{ServiceName: "Spotify", Price: ptr(int64(200)), UserID: userID, StartDate: month(2025, time.February)},
+		{ServiceName: "Spotify", Price: ptr(int64(900)), UserID: uuid.New(), StartDate: month(2025, time.January)},
+	} {
+		_, _, err := s.SaveSubscription(ctx, sub)
+		require.NoError(t, err)
+	}
+
+	overall, err := srv.Save(ctx, models.Budget{UserID: userID, Amount: 500})
+	require.NoError(t, err)
+
+	spotify, err := srv.Save(ctx, models.Budget{UserID: userID, ServiceName: ptr("Spotify"), Amount: 150})
+	require.NoError(t, err)
+
+	_, err = srv.Save(ctx, models.Budget{UserID: userID, Amount: 700})
+	assert.ErrorIs(t, err, budget.ErrAlreadyExists)
+
+	statuses, err := srv.Evaluate(ctx, userID, month(2025, time.January), month(2025, time.March))
+	require.NoError(t, err)
+	require.Len(t, statuses, 6)
+
+	type row struct {
+		month  time.Time
+		budget uuid.UUID
+		spent  int64
+	}
+
+	want := []row{
+		{month(2025, time.January), overall.ID, 400},
+		{month(2025, time.January), spotify.ID, 0},
+		{month(2025, time.February), overall.ID, 600},
+		{month(2025, time.February), spotify.ID, 200},
+		{month(2025, time.March), overall.ID, 200},
+		{month(2025, time.March), spotify.ID, 200},
+	}
+
+	for i, w := range want {
+		assert.True(t, w.month.Equal(statuses[i].Month), "status %d month", i)
+		assert.Equal(t, w.budget, statuses[i].Budget.ID, "status %d budget", i)
+		assert.Equal(t, w.spent, statuses[i].Spent, "status %d spent", i)
+	}
+
+	require.Len(t, pub.events, 3)
+	assert.Equal(t, overall.ID, pub.events[0].BudgetID)
+	assert.Equal(t, "02-2025", pub.events[0].Month)
+	assert.Equal(t, int64(600), pub.events[0].Spent)
+
+	// Evaluating again does not repeat the events.
+	_, err = srv.Evaluate(ctx, userID, month(2025, time.February), month(2025, time.February))
+	require.NoError(t, err)
+	assert.Len(t, pub.events, 3)
+
+	_, err = srv.Evaluate(ctx, userID, month(2025, time.March), month(2025, time.January))
+	assert.ErrorIs(t, err, budget.ErrInvalidPeriod)
+
+	_, err = srv.Evaluate(ctx, userID, month(2025, time.January), month(2028, time.January))
+	assert.ErrorIs(t, err, budget.ErrInvalidPeriod)
+}
+
+func TestService_EvaluateReportsOnce(t *testing.T) {
+	ctx := context.Background()
+	s := memory.New()
+
+	userID := uuid.New()
+	_, _, err := s.SaveSubscription(
+		ctx, models.Subscription{ServiceName: "Netflix", Price: ptr(int64(400)), UserID: userID, StartDate: month(2025, time.January)},
+	)
+	require.NoError(t, err)
+
+	b, err := s.SaveBudget(ctx, models.Budget{UserID: userID, Amount: 300})
+	require.NoError(t, err)
+
+	pub := &recordingPublisher{fail: errors.New("connection lost")}
+	first := budget.New(s, pub)
+
+	_, err = first.Evaluate(ctx, userID, month(2025, time.January), month(2025, time.January))
+	require.NoError(t, err, "a failed publish does not fail the evaluation")
+	assert.Empty(t, pub.events)
+
+	// Instances sharing the storage report the month once, and the one that failed
+	// to publish left it for the next evaluation.
+	pub.fail = nil
+	second := budget.New(s, pub)
+
+	for _, srv := range []*budget.Service{first, second, first} {
+		_, err = srv.Evaluate(ctx, userID, month(2025, time.January), month(2025, time.January))
+		require.NoError(t, err)
+	}
+
+	require.Len(t, pub.events, 1)
+	assert.Equal(t, b.ID, pub.events[0].BudgetID)
+	assert.Equal(t, "01-2025", pub.events[0].Month)
+}
+
+func TestService_CRUD(t *testing.T) {
+	ctx := context.Background()
+	s := memory.New()
+	srv := budget.New(s, nil)
+
+	b, err := srv.Save(ctx, models.Budget{UserID: uuid.New(), Amount: 500})
+	require.NoError(t, err)
+
+	updated, err := srv.Update(ctx, b.ID, 800)
+	require.NoError(t, err)
+	assert.Equal(t, int64(800), updated.Amount)
+
+	list, err := srv.List(ctx, b.UserID)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	require.NoError(t, srv.Delete(ctx, b.ID))
+
+	_, err = srv.Get(ctx, b.ID)
+	assert.ErrorIs(t, err, budget.ErrNotFound)
+
+	_, err = srv.Update(ctx, b.ID, 1)
+	assert.ErrorIs(t, err, budget.ErrNotFound)
+
+	assert.ErrorIs(t, srv.Delete(ctx, b.ID), budget.ErrNotFound)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,176 @@
+// Package privacy exports and erases everything the service holds about a user.
+package privacy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/storage"
+)
+
+var ErrNotErased = errors.New("no erasure recorded for the user")
+
+// SubscriptionLister lists the subscriptions of a user.
+type SubscriptionLister interface {
+	SubscriptionsByUser(ctx context.Context, userID uuid.UUID) ([]models.Subscription, error)
+}
+
+// PriceLister lists the price history of a subscription.
+type PriceLister interface {
+	PriceChanges(ctx context.Context, subscriptionID uuid.UUID) ([]models.PriceChange, error)
+}
+
+// BudgetLister lists the budgets of a user.
+type BudgetLister interface {
+	BudgetsByUser(ctx context.Context, userID uuid.UUID) ([]models.Budget, error)
+}
+
+// Summarizer derives the summary of the current month of a user.
+type Summarizer interface {
+	Summary(ctx context.Context, userID uuid.UUID) (models.UserSummary, error)
+}
+
+// Eraser deletes every row of a user and appends the erasure record, in one transaction.
+type Eraser interface {
+	EraseUser(ctx context.Context, userID uuid.UUID) (models.Erasure, error)
+}
+
+// ErasureLister returns the whole erasure log, ordered by Seq.
+type ErasureLister interface {
+	Erasures(ctx context.Context) ([]models.Erasure, error)
+}
+
+// Invalidator drops cached rows the caller cannot name, such as those of an erased user.
+type Invalidator interface {
+	InvalidateAll(ctx context.Context)
+}
+
+// Storage is everything the service reads and erases.
+type Storage interface {
+	SubscriptionLister
+	PriceLister
+	BudgetLister
+	Eraser
+	ErasureLister
+}
+
+type Service struct {
+	storage     Storage
+	summarizer  Summarizer
+	invalidator Invalidator
+}
+
+// New Service constructor. invalidator may be nil when nothing is cached.
+func New(storage Storage, summarizer Summarizer, invalidator Invalidator) *Service {
+	return &Service{
+		storage:     storage,
+		summarizer:  summarizer,
+		invalidator: invalidator,
+	}
+}
+
+// Export collects the subscriptions of userID with their price history, the budgets
+// and the summary of the current month. Rows are read from the primary, so a write
+// made just before is included.
+func (s *Service) Export(ctx context.Context, userID uuid.UUID) (models.UserExport, error) {
+	const op = "services.privacy.Export"
+	log := slogx.FromContext(ctx).With(
+		slog.String("op", op),
+		slog.String("user_id", userID.String()),
+	)
+
+	ctx = storage.WithPrimary(ctx)
+	export := models.UserExport{UserID: userID, ExportedAt: time.Now().UTC()}
+
+	subs, err := s.storage.SubscriptionsByUser(ctx, userID)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list subscriptions", slogx.Err(err))
+		return models.UserExport{}, fmt.Errorf("%s: subscriptions: %w", op, err)
+	}
+
+	export.Subscriptions = make([]models.SubscriptionExport, 0, len(subs))
+	for _, sub := range subs {
+		changes, err := s.storage.PriceChanges(ctx, sub.ID)
+		if err != nil {
+			log.ErrorContext(ctx, "failed to list price changes", slogx.Err(err))
+			return models.UserExport{}, fmt.Errorf("%s: price changes: %w", op, err)
+		}
+
+		export.Subscriptions = append(export.Subscriptions, models.SubscriptionExport{Subscription: sub, PriceChanges: changes})
+	}
+
+	if export.Budgets, err = s.storage.BudgetsByUser(ctx, userID); err != nil {
+		log.ErrorContext(ctx, "failed to list budgets", slogx.Err(err))
+		return models.UserExport{}, fmt.Errorf("%s: budgets: %w", op, err)
+	}
+
+	if export.Summary, err = s.summarizer.Summary(ctx, userID); err != nil {
+		log.ErrorContext(ctx, "failed to summarize", slogx.Err(err))
+		return models.UserExport{}, fmt.Errorf("%s: summary: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "user exported", slog.Int("subscriptions", len(export.Subscriptions)))
+
+	return export, nil
+}
+
+// Erase hard-deletes every subscription, price change, pause and budget of userID and
+// returns the erasure record. A user without rows is erased too, so every request
+// leaves its proof.
+func (s *Service) Erase(ctx context.Context, userID uuid.UUID) (models.Erasure, error) {
+	const op = "services.privacy.Erase"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	erasure, err := s.storage.EraseUser(ctx, userID)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to erase user", slogx.Err(err))
+		return models.Erasure{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if s.invalidator != nil {
+		s.invalidator.InvalidateAll(ctx)
+	}
+
+	// The user id is not logged, the record is all that is left of the user.
+	log.InfoContext(
+		ctx, "user erased",
+		slog.Int64("seq", erasure.Seq),
+		slog.Int("subscriptions", erasure.Subscriptions),
+		slog.Int("budgets", erasure.Budgets),
+	)
+
+	return erasure, nil
+}
+
+// Erasure proves that userID was erased: it verifies the whole erasure log and returns
+// the latest record of the user. A broken log is an internal error, not a missing record.
+func (s *Service) Erasure(ctx context.Context, userID uuid.UUID) (models.Erasure, error) {
+	const op = "services.privacy.Erasure"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	chain, err := s.storage.Erasures(storage.WithPrimary(ctx))
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list erasures", slogx.Err(err))
+		return models.Erasure{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := models.VerifyErasures(chain); err != nil {
+		log.ErrorContext(ctx, "erasure log is broken", slogx.Err(err))
+		return models.Erasure{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	hash := models.HashUserID(userID)
+	for i := len(chain) - 1; i >= 0; i-- {
+		if chain[i].UserHash == hash {
+			return chain[i], nil
+		}
+	}
+
+	return models.Erasure{}, ErrNotErased
+}
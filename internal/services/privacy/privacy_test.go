@@ -0,0 +1,139 @@
+package privacy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/services/privacy"
+	"github.com/salivare/subscriptions-service/internal/services/subscription"
+	"github.com/salivare/subscriptions-service/internal/storage/memory"
+)
+
+type countingInvalidator struct{ calls int }
+
+func (i *countingInvalidator) InvalidateAll(context.Context) { i.calls++ }
+
+// tamperedLog returns the stored erasures with the counts of the first one changed.
+type tamperedLog struct{ *memory.Storage }
+
+func (l tamperedLog) Erasures(ctx context.Context) ([]models.Erasure, error) {
+	chain, err := l.Storage.Erasures(ctx)
+	if len(chain) > 0 {
+		chain[0].Subscriptions++
+	}
+	return chain, err
+}
+
+func newService(s *memory.Storage, inv privacy.Invalidator) *privacy.Service {
+	subs := subscription.New(s)
+	return privacy.New(s, subs, inv)
+}
+
+func seed(t *testing.T, s *memory.Storage, userID uuid.UUID) uuid.UUID {
+	t.Helper()
+	ctx := context.Background()
+
+	start := models.MonthOf(time.Now()).AddDate(0, -2, 0)
+	id, _, err := s.SaveSubscription(ctx, models.Subscription{
+		ServiceName: "Netflix",
+		Price:       ptr(int64(400)),
+		UserID:      userID,
+		StartDate:   start,
+		TrialMonths: 1,
+	})
+	require.NoError(t, err)
+
+	_, err = s.SavePriceChange(ctx, models.PriceChange{
+		SubscriptionID: id,
+		EffectiveFrom:  models.MonthOf(time.Now()).AddDate(0, 1, 0),
+		Price:          500,
+	})
+	require.NoError(t, err)
+
+	_, err = s.SaveBudget(ctx, models.Budget{UserID: userID, Amount: 1000})
+	require.NoError(t, err)
+
+	return id
+}
+
+func TestService_Export(t *testing.T) {
+	ctx := context.Background()
+	s := memory.New()
+	userID := uuid.New()
+
+	id := seed(t, s, userID)
+	seed(t, s, uuid.New())
+
+	export, err := newService(s, nil).Export(ctx, userID)
+	require.NoError(t, err)
+
+	assert.Equal(t, userID, export.UserID)
+	require.Len(t, export.Subscriptions, 1)
+	assert.Equal(t, id, export.Subscriptions[0].Subscription.ID)
+	require.Len(t, export.Subscriptions[0].PriceChanges, 1)
+	assert.Equal(t, int64(500), export.Subscriptions[0].PriceChanges[0].Price)
+	require.Len(t, export.Budgets, 1)
+	assert.Equal(t, int64(1000), export.Budgets[0].Amount)
+	assert.Equal(t, 1, export.Summary.ActiveCount)
+	assert.Equal(t, int64(400), export.Summary.MonthlyCost)
+
+	empty, err := newService(s, nil).Export(ctx, uuid.New())
+	require.NoError(t, err)
+	assert.NotNil(t, empty.Subscriptions)
+	assert.Empty(t, empty.Subscriptions)
+}
+
+func TestService_Erase(t *testing.T) {
+	ctx := context.Background()
+	s := memory.New()
+	inv := &countingInvalidator{}
+	srv := newService(s, inv)
+
+	userID, otherID := uuid.New(), uuid.New()
+	seed(t, s, userID)
+	seed(t, s, otherID)
+
+	_, err := srv.Erasure(ctx, userID)
+	assert.ErrorIs(t, err, privacy.ErrNotErased)
+
+	erasure, err := srv.Erase(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, erasure.Subscriptions)
+	assert.Equal(t, 1, erasure.Budgets)
+	assert.Equal(t, models.HashUserID(userID), erasure.UserHash)
+	assert.Equal(t, 1, inv.calls)
+
+	export, err := srv.Export(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, export.Subscriptions)
+	assert.Empty(t, export.Budgets)
+
+	other, err := srv.Export(ctx, otherID)
+	require.NoError(t, err)
+	assert.Len(t, other.Subscriptions, 1)
+
+	_, err = srv.Erase(ctx, otherID)
+	require.NoError(t, err)
+
+	proof, err := srv.Erasure(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, erasure, proof)
+
+	subs := subscription.New(s)
+	tampered := privacy.New(tamperedLog{s}, subs, nil)
+
+	_, err = tampered.Erasure(ctx, otherID)
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, privacy.ErrNotErased), "a broken log is not a missing record")
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,340 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/cache"
+	"github.com/salivare/subscriptions-service/internal/config"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/storage"
+	"github.com/salivare/subscriptions-service/internal/tenant"
+)
+
+// Broadcaster delivers cache invalidations to the other instances of the service.
+type Broadcaster interface {
+	Notify(ctx context.Context, channel, payload string) error
+	// Listen calls onListen every time it (re)subscribes; notifications sent while it was
+	// not subscribed are lost.
+	Listen(ctx context.Context, channel string, onListen func(), fn func(payload string)) error
+}
+
+// CacheStats reports hit/miss counters of both caches.
+type CacheStats struct {
+	Subscriptions cache.Stats `json:"subscriptions"`
+	Sums          cache.Stats `json:"sums"`
+}
+
+// Cache is a read-through caching decorator for Storage.
+// Reads go through Getter and Summer; successful writes invalidate the affected
+// entries locally and, if a Broadcaster is set, on every other instance.
+// Entries belong to the tenant of the read that cached them and are never served to another.
+// Misses are filled from the primary: a lagging replica could return a row older than the last
+// invalidation, and the cache would then serve it for the whole TTL.
+type Cache struct {
+	storage Storage
+
+	subs *cache.LRU[uuid.UUID, cachedSubscription]
+	sums *cache.LRU[string, int64]
+
+	// generation is bumped on every invalidation, so a read that raced with a write does not cache a stale row.
+	generation atomic.Uint64
+
+	log         *slogx.Logger
+	broadcaster Broadcaster
+	channel     string
+	instanceID  string
+}
+
+// NewCache Cache constructor. broadcaster may be nil for a single instance.
+func NewCache(
+	log *slogx.Logger,
+	cfg config.CacheConfig,
+	storage Storage,
+	broadcaster Broadcaster,
+) *Cache {
+	return &Cache{
+		storage:     storage,
+		subs:        cache.NewLRU[uuid.UUID, cachedSubscription](cfg.MaxSubscriptions, cfg.SubscriptionTTL),
+		sums:        cache.NewLRU[string, int64](cfg.MaxSums, cfg.SumTTL),
+		log:         log.With(slog.String("component", "subscription_cache")),
+		broadcaster: broadcaster,
+		channel:     cfg.NotifyChannel,
+		instanceID:  uuid.NewString(),
+	}
+}
+
+// Run listens for invalidations from other instances until ctx is cancelled.
+// Everything is dropped whenever the listener (re)subscribes, since invalidations sent
+// while it was down never arrive.
+func (c *Cache) Run(ctx context.Context) {
+	if c.broadcaster == nil {
+		return
+	}
+
+	err := c.broadcaster.Listen(
+		ctx, c.channel, func() { c.apply(allSubscriptions) }, func(payload string) {
+			sender, id, ok := strings.Cut(payload, ":")
+			if !ok || sender == c.instanceID {
+				return
+			}
+
+			c.apply(id)
+		},
+	)
+	if err != nil && ctx.Err() == nil {
+		c.log.Error("cache invalidation listener stopped", slogx.Err(err))
+	}
+}
+
+// Stats returns hit/miss counters for monitoring.
+func (c *Cache) Stats() CacheStats {
+	return CacheStats{
+		Subscriptions: c.subs.Stats(),
+		Sums:          c.sums.Stats(),
+	}
+}
+
+// SaveSubscription implementation of the Saver interface.
+func (c *Cache) SaveSubscription(ctx context.Context, sub models.Subscription) (uuid.UUID, time.Time, error) {
+	id, createdAt, err := c.storage.SaveSubscription(ctx, sub)
+	if err == nil {
+		c.invalidate(ctx, id)
+	}
+
+	return id, createdAt, err
+}
+
+// UpdateSubscription implementation of the Updater interface.
+func (c *Cache) UpdateSubscription(ctx context.Context, sub models.Subscription) (models.Subscription, error) {
+	updated, err := c.storage.UpdateSubscription(ctx, sub)
+	if err == nil {
+		c.invalidate(ctx, sub.ID)
+	}
+
+	return updated, err
+}
+
+// UpsertSubscription implementation of the Upserter interface.
+func (c *Cache) UpsertSubscription(ctx context.Context, sub models.Subscription) (models.Subscription, bool, error) {
+	stored, created, err := c.storage.UpsertSubscription(ctx, sub)
+	if err == nil {
+		c.invalidate(ctx, sub.ID)
+	}
+
+	return stored, created, err
+}
+
+// SubscriptionsByUser implementation of the UserLister interface.
+func (c *Cache) SubscriptionsByUser(ctx context.Context, userID uuid.UUID) ([]models.Subscription, error) {
+	return c.storage.SubscriptionsByUser(ctx, userID)
+}
+
+// CancelUserSubscriptions implementation of the Canceller interface.
+func (c *Cache) CancelUserSubscriptions(ctx context.Context, userID uuid.UUID, month time.Time) ([]uuid.UUID, error) {
+	ids, err := c.storage.CancelUserSubscriptions(ctx, userID, month)
+	for _, id := range ids {
+		c.invalidate(ctx, id)
+	}
+
+	return ids, err
+}
+
+// DeleteSubscription implementation of the Deleter interface.
+func (c *Cache) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	err := c.storage.DeleteSubscription(ctx, id)
+	if err == nil {
+		c.invalidate(ctx, id)
+	}
+
+	return err
+}
+
+// SubscriptionByID implementation of the Getter interface.
+// Reads pinned to the primary bypass the cache.
+func (c *Cache) SubscriptionByID(ctx context.Context, id uuid.UUID) (models.Subscription, error) {
+	if storage.UsePrimary(ctx) {
+		return c.storage.SubscriptionByID(ctx, id)
+	}
+
+	tenantID, _ := tenant.FromContext(ctx)
+
+	if cached, ok := c.subs.Get(id); ok && cached.tenantID == tenantID {
+		return cached.sub, nil
+	}
+
+	gen := c.generation.Load()
+
+	sub, err := c.storage.SubscriptionByID(storage.WithPrimary(ctx), id)
+	if err != nil {
+		return models.Subscription{}, err
+	}
+
+	if c.generation.Load() == gen {
+		c.subs.Put(id, cachedSubscription{tenantID: tenantID, sub: sub})
+	}
+
+	return sub, nil
+}
+
+// SumSubscriptions implementation of the Summer interface.
+func (c *Cache) SumSubscriptions(ctx context.Context, f models.SumFilter) (int64, error) {
+	if storage.UsePrimary(ctx) {
+		return c.storage.SumSubscriptions(ctx, f)
+	}
+
+	tenantID, _ := tenant.FromContext(ctx)
+	key := tenantID + "|" + sumKey(f)
+
+	if total, ok := c.sums.Get(key); ok {
+		return total, nil
+	}
+
+	gen := c.generation.Load()
+
+	total, err := c.storage.SumSubscriptions(storage.WithPrimary(ctx), f)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.generation.Load() == gen {
+		c.sums.Put(key, total)
+	}
+
+	return total, nil
+}
+
+// SumSubscriptionsByService implementation of the ServiceSummer interface. Grouped sums are not cached.
+func (c *Cache) SumSubscriptionsByService(ctx context.Context, f models.SumFilter) ([]models.ServiceCost, error) {
+	return c.storage.SumSubscriptionsByService(ctx, f)
+}
+
+// SubscriptionsWithTrialEnding implementation of the TrialLister interface. Lists are not cached.
+func (c *Cache) SubscriptionsWithTrialEnding(ctx context.Context, month time.Time, userID *uuid.UUID) ([]models.Subscription, error) {
+	return c.storage.SubscriptionsWithTrialEnding(ctx, month, userID)
+}
+
+// SavePriceChange implementation of the PriceScheduler interface. It changes sums, so it invalidates like a write.
+func (c *Cache) SavePriceChange(ctx context.Context, change models.PriceChange) (models.PriceChange, error) {
+	saved, err := c.storage.SavePriceChange(ctx, change)
+	if err == nil {
+		c.invalidate(ctx, change.SubscriptionID)
+	}
+
+	return saved, err
+}
+
+// PriceChanges implementation of the PriceLister interface. Lists are not cached.
+func (c *Cache) PriceChanges(ctx context.Context, subscriptionID uuid.UUID) ([]models.PriceChange, error) {
+	return c.storage.PriceChanges(ctx, subscriptionID)
+}
+
+// PauseSubscription implementation of the Pauser interface. It changes sums, so it invalidates like a write.
+func (c *Cache) PauseSubscription(ctx context.Context, pause models.Pause) (models.Pause, error) {
+	saved, err := c.storage.PauseSubscription(ctx, pause)
+	if err == nil {
+		c.invalidate(ctx, pause.SubscriptionID)
+	}
+
+	return saved, err
+}
+
+// ResumeSubscription implementation of the Pauser interface. It changes sums, so it invalidates like a write.
+func (c *Cache) ResumeSubscription(ctx context.Context, id uuid.UUID, month time.Time) (models.Pause, error) {
+	resumed, err := c.storage.ResumeSubscription(ctx, id, month)
+	if err == nil {
+		c.invalidate(ctx, id)
+	}
+
+	return resumed, err
+}
+
+// TransferSubscription implementation of the Transferrer interface.
+func (c *Cache) TransferSubscription(ctx context.Context, ended, continuation models.Subscription) (uuid.UUID, error) {
+	id, err := c.storage.TransferSubscription(ctx, ended, continuation)
+	if err == nil {
+		c.invalidate(ctx, ended.ID)
+		c.invalidate(ctx, id)
+	}
+
+	return id, err
+}
+
+// InvalidateAll drops every cached entry here and on the other instances, for writes
+// whose subscriptions are not known, such as erasing a user.
+func (c *Cache) InvalidateAll(ctx context.Context) {
+	c.broadcast(ctx, allSubscriptions)
+}
+
+// cachedSubscription is a subscription with the tenant it was read for.
+type cachedSubscription struct {
+	tenantID string
+	sub      models.Subscription
+}
+
+func (c *Cache) invalidate(ctx context.Context, id uuid.UUID) {
+	c.broadcast(ctx, id.String())
+}
+
+func (c *Cache) broadcast(ctx context.Context, id string) {
+	c.apply(id)
+
+	if c.broadcaster == nil {
+		return
+	}
+
+	if err := c.broadcaster.Notify(ctx, c.channel, c.instanceID+":"+id); err != nil {
+		c.log.WarnContext(ctx, "failed to broadcast cache invalidation", slogx.Err(err))
+	}
+}
+
+// allSubscriptions is the invalidation that drops every subscription.
+const allSubscriptions = "*"
+
+// apply drops the subscription and every sum, since any write may change any total.
+// An unparsable id, allSubscriptions included, drops every subscription.
+func (c *Cache) apply(id string) {
+	c.generation.Add(1)
+
+	if uid, err := uuid.Parse(id); err == nil {
+		c.subs.Delete(uid)
+	} else {
+		c.subs.Purge()
+	}
+
+	c.sums.Purge()
+}
+
+func sumKey(f models.SumFilter) string {
+	str := func(s *string) string {
+		if s == nil {
+			return "-"
+		}
+		return *s
+	}
+
+	date := func(t *time.Time) string {
+		if t == nil {
+			return "-"
+		}
+		return t.Format(time.DateOnly)
+	}
+
+	return fmt.Sprintf(
+		"%s|%s|%s|%s|%s|%s|%s|%t",
+		str(f.UserID),
+		str(f.ServiceName),
+		date(f.StartDateFrom),
+		date(f.StartDateTo),
+		date(f.EndDateFrom),
+		date(f.EndDateTo),
+		date(f.AsOf),
+		f.ActiveOnly,
+	)
+}
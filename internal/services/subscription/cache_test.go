@@ -0,0 +1,352 @@
+package subscription_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/config"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/services/subscription"
+	"github.com/salivare/subscriptions-service/internal/storage"
+	"github.com/salivare/subscriptions-service/internal/storage/memory"
+	"github.com/salivare/subscriptions-service/internal/storage/storagetest"
+	"github.com/salivare/subscriptions-service/internal/tenant"
+)
+
+var cacheCfg = config.CacheConfig{
+	MaxSubscriptions: 100,
+	MaxSums:          100,
+	SubscriptionTTL:  time.Minute,
+	SumTTL:           time.Minute,
+	NotifyChannel:    "subscriptions_cache",
+}
+
+func newCache(s storagetest.Storage, b subscription.Broadcaster) *subscription.Cache {
+	return subscription.NewCache(slogx.New(), cacheCfg, s, b)
+}
+
+// bus is an in-process Broadcaster shared by several caches.
+type bus struct {
+	mu        sync.Mutex
+	listeners []func(string)
+	onListen  []func()
+	ready     sync.WaitGroup
+}
+
+// resubscribe acts as if every listener lost its connection and listened again.
+func (b *bus) resubscribe() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, fn := range b.onListen {
+		fn()
+	}
+}
+
+func (b *bus) Notify(_ context.Context, _, payload string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, fn := range b.listeners {
+		fn(payload)
+	}
+
+	return nil
+}
+
+func (b *bus) Listen(ctx context.Context, _ string, onListen func(), fn func(string)) error {
+	onListen()
+
+	b.mu.Lock()
+	b.listeners = append(b.listeners, fn)
+	b.onListen = append(b.onListen, onListen)
+	b.mu.Unlock()
+	b.ready.Done()
+
+	<-ctx.Done()
+	return nil
+}
+
+func TestCache_Conformance(t *testing.T) {
+	storagetest.Run(
+		t, func(t *testing.T) storagetest.Storage {
+			return newCache(memory.New(), nil)
+		},
+	)
+}
+
+func TestCache_HitsAndInvalidation(t *testing.T) {
+	ctx := context.Background()
+	c := newCache(memory.New(), nil)
+
+	price := int64(400)
+	sub := models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      uuid.New(),
+		StartDate:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	id, _, err := c.SaveSubscription(ctx, sub)
+	require.NoError(t, err)
+
+	uid := sub.UserID.String()
+	filter := models.SumFilter{UserID: &uid}
+
+	for range 2 {
+		_, err = c.SubscriptionByID(ctx, id)
+		require.NoError(t, err)
+
+		total, err := c.SumSubscriptions(ctx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, int64(400), total)
+	}
+
+	stats := c.Stats()
+	assert.Equal(t, uint64(1), stats.Subscriptions.Hits)
+	assert.Equal(t, uint64(1), stats.Subscriptions.Misses)
+	assert.Equal(t, uint64(1), stats.Sums.Hits)
+	assert.Equal(t, uint64(1), stats.Sums.Misses)
+
+	sub.ID = id
+	price = 500
+	_, err = c.UpdateSubscription(ctx, sub)
+	require.NoError(t, err)
+
+	got, err := c.SubscriptionByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), *got.Price)
+
+	total, err := c.SumSubscriptions(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), total)
+}
+
+func TestCache_PrimaryReadsBypassCache(t *testing.T) {
+	ctx := context.Background()
+	c := newCache(memory.New(), nil)
+
+	_, err := c.SubscriptionByID(storage.WithPrimary(ctx), uuid.New())
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	assert.Zero(t, c.Stats().Subscriptions.Misses)
+}
+
+// laggingReplica is a storage whose replica has not received any row yet.
+type laggingReplica struct{ *memory.Storage }
+
+func (l laggingReplica) SubscriptionByID(ctx context.Context, id uuid.UUID) (models.Subscription, error) {
+	if !storage.UsePrimary(ctx) {
+		return models.Subscription{}, storage.ErrNotFound
+	}
+	return l.Storage.SubscriptionByID(ctx, id)
+}
+
+func (l laggingReplica) SumSubscriptions(ctx context.Context, f models.SumFilter) (int64, error) {
+	if !storage.UsePrimary(ctx) {
+		return 0, nil
+	}
+	return l.Storage.SumSubscriptions(ctx, f)
+}
+
+func TestCache_FillsFromPrimary(t *testing.T) {
+	ctx := context.Background()
+	c := newCache(laggingReplica{memory.New()}, nil)
+
+	price := int64(400)
+	userID := uuid.New()
+	uid := userID.String()
+	id, _, err := c.SaveSubscription(ctx, models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      userID,
+		StartDate:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	for range 2 {
+		got, err := c.SubscriptionByID(ctx, id)
+		require.NoError(t, err, "a miss must not read the lagging replica")
+		assert.Equal(t, int64(400), *got.Price)
+
+		total, err := c.SumSubscriptions(ctx, models.SumFilter{UserID: &uid})
+		require.NoError(t, err)
+		assert.Equal(t, int64(400), total)
+	}
+
+	assert.Equal(t, uint64(1), c.Stats().Subscriptions.Hits)
+	assert.Equal(t, uint64(1), c.Stats().Sums.Hits)
+}
+
+func TestCache_InvalidatesOtherInstances(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	shared := memory.New()
+	b := &bus{}
+	b.ready.Add(2)
+
+	first := newCache(shared, b)
+	second := newCache(shared, b)
+
+	go first.Run(ctx)
+	go second.Run(ctx)
+	b.ready.Wait()
+
+	price := int64(400)
+	sub := models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      uuid.New(),
+		StartDate:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	id, _, err := first.SaveSubscription(ctx, sub)
+	require.NoError(t, err)
+
+	_, err = second.SubscriptionByID(ctx, id)
+	require.NoError(t, err)
+
+	sub.ID = id
+	price = 900
+	_, err = first.UpdateSubscription(ctx, sub)
+	require.NoError(t, err)
+
+	got, err := second.SubscriptionByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(900), *got.Price)
+}
+
+func TestCache_PurgesOnResubscribe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	shared := memory.New()
+	b := &bus{}
+	b.ready.Add(1)
+
+	c := newCache(shared, b)
+	go c.Run(ctx)
+	b.ready.Wait()
+
+	price := int64(400)
+	sub := models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      uuid.New(),
+		StartDate:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	id, _, err := c.SaveSubscription(ctx, sub)
+	require.NoError(t, err)
+
+	_, err = c.SubscriptionByID(ctx, id)
+	require.NoError(t, err)
+
+	// Another instance updates the row while the listener is down; its notification is lost.
+	sub.ID = id
+	newPrice := int64(900)
+	sub.Price = &newPrice
+	_, err = shared.UpdateSubscription(ctx, sub)
+	require.NoError(t, err)
+
+	b.resubscribe()
+
+	got, err := c.SubscriptionByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(900), *got.Price)
+}
+
+func TestCache_InvalidateAll(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	shared := memory.New()
+	b := &bus{}
+	b.ready.Add(2)
+
+	first := newCache(shared, b)
+	second := newCache(shared, b)
+
+	go first.Run(ctx)
+	go second.Run(ctx)
+	b.ready.Wait()
+
+	price := int64(400)
+	sub := models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      uuid.New(),
+		StartDate:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	id, _, err := first.SaveSubscription(ctx, sub)
+	require.NoError(t, err)
+
+	for _, c := range []*subscription.Cache{first, second} {
+		_, err = c.SubscriptionByID(ctx, id)
+		require.NoError(t, err)
+	}
+
+	// Written behind the caches' back, like an erasure.
+	_, err = shared.EraseUser(ctx, sub.UserID)
+	require.NoError(t, err)
+
+	first.InvalidateAll(ctx)
+
+	for _, c := range []*subscription.Cache{first, second} {
+		_, err = c.SubscriptionByID(ctx, id)
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	}
+}
+
+func TestCache_TenantsDoNotShareEntries(t *testing.T) {
+	shared := memory.New()
+	c := newCache(shared, nil)
+	first := tenant.WithID(context.Background(), "acme")
+	second := tenant.WithID(context.Background(), "globex")
+
+	price := int64(400)
+	sub := models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      uuid.New(),
+		StartDate:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	id, _, err := c.SaveSubscription(first, sub)
+	require.NoError(t, err)
+
+	uid := sub.UserID.String()
+	filter := models.SumFilter{UserID: &uid}
+
+	_, err = c.SubscriptionByID(first, id)
+	require.NoError(t, err)
+
+	_, err = c.SumSubscriptions(first, filter)
+	require.NoError(t, err)
+
+	// Removed behind the cache's back, so only the entries cached for the first tenant still have it.
+	_, err = shared.EraseUser(first, sub.UserID)
+	require.NoError(t, err)
+
+	_, err = c.SubscriptionByID(first, id)
+	require.NoError(t, err)
+
+	_, err = c.SubscriptionByID(second, id)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	total, err := c.SumSubscriptions(first, filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(400), total)
+
+	total, err = c.SumSubscriptions(second, filter)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
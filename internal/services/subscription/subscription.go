@@ -2,6 +2,7 @@ package subscription
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -10,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/salivare-io/slogx"
 	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/format"
 	"github.com/salivare/subscriptions-service/internal/httpserver/request"
 	"github.com/salivare/subscriptions-service/internal/storage"
 )
@@ -19,14 +21,36 @@ var (
 	ErrNotFound          = errors.New("subscription not found")
 	ErrStartDateInFuture = errors.New("start_date_from cannot be in the future when start_date_to is omitted")
 	ErrEndDateInFuture   = errors.New("end_date_from cannot be in the future when end_date_to is omitted")
+	ErrInvalidDateRange  = errors.New("invalid date range")
+	ErrInvalidInput      = errors.New("invalid subscription data")
+	ErrPriceChangeInPast = errors.New("effective_from must be after the current month")
+	ErrOutsidePeriod     = errors.New("month is outside the subscription period")
+	ErrAlreadyPaused     = errors.New("subscription is already paused")
+	ErrNotPaused         = errors.New("subscription is not paused")
+	ErrOverlap           = errors.New("subscription overlaps another one of the same user and service")
+	ErrModified          = errors.New("subscription was modified concurrently, read it again")
 )
 
+// OverlapError names the subscription a write would overlap. It matches ErrOverlap with errors.Is.
+type OverlapError struct {
+	ConflictingID uuid.UUID
+}
+
+func (e *OverlapError) Error() string {
+	return fmt.Sprintf("%s: conflicts with subscription %s", ErrOverlap, e.ConflictingID)
+}
+
+func (e *OverlapError) Is(target error) bool {
+	return target == ErrOverlap
+}
+
 // Saver Save Signature interface
 type Saver interface {
 	SaveSubscription(ctx context.Context, subscription models.Subscription) (uuid.UUID, time.Time, error)
 }
 
-// Updater Update Signature interface
+// Updater Update Signature interface. A non-zero UpdatedAt of subscription is the version
+// the caller read: the row is only updated if it still has it, else storage.ErrModified.
 type Updater interface {
 	UpdateSubscription(ctx context.Context, subscription models.Subscription) (models.Subscription, error)
 }
@@ -46,29 +70,69 @@ type Summer interface {
 	SumSubscriptions(ctx context.Context, filter models.SumFilter) (int64, error)
 }
 
+// ServiceSummer sums like Summer in one pass, per service, ordered by service name.
+// Services without a matching subscription are left out.
+type ServiceSummer interface {
+	SumSubscriptionsByService(ctx context.Context, filter models.SumFilter) ([]models.ServiceCost, error)
+}
+
+// TrialLister lists subscriptions by trial window.
+type TrialLister interface {
+	SubscriptionsWithTrialEnding(ctx context.Context, month time.Time, userID *uuid.UUID) ([]models.Subscription, error)
+}
+
+// PriceScheduler PriceChange save Signature interface
+type PriceScheduler interface {
+	SavePriceChange(ctx context.Context, change models.PriceChange) (models.PriceChange, error)
+}
+
+// PriceLister PriceChange list Signature interface
+type PriceLister interface {
+	PriceChanges(ctx context.Context, subscriptionID uuid.UUID) ([]models.PriceChange, error)
+}
+
+// Pauser Pause and Resume Signature interface
+type Pauser interface {
+	PauseSubscription(ctx context.Context, pause models.Pause) (models.Pause, error)
+	ResumeSubscription(ctx context.Context, id uuid.UUID, month time.Time) (models.Pause, error)
+}
+
+// Transferrer ends a subscription and creates its continuation for another user in one transaction.
+type Transferrer interface {
+	TransferSubscription(ctx context.Context, ended, continuation models.Subscription) (uuid.UUID, error)
+}
+
+// Upserter inserts a subscription with a client-supplied id or replaces the existing one.
+type Upserter interface {
+	UpsertSubscription(ctx context.Context, subscription models.Subscription) (models.Subscription, bool, error)
+}
+
+// Storage is everything the service reads and writes. The postgres, sqlite and
+// memory storages implement it, and so does Cache.
+type Storage interface {
+	Saver
+	Updater
+	Deleter
+	Getter
+	Summer
+	ServiceSummer
+	TrialLister
+	PriceScheduler
+	PriceLister
+	Pauser
+	Transferrer
+	Upserter
+	UserLister
+	Canceller
+}
+
 type Service struct {
-	subSaver   Saver
-	subUpdater Updater
-	subDeleter Deleter
-	subGetter  Getter
-	subSummer  Summer
+	storage Storage
 }
 
 // New Service constructor.
-func New(
-	subSaver Saver,
-	subUpdater Updater,
-	subDeleter Deleter,
-	subGetter Getter,
-	subSummer Summer,
-) *Service {
-	return &Service{
-		subSaver:   subSaver,
-		subUpdater: subUpdater,
-		subDeleter: subDeleter,
-		subGetter:  subGetter,
-		subSummer:  subSummer,
-	}
+func New(storage Storage) *Service {
+	return &Service{storage: storage}
 }
 
 // Save implementation of the Subscription interface.
@@ -76,13 +140,28 @@ func (s *Service) Save(ctx context.Context, sub models.Subscription) (uuid.UUID,
 	const op = "services.subscriptions.Create"
 	log := slogx.FromContext(ctx).With(slog.String("op", op))
 
-	id, createAt, err := s.subSaver.SaveSubscription(ctx, sub)
+	if err := sub.Validate(); err != nil {
+		log.WarnContext(ctx, "invalid subscription", slogx.Err(err))
+		return uuid.Nil, time.Time{}, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+
+	id, createAt, err := s.storage.SaveSubscription(ctx, sub)
 	if err != nil {
 		if errors.Is(err, storage.ErrSubscriptionExists) {
 			log.WarnContext(ctx, "subscription already exists", slogx.Err(err))
 			return uuid.Nil, time.Time{}, ErrAlreadyExists
 		}
 
+		if errors.Is(err, storage.ErrOverlap) {
+			log.WarnContext(ctx, "subscription overlaps another one", slogx.Err(err))
+			return uuid.Nil, time.Time{}, overlapError(err)
+		}
+
+		if errors.Is(err, storage.ErrCheckViolation) {
+			log.WarnContext(ctx, "subscription violates a check constraint", slogx.Err(err))
+			return uuid.Nil, time.Time{}, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+		}
+
 		log.ErrorContext(ctx, "error creating subscription", slogx.Err(err))
 		return uuid.Nil, time.Time{}, fmt.Errorf("create subscription: %w", err)
 	}
@@ -95,7 +174,7 @@ func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
 	const op = "services.subscriptions.Delete"
 	log := slogx.FromContext(ctx).With(slog.String("op", op), slog.String("id", id.String()))
 
-	err := s.subDeleter.DeleteSubscription(ctx, id)
+	err := s.storage.DeleteSubscription(ctx, id)
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
 			log.WarnContext(ctx, "subscription not found", slogx.Err(err))
@@ -117,7 +196,8 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, patch request.Update
 		slog.String("id", id.String()),
 	)
 
-	current, err := s.subGetter.SubscriptionByID(ctx, id)
+	// The patch is applied to the current row, so it must not come from a lagging replica.
+	current, err := s.storage.SubscriptionByID(storage.WithPrimary(ctx), id)
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
 			log.WarnContext(ctx, "subscription not found", slogx.Err(err))
@@ -129,12 +209,153 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, patch request.Update
 	}
 
 	if err := patch.ApplyTo(&current); err != nil {
-		log.ErrorContext(ctx, "failed to apply patch", slogx.Err(err))
-		return models.Subscription{}, fmt.Errorf("%s: apply: %w", op, err)
+		log.WarnContext(ctx, "failed to apply patch", slogx.Err(err))
+		return models.Subscription{}, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+
+	return s.update(ctx, log, op, current)
+}
+
+// Replace stores sub under its id, replacing every field of an existing subscription or
+// creating it. created reports which happened. As with Update, the owner of an existing
+// subscription cannot be changed.
+func (s *Service) Replace(ctx context.Context, sub models.Subscription) (stored models.Subscription, created bool, err error) {
+	const op = "services.subscriptions.Replace"
+	log := slogx.FromContext(ctx).With(
+		slog.String("op", op),
+		slog.String("id", sub.ID.String()),
+	)
+
+	if err := sub.Validate(); err != nil {
+		log.WarnContext(ctx, "invalid subscription", slogx.Err(err))
+		return models.Subscription{}, false, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+
+	current, err := s.storage.SubscriptionByID(storage.WithPrimary(ctx), sub.ID)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+	case err != nil:
+		log.ErrorContext(ctx, "failed to get subscription", slogx.Err(err))
+		return models.Subscription{}, false, fmt.Errorf("%s: get: %w", op, err)
+	case current.UserID != sub.UserID:
+		log.WarnContext(ctx, "owner change rejected")
+		return models.Subscription{}, false, fmt.Errorf(
+			"%w: user_id cannot be changed, transfer the subscription instead", ErrInvalidInput,
+		)
+	}
+
+	stored, created, err = s.storage.UpsertSubscription(ctx, sub)
+	if err != nil {
+		if errors.Is(err, storage.ErrSubscriptionExists) {
+			log.WarnContext(ctx, "subscription already exists", slogx.Err(err))
+			return models.Subscription{}, false, ErrAlreadyExists
+		}
+
+		if errors.Is(err, storage.ErrOverlap) {
+			log.WarnContext(ctx, "subscription overlaps another one", slogx.Err(err))
+			return models.Subscription{}, false, overlapError(err)
+		}
+
+		if errors.Is(err, storage.ErrCheckViolation) {
+			log.WarnContext(ctx, "subscription violates a check constraint", slogx.Err(err))
+			return models.Subscription{}, false, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+		}
+
+		log.ErrorContext(ctx, "failed to upsert subscription", slogx.Err(err))
+		return models.Subscription{}, false, fmt.Errorf("%s: upsert: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "subscription replaced", slog.Bool("created", created))
+	return stored, created, nil
+}
+
+// Patch applies a merge patch or JSON patch to the document of subscription id. apply gets
+// the JSON of request.SubscriptionDocument and returns the patched one; its errors are
+// returned as is, so the caller can tell a failed test operation from a malformed patch.
+func (s *Service) Patch(
+	ctx context.Context,
+	id uuid.UUID,
+	apply func(doc []byte) ([]byte, error),
+) (models.Subscription, error) {
+	const op = "services.subscriptions.Patch"
+	log := slogx.FromContext(ctx).With(
+		slog.String("op", op),
+		slog.String("id", id.String()),
+	)
+
+	// As in Update, test operations must see the current row, not a lagging replica.
+	current, err := s.storage.SubscriptionByID(storage.WithPrimary(ctx), id)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			log.WarnContext(ctx, "subscription not found", slogx.Err(err))
+			return models.Subscription{}, ErrNotFound
+		}
+
+		log.ErrorContext(ctx, "failed to get subscription", slogx.Err(err))
+		return models.Subscription{}, fmt.Errorf("%s: get: %w", op, err)
+	}
+
+	doc, err := json.Marshal(request.DocumentOf(current))
+	if err != nil {
+		log.ErrorContext(ctx, "failed to encode subscription", slogx.Err(err))
+		return models.Subscription{}, fmt.Errorf("%s: encode: %w", op, err)
+	}
+
+	patched, err := apply(doc)
+	if err != nil {
+		log.WarnContext(ctx, "failed to apply patch", slogx.Err(err))
+		return models.Subscription{}, err
+	}
+
+	d, err := request.DecodeDocument(patched)
+	if err != nil {
+		log.WarnContext(ctx, "patched document is invalid", slogx.Err(err))
+		return models.Subscription{}, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+
+	if err := d.ApplyTo(&current); err != nil {
+		log.WarnContext(ctx, "failed to apply patched document", slogx.Err(err))
+		return models.Subscription{}, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+
+	return s.update(ctx, log, op, current)
+}
+
+// update validates and stores a patched subscription, mapping storage errors for Update and Patch.
+// sub carries the updated_at it was read with, so a write made since then fails with ErrModified.
+func (s *Service) update(ctx context.Context, log *slogx.Logger, op string, sub models.Subscription) (models.Subscription, error) {
+	if err := sub.Validate(); err != nil {
+		log.WarnContext(ctx, "patched subscription is invalid", slogx.Err(err))
+		return models.Subscription{}, fmt.Errorf("%w: %w", ErrInvalidInput, err)
 	}
 
-	updated, err := s.subUpdater.UpdateSubscription(ctx, current)
+	updated, err := s.storage.UpdateSubscription(ctx, sub)
 	if err != nil {
+		if errors.Is(err, storage.ErrSubscriptionExists) {
+			log.WarnContext(ctx, "subscription already exists", slogx.Err(err))
+			return models.Subscription{}, ErrAlreadyExists
+		}
+
+		if errors.Is(err, storage.ErrOverlap) {
+			log.WarnContext(ctx, "subscription overlaps another one", slogx.Err(err))
+			return models.Subscription{}, overlapError(err)
+		}
+
+		if errors.Is(err, storage.ErrCheckViolation) {
+			log.WarnContext(ctx, "subscription violates a check constraint", slogx.Err(err))
+			return models.Subscription{}, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+		}
+
+		if errors.Is(err, storage.ErrNotFound) {
+			log.WarnContext(ctx, "subscription not found", slogx.Err(err))
+			return models.Subscription{}, ErrNotFound
+		}
+
+		if errors.Is(err, storage.ErrModified) {
+			log.WarnContext(ctx, "subscription was modified concurrently", slogx.Err(err))
+			return models.Subscription{}, ErrModified
+		}
+
 		log.ErrorContext(ctx, "failed to update subscription", slogx.Err(err))
 		return models.Subscription{}, fmt.Errorf("%s: update: %w", op, err)
 	}
@@ -151,7 +372,7 @@ func (s *Service) Get(ctx context.Context, id uuid.UUID) (models.Subscription, e
 		slog.String("id", id.String()),
 	)
 
-	sub, err := s.subGetter.SubscriptionByID(ctx, id)
+	sub, err := s.storage.SubscriptionByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
 			log.WarnContext(ctx, "subscription not found", slogx.Err(err))
@@ -199,7 +420,7 @@ func (s *Service) Sum(ctx context.Context, f models.SumFilter) (int64, error) {
 				slog.Time("from", *f.StartDateFrom),
 				slog.Time("to", *f.StartDateTo),
 			)
-			return 0, fmt.Errorf("start_date_to must be >= start_date_from")
+			return 0, fmt.Errorf("%w: start_date_to must be >= start_date_from", ErrInvalidDateRange)
 		}
 	}
 
@@ -211,11 +432,346 @@ func (s *Service) Sum(ctx context.Context, f models.SumFilter) (int64, error) {
 				slog.Time("from", *f.EndDateFrom),
 				slog.Time("to", *f.EndDateTo),
 			)
-			return 0, fmt.Errorf("end_date_to must be >= end_date_from")
+			return 0, fmt.Errorf("%w: end_date_to must be >= end_date_from", ErrInvalidDateRange)
 		}
 	}
 
+	if f.AsOf == nil {
+		f.AsOf = &currentMonth
+	}
+
 	log.InfoContext(ctx, "calculating subscription sum")
 
-	return s.subSummer.SumSubscriptions(ctx, f)
+	return s.storage.SumSubscriptions(ctx, f)
+}
+
+// TrialsEnding returns the subscriptions whose trial ends in month, optionally for a single user.
+func (s *Service) TrialsEnding(ctx context.Context, month time.Time, userID *uuid.UUID) ([]models.Subscription, error) {
+	const op = "services.subscriptions.TrialsEnding"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	subs, err := s.storage.SubscriptionsWithTrialEnding(ctx, models.MonthOf(month), userID)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list trials", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return subs, nil
+}
+
+// SchedulePriceChange sets a new price of the subscription from a future month on.
+// Scheduling the same month again replaces the price.
+func (s *Service) SchedulePriceChange(
+	ctx context.Context,
+	id uuid.UUID,
+	effectiveFrom time.Time,
+	price int64,
+) (models.PriceChange, error) {
+	const op = "services.subscriptions.SchedulePriceChange"
+	log := slogx.FromContext(ctx).With(
+		slog.String("op", op),
+		slog.String("id", id.String()),
+	)
+
+	effectiveFrom = models.MonthOf(effectiveFrom)
+
+	if !effectiveFrom.After(models.MonthOf(time.Now())) {
+		return models.PriceChange{}, ErrPriceChangeInPast
+	}
+
+	sub, err := s.storage.SubscriptionByID(storage.WithPrimary(ctx), id)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			log.WarnContext(ctx, "subscription not found", slogx.Err(err))
+			return models.PriceChange{}, ErrNotFound
+		}
+
+		log.ErrorContext(ctx, "failed to get subscription", slogx.Err(err))
+		return models.PriceChange{}, fmt.Errorf("%s: get: %w", op, err)
+	}
+
+	if !effectiveFrom.After(sub.StartDate) || (sub.EndDate != nil && effectiveFrom.After(*sub.EndDate)) {
+		return models.PriceChange{}, ErrOutsidePeriod
+	}
+
+	change, err := s.storage.SavePriceChange(
+		ctx, models.PriceChange{
+			SubscriptionID: id,
+			EffectiveFrom:  effectiveFrom,
+			Price:          price,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			log.WarnContext(ctx, "subscription not found", slogx.Err(err))
+			return models.PriceChange{}, ErrNotFound
+		}
+
+		log.ErrorContext(ctx, "failed to save price change", slogx.Err(err))
+		return models.PriceChange{}, fmt.Errorf("%s: save: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "price change scheduled", slog.Time("effective_from", effectiveFrom))
+	return change, nil
+}
+
+// PriceChanges returns the past and scheduled price changes of the subscription, oldest first.
+func (s *Service) PriceChanges(ctx context.Context, id uuid.UUID) ([]models.PriceChange, error) {
+	const op = "services.subscriptions.PriceChanges"
+	log := slogx.FromContext(ctx).With(
+		slog.String("op", op),
+		slog.String("id", id.String()),
+	)
+
+	if _, err := s.storage.SubscriptionByID(ctx, id); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			log.WarnContext(ctx, "subscription not found", slogx.Err(err))
+			return nil, ErrNotFound
+		}
+
+		log.ErrorContext(ctx, "failed to get subscription", slogx.Err(err))
+		return nil, fmt.Errorf("%s: get: %w", op, err)
+	}
+
+	changes, err := s.storage.PriceChanges(ctx, id)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list price changes", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return changes, nil
+}
+
+// Pause stops billing the subscription from month on, the current month if month is nil.
+// Months already covered by an earlier pause cannot be paused again.
+func (s *Service) Pause(ctx context.Context, id uuid.UUID, month *time.Time) (models.Subscription, error) {
+	const op = "services.subscriptions.Pause"
+	log := slogx.FromContext(ctx).With(
+		slog.String("op", op),
+		slog.String("id", id.String()),
+	)
+
+	sub, err := s.primarySubscription(ctx, op, log, id)
+	if err != nil {
+		return models.Subscription{}, err
+	}
+
+	from := models.MonthOf(time.Now())
+	if month != nil {
+		from = models.MonthOf(*month)
+	}
+
+	if sub.OpenPause() != nil {
+		return models.Subscription{}, ErrAlreadyPaused
+	}
+
+	if from.Before(sub.StartDate) || (sub.EndDate != nil && from.After(*sub.EndDate)) {
+		return models.Subscription{}, ErrOutsidePeriod
+	}
+
+	for _, p := range sub.Pauses {
+		if from.Before(*p.ResumedFrom) {
+			return models.Subscription{}, fmt.Errorf("%w: pause overlaps an earlier pause", ErrInvalidDateRange)
+		}
+	}
+
+	if _, err := s.storage.PauseSubscription(ctx, models.Pause{SubscriptionID: id, PausedFrom: from}); err != nil {
+		return models.Subscription{}, s.pauseError(ctx, op, log, err)
+	}
+
+	log.InfoContext(ctx, "subscription paused", slog.Time("from", from))
+
+	return s.primarySubscription(ctx, op, log, id)
+}
+
+// Resume bills the subscription again from month on, by default the current month
+// or the first paused month if the pause has not started yet.
+func (s *Service) Resume(ctx context.Context, id uuid.UUID, month *time.Time) (models.Subscription, error) {
+	const op = "services.subscriptions.Resume"
+	log := slogx.FromContext(ctx).With(
+		slog.String("op", op),
+		slog.String("id", id.String()),
+	)
+
+	sub, err := s.primarySubscription(ctx, op, log, id)
+	if err != nil {
+		return models.Subscription{}, err
+	}
+
+	open := sub.OpenPause()
+	if open == nil {
+		return models.Subscription{}, ErrNotPaused
+	}
+
+	from := models.MonthOf(time.Now())
+	if month != nil {
+		from = models.MonthOf(*month)
+	} else if from.Before(open.PausedFrom) {
+		from = open.PausedFrom
+	}
+
+	if from.Before(open.PausedFrom) {
+		return models.Subscription{}, fmt.Errorf("%w: cannot resume before the pause starts", ErrInvalidDateRange)
+	}
+
+	if _, err := s.storage.ResumeSubscription(ctx, id, from); err != nil {
+		return models.Subscription{}, s.pauseError(ctx, op, log, err)
+	}
+
+	log.InfoContext(ctx, "subscription resumed", slog.Time("from", from))
+
+	return s.primarySubscription(ctx, op, log, id)
+}
+
+// Transfer moves the subscription to userID from month on, the current month if month is nil.
+// The subscription ends for its owner the month before, and a continuation with the price in
+// effect and what is left of the trial is created for userID; later price changes move with it.
+// It returns the ended subscription and the continuation.
+func (s *Service) Transfer(
+	ctx context.Context,
+	id uuid.UUID,
+	userID uuid.UUID,
+	month *time.Time,
+) (models.Subscription, models.Subscription, error) {
+	const op = "services.subscriptions.Transfer"
+	log := slogx.FromContext(ctx).With(
+		slog.String("op", op),
+		slog.String("id", id.String()),
+	)
+
+	sub, err := s.primarySubscription(ctx, op, log, id)
+	if err != nil {
+		return models.Subscription{}, models.Subscription{}, err
+	}
+
+	from := models.MonthOf(time.Now())
+	if month != nil {
+		from = models.MonthOf(*month)
+	}
+
+	if userID == sub.UserID {
+		return models.Subscription{}, models.Subscription{}, fmt.Errorf("%w: the subscription already belongs to the user", ErrInvalidInput)
+	}
+
+	// The old owner keeps at least one month, otherwise there is nothing to end.
+	if !from.After(sub.StartDate) || (sub.EndDate != nil && from.After(*sub.EndDate)) {
+		return models.Subscription{}, models.Subscription{}, ErrOutsidePeriod
+	}
+
+	if sub.OpenPause() != nil {
+		return models.Subscription{}, models.Subscription{}, fmt.Errorf("%w: resume it before the transfer", ErrAlreadyPaused)
+	}
+
+	for _, p := range sub.Pauses {
+		if p.ResumedFrom.After(from) {
+			return models.Subscription{}, models.Subscription{}, fmt.Errorf("%w: transfer month is inside a pause", ErrInvalidDateRange)
+		}
+	}
+
+	changes, err := s.storage.PriceChanges(storage.WithPrimary(ctx), id)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list price changes", slogx.Err(err))
+		return models.Subscription{}, models.Subscription{}, fmt.Errorf("%s: prices: %w", op, err)
+	}
+
+	ended := sub
+	endDate := from.AddDate(0, -1, 0)
+	ended.EndDate = &endDate
+
+	continuation := sub.Continuation(userID, from, changes)
+	if err := continuation.Validate(); err != nil {
+		log.WarnContext(ctx, "invalid continuation", slogx.Err(err))
+		return models.Subscription{}, models.Subscription{}, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+
+	newID, err := s.storage.TransferSubscription(ctx, ended, continuation)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			log.WarnContext(ctx, "subscription not found", slogx.Err(err))
+			return models.Subscription{}, models.Subscription{}, ErrNotFound
+		case errors.Is(err, storage.ErrSubscriptionExists):
+			log.WarnContext(ctx, "user already has the subscription", slogx.Err(err))
+			return models.Subscription{}, models.Subscription{}, fmt.Errorf(
+				"%w: user %s already has %s starting %s",
+				ErrAlreadyExists, userID, continuation.ServiceName, from.Format(format.MonthYear),
+			)
+		case errors.Is(err, storage.ErrOverlap):
+			log.WarnContext(ctx, "continuation overlaps a subscription of the user", slogx.Err(err))
+			return models.Subscription{}, models.Subscription{}, overlapError(err)
+		case errors.Is(err, storage.ErrCheckViolation):
+			log.WarnContext(ctx, "subscription violates a check constraint", slogx.Err(err))
+			return models.Subscription{}, models.Subscription{}, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+		}
+
+		log.ErrorContext(ctx, "failed to transfer subscription", slogx.Err(err))
+		return models.Subscription{}, models.Subscription{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(
+		ctx, "subscription transferred",
+		slog.String("to_user_id", userID.String()),
+		slog.String("continuation_id", newID.String()),
+		slog.Time("from", from),
+	)
+
+	ended, err = s.primarySubscription(ctx, op, log, id)
+	if err != nil {
+		return models.Subscription{}, models.Subscription{}, err
+	}
+
+	continuation, err = s.primarySubscription(ctx, op, log, newID)
+	if err != nil {
+		return models.Subscription{}, models.Subscription{}, err
+	}
+
+	return ended, continuation, nil
+}
+
+// primarySubscription reads the subscription from the primary, so that checks see the latest writes.
+func (s *Service) primarySubscription(
+	ctx context.Context,
+	op string,
+	log *slogx.Logger,
+	id uuid.UUID,
+) (models.Subscription, error) {
+	sub, err := s.storage.SubscriptionByID(storage.WithPrimary(ctx), id)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			log.WarnContext(ctx, "subscription not found", slogx.Err(err))
+			return models.Subscription{}, ErrNotFound
+		}
+
+		log.ErrorContext(ctx, "failed to get subscription", slogx.Err(err))
+		return models.Subscription{}, fmt.Errorf("%s: get: %w", op, err)
+	}
+
+	return sub, nil
+}
+
+func (s *Service) pauseError(ctx context.Context, op string, log *slogx.Logger, err error) error {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		log.WarnContext(ctx, "subscription not found", slogx.Err(err))
+		return ErrNotFound
+	case errors.Is(err, storage.ErrAlreadyPaused):
+		log.WarnContext(ctx, "subscription already paused", slogx.Err(err))
+		return ErrAlreadyPaused
+	case errors.Is(err, storage.ErrNotPaused):
+		log.WarnContext(ctx, "subscription not paused", slogx.Err(err))
+		return ErrNotPaused
+	default:
+		log.ErrorContext(ctx, "failed to save pause", slogx.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+}
+
+// overlapError maps a storage overlap error to ErrOverlap, naming the conflicting subscription when known.
+func overlapError(err error) error {
+	var overlap *storage.OverlapError
+	if errors.As(err, &overlap) {
+		return &OverlapError{ConflictingID: overlap.ConflictingID}
+	}
+
+	return ErrOverlap
 }
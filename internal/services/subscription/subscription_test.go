@@ -0,0 +1,588 @@
+package subscription_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/format"
+	"github.com/salivare/subscriptions-service/internal/httpserver/request"
+	"github.com/salivare/subscriptions-service/internal/jsonpatch"
+	"github.com/salivare/subscriptions-service/internal/services/subscription"
+	"github.com/salivare/subscriptions-service/internal/storage/memory"
+)
+
+func newService() *subscription.Service {
+	s := memory.New()
+	return subscription.New(s)
+}
+
+func TestService_SchedulePriceChange(t *testing.T) {
+	ctx := context.Background()
+	srv := newService()
+
+	current := models.MonthOf(time.Now())
+	price := int64(400)
+	end := current.AddDate(1, 0, 0)
+
+	id, _, err := srv.Save(ctx, models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      uuid.New(),
+		StartDate:   current.AddDate(-1, 0, 0),
+		EndDate:     &end,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		id      uuid.UUID
+		month   time.Time
+		wantErr error
+	}{
+		{name: "next month", id: id, month: current.AddDate(0, 1, 0)},
+		{name: "last month of the period", id: id, month: end},
+		{name: "current month", id: id, month: current, wantErr: subscription.ErrPriceChangeInPast},
+		{name: "after the end", id: id, month: end.AddDate(0, 1, 0), wantErr: subscription.ErrOutsidePeriod},
+		{name: "missing subscription", id: uuid.New(), month: current.AddDate(0, 1, 0), wantErr: subscription.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				change, err := srv.SchedulePriceChange(ctx, tt.id, tt.month, 500)
+
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+					return
+				}
+
+				require.NoError(t, err)
+				assert.True(t, tt.month.Equal(change.EffectiveFrom))
+			},
+		)
+	}
+
+	changes, err := srv.PriceChanges(ctx, id)
+	require.NoError(t, err)
+	assert.Len(t, changes, 2)
+
+	_, err = srv.PriceChanges(ctx, uuid.New())
+	assert.ErrorIs(t, err, subscription.ErrNotFound)
+}
+
+func TestService_PauseResume(t *testing.T) {
+	ctx := context.Background()
+	srv := newService()
+
+	current := models.MonthOf(time.Now())
+	price := int64(400)
+	userID := uuid.New()
+
+	id, _, err := srv.Save(ctx, models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      userID,
+		StartDate:   current.AddDate(-1, 0, 0),
+	})
+	require.NoError(t, err)
+
+	month := func(offset int) *time.Time {
+		m := current.AddDate(0, offset, 0)
+		return &m
+	}
+
+	_, err = srv.Resume(ctx, id, nil)
+	assert.ErrorIs(t, err, subscription.ErrNotPaused)
+
+	_, err = srv.Pause(ctx, id, month(-13))
+	assert.ErrorIs(t, err, subscription.ErrOutsidePeriod)
+
+	_, err = srv.Pause(ctx, uuid.New(), nil)
+	assert.ErrorIs(t, err, subscription.ErrNotFound)
+
+	sub, err := srv.Pause(ctx, id, month(-2))
+	require.NoError(t, err)
+	require.NotNil(t, sub.OpenPause())
+	assert.True(t, month(-2).Equal(sub.OpenPause().PausedFrom))
+
+	_, err = srv.Pause(ctx, id, nil)
+	assert.ErrorIs(t, err, subscription.ErrAlreadyPaused)
+
+	_, err = srv.Resume(ctx, id, month(-3))
+	assert.ErrorIs(t, err, subscription.ErrInvalidDateRange)
+
+	sub, err = srv.Resume(ctx, id, nil)
+	require.NoError(t, err)
+	require.Len(t, sub.Pauses, 1)
+	require.NotNil(t, sub.Pauses[0].ResumedFrom)
+	assert.True(t, current.Equal(*sub.Pauses[0].ResumedFrom))
+
+	// A new pause cannot start inside the previous one.
+	_, err = srv.Pause(ctx, id, month(-1))
+	assert.ErrorIs(t, err, subscription.ErrInvalidDateRange)
+
+	uid := userID.String()
+
+	total, err := srv.Sum(ctx, models.SumFilter{UserID: &uid, AsOf: month(-1)})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+
+	total, err = srv.Sum(ctx, models.SumFilter{UserID: &uid})
+	require.NoError(t, err)
+	assert.Equal(t, int64(400), total)
+
+	// Resuming a pause that starts in the future defaults to its first month.
+	_, err = srv.Pause(ctx, id, month(3))
+	require.NoError(t, err)
+
+	sub, err = srv.Resume(ctx, id, nil)
+	require.NoError(t, err)
+	require.Len(t, sub.Pauses, 2)
+	assert.True(t, month(3).Equal(*sub.Pauses[1].ResumedFrom))
+}
+
+func TestService_Overlap(t *testing.T) {
+	ctx := context.Background()
+	srv := newService()
+
+	userID := uuid.New()
+	price := int64(400)
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 5, 0)
+
+	first, _, err := srv.Save(ctx, models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      userID,
+		StartDate:   start,
+		EndDate:     &end,
+	})
+	require.NoError(t, err)
+
+	second, _, err := srv.Save(ctx, models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      userID,
+		StartDate:   end.AddDate(0, 1, 0),
+	})
+	require.NoError(t, err)
+
+	_, _, err = srv.Save(ctx, models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      userID,
+		StartDate:   start.AddDate(0, 2, 0),
+	})
+	require.ErrorIs(t, err, subscription.ErrOverlap)
+	assert.ErrorContains(t, err, first.String())
+
+	startDate := start.AddDate(0, 3, 0).Format(format.MonthYear)
+	_, err = srv.Update(ctx, second, request.UpdateRequest{StartDate: &startDate})
+	require.ErrorIs(t, err, subscription.ErrOverlap)
+	assert.ErrorContains(t, err, first.String())
+
+	allow := true
+	updated, err := srv.Update(ctx, second, request.UpdateRequest{StartDate: &startDate, AllowOverlap: &allow})
+	require.NoError(t, err)
+	assert.True(t, updated.AllowOverlap)
+}
+
+func TestService_Validate(t *testing.T) {
+	ctx := context.Background()
+	srv := newService()
+
+	price := int64(400)
+	start := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	before := start.AddDate(0, -1, 0)
+
+	_, _, err := srv.Save(ctx, models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      uuid.New(),
+		StartDate:   start,
+		EndDate:     &before,
+	})
+	require.ErrorIs(t, err, subscription.ErrInvalidInput)
+	assert.ErrorIs(t, err, models.ErrInvalid)
+
+	id, _, err := srv.Save(ctx, models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      uuid.New(),
+		StartDate:   start,
+	})
+	require.NoError(t, err)
+
+	endDate := before.Format(format.MonthYear)
+	_, err = srv.Update(ctx, id, request.UpdateRequest{EndDate: &endDate})
+	require.ErrorIs(t, err, subscription.ErrInvalidInput)
+
+	var invalid models.ValidationErrors
+	require.ErrorAs(t, err, &invalid)
+	assert.Equal(t, "end_date", invalid[0].Field)
+}
+
+func TestService_Transfer(t *testing.T) {
+	ctx := context.Background()
+	srv := newService()
+
+	owner := uuid.New()
+	price := int64(400)
+	current := models.MonthOf(time.Now())
+	start := current.AddDate(0, -2, 0)
+	end := current.AddDate(1, 0, 0)
+
+	// The trial runs from two months ago to two months ahead.
+	id, _, err := srv.Save(ctx, models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      owner,
+		StartDate:   start,
+		EndDate:     &end,
+		TrialMonths: 5,
+		TrialPrice:  100,
+	})
+	require.NoError(t, err)
+
+	next := current.AddDate(0, 1, 0)
+	_, err = srv.SchedulePriceChange(ctx, id, next, 500)
+	require.NoError(t, err)
+
+	newOwner := uuid.New()
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		month   *time.Time
+		wantErr error
+	}{
+		{name: "same owner", userID: owner, wantErr: subscription.ErrInvalidInput},
+		{name: "first month", userID: newOwner, month: &start, wantErr: subscription.ErrOutsidePeriod},
+		{name: "after the end", userID: newOwner, month: ptr(end.AddDate(0, 1, 0)), wantErr: subscription.ErrOutsidePeriod},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				_, _, err := srv.Transfer(ctx, id, tt.userID, tt.month)
+				assert.ErrorIs(t, err, tt.wantErr)
+			},
+		)
+	}
+
+	_, _, err = srv.Transfer(ctx, uuid.New(), newOwner, nil)
+	assert.ErrorIs(t, err, subscription.ErrNotFound)
+
+	busy := uuid.New()
+	_, _, err = srv.Save(ctx, models.Subscription{ServiceName: "Netflix", Price: &price, UserID: busy, StartDate: current})
+	require.NoError(t, err)
+
+	_, _, err = srv.Transfer(ctx, id, busy, nil)
+	assert.ErrorIs(t, err, subscription.ErrAlreadyExists)
+	assert.ErrorContains(t, err, busy.String())
+
+	ended, continuation, err := srv.Transfer(ctx, id, newOwner, nil)
+	require.NoError(t, err)
+
+	require.NotNil(t, ended.EndDate)
+	assert.True(t, current.AddDate(0, -1, 0).Equal(*ended.EndDate))
+
+	assert.Equal(t, newOwner, continuation.UserID)
+	assert.True(t, current.Equal(continuation.StartDate))
+	require.NotNil(t, continuation.EndDate)
+	assert.True(t, end.Equal(*continuation.EndDate))
+	assert.Equal(t, price, *continuation.Price)
+	assert.Equal(t, 3, continuation.TrialMonths, "the rest of the trial moves with the subscription")
+	assert.Equal(t, int64(100), continuation.TrialPrice)
+
+	changes, err := srv.PriceChanges(ctx, continuation.ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.True(t, next.Equal(changes[0].EffectiveFrom))
+
+}
+
+func TestService_UpdateRejectsOwnerChange(t *testing.T) {
+	ctx := context.Background()
+	srv := newService()
+
+	price := int64(400)
+	owner := uuid.New()
+
+	id, _, err := srv.Save(ctx, models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      owner,
+		StartDate:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	other := uuid.NewString()
+	_, err = srv.Update(ctx, id, request.UpdateRequest{UserID: &other})
+	assert.ErrorIs(t, err, subscription.ErrInvalidInput)
+
+	same := owner.String()
+	_, err = srv.Update(ctx, id, request.UpdateRequest{UserID: &same})
+	assert.NoError(t, err)
+}
+
+func TestService_Replace(t *testing.T) {
+	ctx := context.Background()
+	srv := newService()
+
+	owner := uuid.New()
+	sub := models.Subscription{
+		ID:          uuid.New(),
+		ServiceName: "Netflix",
+		Price:       ptr(int64(400)),
+		UserID:      owner,
+		StartDate:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		TrialMonths: 2,
+	}
+
+	stored, created, err := srv.Replace(ctx, sub)
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, sub.ID, stored.ID)
+
+	// Replacing is idempotent and resets the fields the body leaves at zero.
+	sub.TrialMonths = 0
+	for range 2 {
+		stored, created, err = srv.Replace(ctx, sub)
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Zero(t, stored.TrialMonths)
+	}
+
+	moved := sub
+	moved.UserID = uuid.New()
+	_, _, err = srv.Replace(ctx, moved)
+	assert.ErrorIs(t, err, subscription.ErrInvalidInput, "owner changes need a transfer")
+
+	invalid := sub
+	invalid.Price = ptr(int64(-1))
+	_, _, err = srv.Replace(ctx, invalid)
+	assert.ErrorIs(t, err, subscription.ErrInvalidInput)
+
+	duplicate := sub
+	duplicate.ID = uuid.New()
+	_, _, err = srv.Replace(ctx, duplicate)
+	assert.ErrorIs(t, err, subscription.ErrAlreadyExists)
+}
+
+func TestService_Patch(t *testing.T) {
+	ctx := context.Background()
+	srv := newService()
+
+	price := int64(400)
+	end := time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+	id, _, err := srv.Save(ctx, models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      uuid.New(),
+		StartDate:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:     &end,
+		TrialMonths: 2,
+	})
+	require.NoError(t, err)
+
+	merge := func(patch string) func([]byte) ([]byte, error) {
+		return func(doc []byte) ([]byte, error) { return jsonpatch.MergePatch(doc, []byte(patch)) }
+	}
+
+	jsonPatch := func(patch string) func([]byte) ([]byte, error) {
+		p, err := jsonpatch.Decode([]byte(patch))
+		require.NoError(t, err)
+		return p.Apply
+	}
+
+	// null clears the end date and resets the trial; absent members are left alone.
+	patched, err := srv.Patch(ctx, id, merge(`{"end_date": null, "trial_months": null}`))
+	require.NoError(t, err)
+	assert.Nil(t, patched.EndDate)
+	assert.Zero(t, patched.TrialMonths)
+	assert.Equal(t, int64(400), *patched.Price)
+
+	_, err = srv.Patch(ctx, id, merge(`{"price": null}`))
+	assert.ErrorIs(t, err, subscription.ErrInvalidInput, "price is required")
+
+	_, err = srv.Patch(ctx, id, merge(`{"user_id": "`+uuid.NewString()+`"}`))
+	assert.ErrorIs(t, err, subscription.ErrInvalidInput, "owner changes need a transfer")
+
+	_, err = srv.Patch(ctx, id, merge(`{"unknown": 1}`))
+	assert.ErrorIs(t, err, subscription.ErrInvalidInput)
+
+	// A failed test operation leaves the subscription untouched.
+	_, err = srv.Patch(ctx, id, jsonPatch(`[
+		{"op": "test", "path": "/price", "value": 300},
+		{"op": "replace", "path": "/price", "value": 500}
+	]`))
+	assert.ErrorIs(t, err, jsonpatch.ErrTestFailed)
+
+	got, err := srv.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(400), *got.Price)
+
+	patched, err = srv.Patch(ctx, id, jsonPatch(`[
+		{"op": "test", "path": "/price", "value": 400},
+		{"op": "replace", "path": "/price", "value": 500},
+		{"op": "replace", "path": "/end_date", "value": "06-2024"}
+	]`))
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), *patched.Price)
+	require.NotNil(t, patched.EndDate)
+	assert.Equal(t, time.June, patched.EndDate.Month())
+
+	_, err = srv.Patch(ctx, id, jsonPatch(`[{"op": "replace", "path": "/id", "value": "`+uuid.NewString()+`"}]`))
+	assert.ErrorIs(t, err, subscription.ErrInvalidInput, "id is read-only")
+
+	_, err = srv.Patch(ctx, uuid.New(), merge(`{}`))
+	assert.ErrorIs(t, err, subscription.ErrNotFound)
+}
+
+func TestService_PatchConcurrent(t *testing.T) {
+	ctx := context.Background()
+	srv := newService()
+
+	price := int64(400)
+	id, _, err := srv.Save(ctx, models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      uuid.New(),
+		StartDate:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	// Both patches read the subscription before either writes, so both test operations pass.
+	var read sync.WaitGroup
+	read.Add(2)
+
+	prices := []int64{500, 600}
+	errs := make([]error, len(prices))
+
+	var wg sync.WaitGroup
+	for i, p := range prices {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			patch, err := jsonpatch.Decode([]byte(fmt.Sprintf(`[
+				{"op": "test", "path": "/price", "value": 400},
+				{"op": "replace", "path": "/price", "value": %d}
+			]`, p)))
+			if err != nil {
+				errs[i] = err
+				read.Done()
+				return
+			}
+
+			_, errs[i] = srv.Patch(ctx, id, func(doc []byte) ([]byte, error) {
+				read.Done()
+				read.Wait()
+				return patch.Apply(doc)
+			})
+		}()
+	}
+	wg.Wait()
+
+	winner := -1
+	for i, err := range errs {
+		if err == nil {
+			winner = i
+			continue
+		}
+		assert.ErrorIs(t, err, subscription.ErrModified)
+	}
+	require.NotEqual(t, -1, winner, "one of the patches must be stored")
+
+	got, err := srv.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, prices[winner], *got.Price, "the rejected patch must not overwrite the stored one")
+}
+
+func TestService_Summary(t *testing.T) {
+	ctx := context.Background()
+	srv := newService()
+
+	current := models.MonthOf(time.Now())
+	userID := uuid.New()
+
+	save := func(service string, price int64, start time.Time, end *time.Time) uuid.UUID {
+		id, _, err := srv.Save(ctx, models.Subscription{
+			ServiceName: service,
+			Price:       &price,
+			UserID:      userID,
+			StartDate:   start,
+			EndDate:     end,
+		})
+		require.NoError(t, err)
+		return id
+	}
+
+	summary, err := srv.Summary(ctx, userID)
+	require.NoError(t, err)
+	assert.Zero(t, summary.ActiveCount)
+	assert.Nil(t, summary.MostExpensive)
+
+	ended := current.AddDate(0, -3, 0)
+	save("Netflix", 400, current.AddDate(-1, 0, 0), nil)
+	save("Spotify", 200, current.AddDate(0, -2, 0), nil)
+	save("Spotify", 300, current.AddDate(-1, 0, 0), &ended)
+	save("Kion", 900, current.AddDate(0, 1, 0), nil)
+	paused := save("Yandex Plus", 1000, current.AddDate(0, -3, 0), nil)
+
+	_, err = srv.Pause(ctx, paused, &current)
+	require.NoError(t, err)
+
+	summary, err = srv.Summary(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, current, summary.Month)
+	assert.Equal(t, 2, summary.ActiveCount, "ended, future and paused subscriptions are not active")
+	assert.Equal(t, int64(600), summary.MonthlyCost)
+	require.NotNil(t, summary.MostExpensive)
+	assert.Equal(t, models.ServiceCost{ServiceName: "Netflix", Cost: 400}, *summary.MostExpensive)
+}
+
+func TestService_CancelAll(t *testing.T) {
+	ctx := context.Background()
+	srv := newService()
+
+	current := models.MonthOf(time.Now())
+	userID := uuid.New()
+
+	price := int64(400)
+	id, _, err := srv.Save(ctx, models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      userID,
+		StartDate:   current.AddDate(0, -6, 0),
+	})
+	require.NoError(t, err)
+
+	past := current.AddDate(0, -1, 0)
+	_, _, err = srv.CancelAll(ctx, userID, &past)
+	assert.ErrorIs(t, err, subscription.ErrInvalidDateRange)
+
+	end, ids, err := srv.CancelAll(ctx, userID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, current, end)
+	assert.Equal(t, []uuid.UUID{id}, ids)
+
+	got, err := srv.Get(ctx, id)
+	require.NoError(t, err)
+	require.NotNil(t, got.EndDate)
+	assert.Equal(t, current, *got.EndDate)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,131 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+)
+
+// UserLister lists the subscriptions of a user.
+type UserLister interface {
+	SubscriptionsByUser(ctx context.Context, userID uuid.UUID) ([]models.Subscription, error)
+}
+
+// Canceller ends every open-ended subscription of a user with one statement.
+type Canceller interface {
+	CancelUserSubscriptions(ctx context.Context, userID uuid.UUID, month time.Time) ([]uuid.UUID, error)
+}
+
+// ListByUser returns every subscription of userID ordered by start date and service.
+func (s *Service) ListByUser(ctx context.Context, userID uuid.UUID) ([]models.Subscription, error) {
+	const op = "services.subscriptions.ListByUser"
+	log := slogx.FromContext(ctx).With(
+		slog.String("op", op),
+		slog.String("user_id", userID.String()),
+	)
+
+	subs, err := s.storage.SubscriptionsByUser(ctx, userID)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list subscriptions", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return subs, nil
+}
+
+// Summary describes the current month of userID. Costs come from one grouped sum priced
+// like SumSubscriptions, so pauses, trials and scheduled prices are taken into account.
+func (s *Service) Summary(ctx context.Context, userID uuid.UUID) (models.UserSummary, error) {
+	const op = "services.subscriptions.Summary"
+	log := slogx.FromContext(ctx).With(
+		slog.String("op", op),
+		slog.String("user_id", userID.String()),
+	)
+
+	month := models.MonthOf(time.Now())
+	summary := models.UserSummary{UserID: userID, Month: month}
+
+	subs, err := s.storage.SubscriptionsByUser(ctx, userID)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list subscriptions", slogx.Err(err))
+		return models.UserSummary{}, fmt.Errorf("%s: list: %w", op, err)
+	}
+
+	var services []string
+	seen := make(map[string]bool)
+
+	for _, sub := range subs {
+		if !sub.ActiveIn(month) || sub.PausedAt(month) {
+			continue
+		}
+
+		summary.ActiveCount++
+
+		if !seen[sub.ServiceName] {
+			seen[sub.ServiceName] = true
+			services = append(services, sub.ServiceName)
+		}
+	}
+
+	uid := userID.String()
+	costs, err := s.storage.SumSubscriptionsByService(
+		ctx, models.SumFilter{
+			UserID:     &uid,
+			AsOf:       &month,
+			ActiveOnly: true,
+		},
+	)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to sum subscriptions", slogx.Err(err))
+		return models.UserSummary{}, fmt.Errorf("%s: sum: %w", op, err)
+	}
+
+	byService := make(map[string]int64, len(costs))
+	for _, c := range costs {
+		byService[c.ServiceName] = c.Cost
+		summary.MonthlyCost += c.Cost
+	}
+
+	// Services come in start date order, so the earliest one wins a tie.
+	for _, service := range services {
+		cost := byService[service]
+		if cost > 0 && (summary.MostExpensive == nil || cost > summary.MostExpensive.Cost) {
+			summary.MostExpensive = &models.ServiceCost{ServiceName: service, Cost: cost}
+		}
+	}
+
+	return summary, nil
+}
+
+// CancelAll ends every open-ended subscription of userID with month, the current month by
+// default. A subscription that has not started by then ends in its first month. It returns
+// the end month and the ids of the cancelled subscriptions.
+func (s *Service) CancelAll(ctx context.Context, userID uuid.UUID, month *time.Time) (time.Time, []uuid.UUID, error) {
+	const op = "services.subscriptions.CancelAll"
+	log := slogx.FromContext(ctx).With(
+		slog.String("op", op),
+		slog.String("user_id", userID.String()),
+	)
+
+	end := models.MonthOf(time.Now())
+	if month != nil {
+		if models.MonthOf(*month).Before(end) {
+			return time.Time{}, nil, fmt.Errorf("%w: end_date cannot be before the current month", ErrInvalidDateRange)
+		}
+		end = models.MonthOf(*month)
+	}
+
+	ids, err := s.storage.CancelUserSubscriptions(ctx, userID, end)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to cancel subscriptions", slogx.Err(err))
+		return time.Time{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "subscriptions cancelled", slog.Int("count", len(ids)))
+	return end, ids, nil
+}
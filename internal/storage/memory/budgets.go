@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/storage"
+)
+
+// SaveBudget implementation of the budget Saver interface.
+func (s *Storage) SaveBudget(_ context.Context, b models.Budget) (models.Budget, error) {
+	const op = "storage.memory.SaveBudget"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b = cloneBudget(b)
+
+	// Same key as the budgets_unique_user_service index.
+	for _, other := range s.budgets {
+		if other.UserID == b.UserID && serviceKey(other.ServiceName) == serviceKey(b.ServiceName) {
+			return models.Budget{}, fmt.Errorf("%s: %w", op, storage.ErrBudgetExists)
+		}
+	}
+
+	b.ID = uuid.New()
+	b.CreatedAt = s.now()
+	b.UpdatedAt = b.CreatedAt
+
+	s.budgets[b.ID] = b
+
+	return cloneBudget(b), nil
+}
+
+// BudgetByID implementation of the budget Getter interface.
+func (s *Storage) BudgetByID(_ context.Context, id uuid.UUID) (models.Budget, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	b, ok := s.budgets[id]
+	if !ok {
+		return models.Budget{}, storage.ErrBudgetNotFound
+	}
+
+	return cloneBudget(b), nil
+}
+
+// BudgetsByUser implementation of the budget Lister interface.
+func (s *Storage) BudgetsByUser(_ context.Context, userID uuid.UUID) ([]models.Budget, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var budgets []models.Budget
+	for _, b := range s.budgets {
+		if b.UserID == userID {
+			budgets = append(budgets, cloneBudget(b))
+		}
+	}
+
+	// The overall budget first, then by service, like ORDER BY service_name NULLS FIRST.
+	sort.Slice(budgets, func(i, j int) bool {
+		if budgets[i].ServiceName == nil || budgets[j].ServiceName == nil {
+			return budgets[i].ServiceName == nil && budgets[j].ServiceName != nil
+		}
+		return *budgets[i].ServiceName < *budgets[j].ServiceName
+	})
+
+	return budgets, nil
+}
+
+// UpdateBudget implementation of the budget Updater interface.
+func (s *Storage) UpdateBudget(_ context.Context, id uuid.UUID, amount int64) (models.Budget, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.budgets[id]
+	if !ok {
+		return models.Budget{}, storage.ErrBudgetNotFound
+	}
+
+	b.Amount = amount
+	b.UpdatedAt = s.now()
+	s.budgets[id] = b
+
+	return cloneBudget(b), nil
+}
+
+// DeleteBudget implementation of the budget Deleter interface.
+func (s *Storage) DeleteBudget(_ context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.budgets[id]; !ok {
+		return storage.ErrBudgetNotFound
+	}
+
+	delete(s.budgets, id)
+	delete(s.alerts, id)
+
+	return nil
+}
+
+// MarkBudgetAlert implementation of the budget AlertMarker interface.
+func (s *Storage) MarkBudgetAlert(_ context.Context, budgetID uuid.UUID, month time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The foreign key of budget_alerts.
+	if _, ok := s.budgets[budgetID]; !ok {
+		return false, storage.ErrBudgetNotFound
+	}
+
+	key := month.Format(time.DateOnly)
+
+	months, ok := s.alerts[budgetID]
+	if !ok {
+		months = make(map[string]struct{})
+		s.alerts[budgetID] = months
+	}
+
+	if _, ok := months[key]; ok {
+		return false, nil
+	}
+	months[key] = struct{}{}
+
+	return true, nil
+}
+
+// UnmarkBudgetAlert implementation of the budget AlertMarker interface.
+func (s *Storage) UnmarkBudgetAlert(_ context.Context, budgetID uuid.UUID, month time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.alerts[budgetID], month.Format(time.DateOnly))
+
+	return nil
+}
+
+func serviceKey(name *string) string {
+	if name == nil {
+		return ""
+	}
+
+	return *name
+}
+
+func cloneBudget(b models.Budget) models.Budget {
+	if b.ServiceName != nil {
+		n := *b.ServiceName
+		b.ServiceName = &n
+	}
+
+	return b
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+)
+
+// EraseUser implementation of the privacy Eraser interface.
+func (s *Storage) EraseUser(_ context.Context, userID uuid.UUID) (models.Erasure, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var subs, budgets int
+
+	for id, sub := range s.subs {
+		if sub.UserID == userID {
+			delete(s.subs, id)
+			delete(s.prices, id)
+			delete(s.pauses, id)
+			subs++
+		}
+	}
+
+	for id, b := range s.budgets {
+		if b.UserID == userID {
+			delete(s.budgets, id)
+			delete(s.alerts, id)
+			budgets++
+		}
+	}
+
+	var prev models.Erasure
+	if n := len(s.erasures); n > 0 {
+		prev = s.erasures[n-1]
+	}
+
+	erasure := models.NewErasure(prev, userID, subs, budgets, s.now())
+	s.erasures = append(s.erasures, erasure)
+
+	return erasure, nil
+}
+
+// Erasures implementation of the privacy ErasureLister interface.
+func (s *Storage) Erasures(_ context.Context) ([]models.Erasure, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]models.Erasure, len(s.erasures))
+	copy(res, s.erasures)
+
+	return res, nil
+}
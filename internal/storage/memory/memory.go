@@ -0,0 +1,658 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+
+	"github.com/salivare/subscriptions-service/internal/storage"
+)
+
+// Storage keeps subscriptions in process memory. It mirrors the semantics of
+// postgres.Storage and is meant for demos and tests.
+type Storage struct {
+	mu     sync.RWMutex
+	subs   map[uuid.UUID]models.Subscription
+	prices map[uuid.UUID][]models.PriceChange
+	pauses map[uuid.UUID][]models.Pause
+	// budgets share mu with subscriptions.
+	budgets map[uuid.UUID]models.Budget
+	// alerts holds the reported months of every budget, as dates.
+	alerts map[uuid.UUID]map[string]struct{}
+	// erasures is the append-only erasure log, oldest first.
+	erasures []models.Erasure
+	now      func() time.Time
+}
+
+// New Storage constructor.
+func New() *Storage {
+	return &Storage{
+		subs:    make(map[uuid.UUID]models.Subscription),
+		prices:  make(map[uuid.UUID][]models.PriceChange),
+		pauses:  make(map[uuid.UUID][]models.Pause),
+		budgets: make(map[uuid.UUID]models.Budget),
+		alerts:  make(map[uuid.UUID]map[string]struct{}),
+		now: func() time.Time {
+			return time.Now().UTC().Truncate(time.Microsecond)
+		},
+	}
+}
+
+// Close is a no-op kept for parity with postgres.Storage.
+func (s *Storage) Close() {}
+
+// SaveSubscription implementation of the Saver interface.
+func (s *Storage) SaveSubscription(ctx context.Context, sub models.Subscription) (uuid.UUID, time.Time, error) {
+	const op = "storage.memory.SaveSubscription"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub = normalize(sub)
+
+	// The CHECK constraints of the SQL backends.
+	if err := sub.Validate(); err != nil {
+		log.WarnContext(ctx, "subscription violates a check constraint", slogx.Err(err))
+		return uuid.Nil, time.Time{}, fmt.Errorf("%s: %w: %w", op, storage.ErrCheckViolation, err)
+	}
+
+	if s.conflicts(sub) {
+		log.WarnContext(ctx, "subscription already exists")
+		return uuid.Nil, time.Time{}, fmt.Errorf("%s: %w", op, storage.ErrSubscriptionExists)
+	}
+
+	if conflicting, ok := s.overlapping(sub); ok {
+		log.WarnContext(ctx, "subscription overlaps another one")
+		return uuid.Nil, time.Time{}, fmt.Errorf("%s: %w", op, &storage.OverlapError{ConflictingID: conflicting})
+	}
+
+	sub.ID = uuid.New()
+	sub.CreatedAt = s.now()
+	sub.UpdatedAt = sub.CreatedAt
+
+	s.subs[sub.ID] = sub
+
+	return sub.ID, sub.CreatedAt, nil
+}
+
+// SubscriptionByID implementation of the Getter interface.
+func (s *Storage) SubscriptionByID(_ context.Context, id uuid.UUID) (models.Subscription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sub, ok := s.subs[id]
+	if !ok {
+		return models.Subscription{}, storage.ErrNotFound
+	}
+
+	return s.withPauses(sub), nil
+}
+
+// DeleteSubscription implementation of the Deleter interface.
+func (s *Storage) DeleteSubscription(_ context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subs[id]; !ok {
+		return storage.ErrNotFound
+	}
+
+	delete(s.subs, id)
+	delete(s.prices, id)
+	delete(s.pauses, id)
+
+	return nil
+}
+
+// UpdateSubscription implementation of the Updater interface.
+func (s *Storage) UpdateSubscription(ctx context.Context, sub models.Subscription) (models.Subscription, error) {
+	const op = "storage.memory.UpdateSubscription"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.subs[sub.ID]
+	if !ok {
+		log.WarnContext(ctx, "subscription does not exist")
+		return models.Subscription{}, storage.ErrNotFound
+	}
+
+	if !sub.UpdatedAt.IsZero() && !sub.UpdatedAt.Equal(current.UpdatedAt) {
+		log.WarnContext(ctx, "subscription was modified since it was read")
+		return models.Subscription{}, fmt.Errorf("%s: %w", op, storage.ErrModified)
+	}
+
+	sub = normalize(sub)
+
+	// The CHECK constraints of the SQL backends.
+	if err := sub.Validate(); err != nil {
+		log.WarnContext(ctx, "subscription violates a check constraint", slogx.Err(err))
+		return models.Subscription{}, fmt.Errorf("%s: %w: %w", op, storage.ErrCheckViolation, err)
+	}
+
+	if s.conflicts(sub) {
+		log.WarnContext(ctx, "subscription already exists")
+		return models.Subscription{}, fmt.Errorf("%s: %w", op, storage.ErrSubscriptionExists)
+	}
+
+	if conflicting, ok := s.overlapping(sub); ok {
+		log.WarnContext(ctx, "subscription overlaps another one")
+		return models.Subscription{}, fmt.Errorf("%s: %w", op, &storage.OverlapError{ConflictingID: conflicting})
+	}
+
+	sub.CreatedAt = current.CreatedAt
+	sub.UpdatedAt = s.touch(current.UpdatedAt)
+
+	s.subs[sub.ID] = sub
+
+	return s.withPauses(sub), nil
+}
+
+// UpsertSubscription implementation of the Upserter interface.
+func (s *Storage) UpsertSubscription(ctx context.Context, sub models.Subscription) (models.Subscription, bool, error) {
+	const op = "storage.memory.UpsertSubscription"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub = normalize(sub)
+
+	// The CHECK constraints of the SQL backends.
+	if err := sub.Validate(); err != nil {
+		log.WarnContext(ctx, "subscription violates a check constraint", slogx.Err(err))
+		return models.Subscription{}, false, fmt.Errorf("%s: %w: %w", op, storage.ErrCheckViolation, err)
+	}
+
+	if s.conflicts(sub) {
+		log.WarnContext(ctx, "subscription already exists")
+		return models.Subscription{}, false, fmt.Errorf("%s: %w", op, storage.ErrSubscriptionExists)
+	}
+
+	if conflicting, ok := s.overlapping(sub); ok {
+		log.WarnContext(ctx, "subscription overlaps another one")
+		return models.Subscription{}, false, fmt.Errorf("%s: %w", op, &storage.OverlapError{ConflictingID: conflicting})
+	}
+
+	current, exists := s.subs[sub.ID]
+
+	sub.UpdatedAt = s.now()
+	sub.CreatedAt = sub.UpdatedAt
+	if exists {
+		sub.CreatedAt = current.CreatedAt
+	}
+
+	s.subs[sub.ID] = sub
+
+	return s.withPauses(sub), !exists, nil
+}
+
+// TransferSubscription implementation of the Transferrer interface.
+func (s *Storage) TransferSubscription(ctx context.Context, ended, continuation models.Subscription) (uuid.UUID, error) {
+	const op = "storage.memory.TransferSubscription"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.subs[ended.ID]
+	if !ok {
+		log.WarnContext(ctx, "subscription does not exist")
+		return uuid.Nil, storage.ErrNotFound
+	}
+
+	shortened := current
+	shortened.EndDate = normalize(ended).EndDate
+	shortened.UpdatedAt = s.now()
+
+	if err := shortened.Validate(); err != nil {
+		log.WarnContext(ctx, "subscription violates a check constraint", slogx.Err(err))
+		return uuid.Nil, fmt.Errorf("%s: %w: %w", op, storage.ErrCheckViolation, err)
+	}
+
+	// Both writes are checked against each other, and rolled back together on failure.
+	s.subs[ended.ID] = shortened
+
+	next := normalize(continuation)
+	next.ID = uuid.New()
+
+	if err := next.Validate(); err != nil {
+		s.subs[ended.ID] = current
+		log.WarnContext(ctx, "subscription violates a check constraint", slogx.Err(err))
+		return uuid.Nil, fmt.Errorf("%s: %w: %w", op, storage.ErrCheckViolation, err)
+	}
+
+	if s.conflicts(next) {
+		s.subs[ended.ID] = current
+		log.WarnContext(ctx, "subscription already exists")
+		return uuid.Nil, fmt.Errorf("%s: %w", op, storage.ErrSubscriptionExists)
+	}
+
+	if conflicting, ok := s.overlapping(next); ok {
+		s.subs[ended.ID] = current
+		log.WarnContext(ctx, "subscription overlaps another one")
+		return uuid.Nil, fmt.Errorf("%s: %w", op, &storage.OverlapError{ConflictingID: conflicting})
+	}
+
+	next.CreatedAt = shortened.UpdatedAt
+	next.UpdatedAt = next.CreatedAt
+	s.subs[next.ID] = next
+
+	// Scheduled changes move with the subscription; one due in the transfer month is
+	// already part of the continuation's price.
+	var kept []models.PriceChange
+	for _, c := range s.prices[ended.ID] {
+		switch {
+		case c.EffectiveFrom.After(next.StartDate):
+			c.SubscriptionID = next.ID
+			s.prices[next.ID] = append(s.prices[next.ID], c)
+		case c.EffectiveFrom.Before(next.StartDate):
+			kept = append(kept, c)
+		}
+	}
+	s.prices[ended.ID] = kept
+
+	return next.ID, nil
+}
+
+// SumSubscriptions implementation of the Summer interface.
+func (s *Storage) SumSubscriptions(_ context.Context, f models.SumFilter) (int64, error) {
+	const op = "storage.memory.SumSubscriptions"
+
+	var userID *uuid.UUID
+	if f.UserID != nil {
+		id, err := uuid.Parse(*f.UserID)
+		if err != nil {
+			return 0, fmt.Errorf("%s: invalid user_id: %w", op, err)
+		}
+		userID = &id
+	}
+
+	asOf := toDate(f.AsOfOrCurrent())
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var total int64
+	for _, sub := range s.subs {
+		if matches(sub, userID, f) {
+			sub = s.withPauses(sub)
+			total += sub.PriceAt(sub.BillingMonth(asOf), s.prices[sub.ID])
+		}
+	}
+
+	return total, nil
+}
+
+// SumSubscriptionsByService implementation of the ServiceSummer interface.
+func (s *Storage) SumSubscriptionsByService(_ context.Context, f models.SumFilter) ([]models.ServiceCost, error) {
+	const op = "storage.memory.SumSubscriptionsByService"
+
+	var userID *uuid.UUID
+	if f.UserID != nil {
+		id, err := uuid.Parse(*f.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid user_id: %w", op, err)
+		}
+		userID = &id
+	}
+
+	asOf := toDate(f.AsOfOrCurrent())
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	totals := make(map[string]int64)
+	for _, sub := range s.subs {
+		if matches(sub, userID, f) {
+			sub = s.withPauses(sub)
+			totals[sub.ServiceName] += sub.PriceAt(sub.BillingMonth(asOf), s.prices[sub.ID])
+		}
+	}
+
+	var costs []models.ServiceCost
+	for name, cost := range totals {
+		costs = append(costs, models.ServiceCost{ServiceName: name, Cost: cost})
+	}
+
+	// Like ORDER BY service_name.
+	sort.Slice(costs, func(i, j int) bool { return costs[i].ServiceName < costs[j].ServiceName })
+
+	return costs, nil
+}
+
+// SubscriptionsWithTrialEnding implementation of the TrialLister interface.
+func (s *Storage) SubscriptionsWithTrialEnding(
+	_ context.Context,
+	month time.Time,
+	userID *uuid.UUID,
+) ([]models.Subscription, error) {
+	month = toDate(month)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var subs []models.Subscription
+	for _, sub := range s.subs {
+		if userID != nil && sub.UserID != *userID {
+			continue
+		}
+
+		end := sub.TrialEnd()
+		if end == nil || !end.Equal(month) {
+			continue
+		}
+
+		// A subscription cancelled before its trial ends never converts.
+		if sub.EndDate != nil && sub.EndDate.Before(month) {
+			continue
+		}
+
+		subs = append(subs, s.withPauses(sub))
+	}
+
+	sort.Slice(subs, func(i, j int) bool {
+		if !subs[i].CreatedAt.Equal(subs[j].CreatedAt) {
+			return subs[i].CreatedAt.Before(subs[j].CreatedAt)
+		}
+		return subs[i].ID.String() < subs[j].ID.String()
+	})
+
+	return subs, nil
+}
+
+// SubscriptionsByUser implementation of the UserLister interface.
+func (s *Storage) SubscriptionsByUser(_ context.Context, userID uuid.UUID) ([]models.Subscription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var subs []models.Subscription
+	for _, sub := range s.subs {
+		if sub.UserID == userID {
+			subs = append(subs, s.withPauses(sub))
+		}
+	}
+
+	sort.Slice(subs, func(i, j int) bool {
+		if !subs[i].StartDate.Equal(subs[j].StartDate) {
+			return subs[i].StartDate.Before(subs[j].StartDate)
+		}
+		if subs[i].ServiceName != subs[j].ServiceName {
+			return subs[i].ServiceName < subs[j].ServiceName
+		}
+		return subs[i].ID.String() < subs[j].ID.String()
+	})
+
+	return subs, nil
+}
+
+// CancelUserSubscriptions implementation of the Canceller interface.
+func (s *Storage) CancelUserSubscriptions(_ context.Context, userID uuid.UUID, month time.Time) ([]uuid.UUID, error) {
+	month = toDate(month)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	var ids []uuid.UUID
+	for id, sub := range s.subs {
+		if sub.UserID != userID || sub.EndDate != nil {
+			continue
+		}
+
+		// A subscription that starts after month ends in its first month.
+		end := month
+		if sub.StartDate.After(end) {
+			end = sub.StartDate
+		}
+
+		sub.EndDate = &end
+		sub.UpdatedAt = now
+		s.subs[id] = sub
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// SavePriceChange implementation of the PriceScheduler interface.
+func (s *Storage) SavePriceChange(_ context.Context, change models.PriceChange) (models.PriceChange, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subs[change.SubscriptionID]; !ok {
+		return models.PriceChange{}, storage.ErrNotFound
+	}
+
+	change.EffectiveFrom = toDate(change.EffectiveFrom)
+	change.CreatedAt = s.now()
+
+	changes := s.prices[change.SubscriptionID]
+	for i, c := range changes {
+		if c.EffectiveFrom.Equal(change.EffectiveFrom) {
+			changes = append(changes[:i], changes[i+1:]...)
+			break
+		}
+	}
+
+	s.prices[change.SubscriptionID] = append(changes, change)
+
+	return change, nil
+}
+
+// PriceChanges implementation of the PriceLister interface.
+func (s *Storage) PriceChanges(_ context.Context, subscriptionID uuid.UUID) ([]models.PriceChange, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	changes := append([]models.PriceChange(nil), s.prices[subscriptionID]...)
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].EffectiveFrom.Before(changes[j].EffectiveFrom)
+	})
+
+	return changes, nil
+}
+
+// PauseSubscription implementation of the Pauser interface.
+func (s *Storage) PauseSubscription(_ context.Context, pause models.Pause) (models.Pause, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subs[pause.SubscriptionID]; !ok {
+		return models.Pause{}, storage.ErrNotFound
+	}
+
+	for _, p := range s.pauses[pause.SubscriptionID] {
+		if p.ResumedFrom == nil {
+			return models.Pause{}, storage.ErrAlreadyPaused
+		}
+	}
+
+	pause.PausedFrom = toDate(pause.PausedFrom)
+	pause.ResumedFrom = nil
+	pause.CreatedAt = s.now()
+
+	s.pauses[pause.SubscriptionID] = append(s.pauses[pause.SubscriptionID], pause)
+
+	return pause, nil
+}
+
+// ResumeSubscription implementation of the Pauser interface.
+func (s *Storage) ResumeSubscription(_ context.Context, id uuid.UUID, month time.Time) (models.Pause, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pauses := s.pauses[id]
+	for i := range pauses {
+		if pauses[i].ResumedFrom == nil {
+			resumed := toDate(month)
+			pauses[i].ResumedFrom = &resumed
+			return clonePause(pauses[i]), nil
+		}
+	}
+
+	return models.Pause{}, storage.ErrNotPaused
+}
+
+// touch returns the new updated_at of a row last updated at prev. It always moves forward,
+// so two writes within the same microsecond still leave different versions.
+func (s *Storage) touch(prev time.Time) time.Time {
+	now := s.now()
+	if !now.After(prev) {
+		now = prev.Add(time.Microsecond)
+	}
+	return now
+}
+
+// withPauses returns a copy of sub with its pause history, oldest first.
+func (s *Storage) withPauses(sub models.Subscription) models.Subscription {
+	sub = clone(sub)
+	sub.Pauses = nil
+
+	for _, p := range s.pauses[sub.ID] {
+		sub.Pauses = append(sub.Pauses, clonePause(p))
+	}
+
+	sort.Slice(sub.Pauses, func(i, j int) bool {
+		return sub.Pauses[i].PausedFrom.Before(sub.Pauses[j].PausedFrom)
+	})
+
+	return sub
+}
+
+// conflicts reports whether another subscription has the same (user_id, service_name, start_date),
+// the key of the subscriptions_unique_user_service_start index.
+func (s *Storage) conflicts(sub models.Subscription) bool {
+	for id, other := range s.subs {
+		if id == sub.ID {
+			continue
+		}
+
+		if other.UserID == sub.UserID &&
+			other.ServiceName == sub.ServiceName &&
+			other.StartDate.Equal(sub.StartDate) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// overlapping returns the earliest subscription of the same user and service whose period
+// overlaps sub, mirroring the subscriptions_no_overlap constraint. Subscriptions flagged
+// with AllowOverlap are not checked.
+func (s *Storage) overlapping(sub models.Subscription) (uuid.UUID, bool) {
+	if sub.AllowOverlap {
+		return uuid.Nil, false
+	}
+
+	var found *models.Subscription
+
+	for id, other := range s.subs {
+		if id == sub.ID || other.AllowOverlap {
+			continue
+		}
+
+		if other.UserID != sub.UserID || other.ServiceName != sub.ServiceName || !other.Overlaps(sub) {
+			continue
+		}
+
+		if found == nil || other.StartDate.Before(found.StartDate) {
+			found = &other
+		}
+	}
+
+	if found == nil {
+		return uuid.Nil, false
+	}
+
+	return found.ID, true
+}
+
+// matches applies the filter the same way the SQL WHERE clause does:
+// a NULL end_date never satisfies an end_date condition.
+func matches(sub models.Subscription, userID *uuid.UUID, f models.SumFilter) bool {
+	if userID != nil && sub.UserID != *userID {
+		return false
+	}
+
+	if f.ServiceName != nil && sub.ServiceName != *f.ServiceName {
+		return false
+	}
+
+	if f.StartDateFrom != nil && sub.StartDate.Before(*f.StartDateFrom) {
+		return false
+	}
+
+	if f.StartDateTo != nil && sub.StartDate.After(*f.StartDateTo) {
+		return false
+	}
+
+	if f.EndDateFrom != nil && (sub.EndDate == nil || sub.EndDate.Before(*f.EndDateFrom)) {
+		return false
+	}
+
+	if f.EndDateTo != nil && (sub.EndDate == nil || sub.EndDate.After(*f.EndDateTo)) {
+		return false
+	}
+
+	if f.ActiveOnly && !sub.ActiveIn(toDate(f.AsOfOrCurrent())) {
+		return false
+	}
+
+	return true
+}
+
+// normalize truncates dates to whole days in UTC like the DATE columns do and detaches pointers from the caller.
+func normalize(sub models.Subscription) models.Subscription {
+	sub = clone(sub)
+	sub.StartDate = toDate(sub.StartDate)
+	// Pauses are kept apart, like the subscription_pauses table.
+	sub.Pauses = nil
+
+	if sub.EndDate != nil {
+		d := toDate(*sub.EndDate)
+		sub.EndDate = &d
+	}
+
+	return sub
+}
+
+func toDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func clone(sub models.Subscription) models.Subscription {
+	if sub.Price != nil {
+		p := *sub.Price
+		sub.Price = &p
+	}
+
+	if sub.EndDate != nil {
+		e := *sub.EndDate
+		sub.EndDate = &e
+	}
+
+	return sub
+}
+
+func clonePause(p models.Pause) models.Pause {
+	if p.ResumedFrom != nil {
+		r := *p.ResumedFrom
+		p.ResumedFrom = &r
+	}
+
+	return p
+}
@@ -0,0 +1,16 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/salivare/subscriptions-service/internal/storage/memory"
+	"github.com/salivare/subscriptions-service/internal/storage/storagetest"
+)
+
+func TestConformance(t *testing.T) {
+	storagetest.Run(
+		t, func(t *testing.T) storagetest.Storage {
+			return memory.New()
+		},
+	)
+}
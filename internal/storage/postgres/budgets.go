@@ -0,0 +1,238 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/salivare-io/slogx"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/storage"
+)
+
+// SaveBudget implementation of the budget Saver interface.
+func (s *Storage) SaveBudget(ctx context.Context, b models.Budget) (models.Budget, error) {
+	const op = "storage.postgres.SaveBudget"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        INSERT INTO budgets (user_id, service_name, amount)
+        VALUES ($1, $2, $3)
+        RETURNING ` + budgetColumns + `;
+    `
+
+	var saved models.Budget
+
+	err := s.write(ctx, func(tx pgx.Tx) error {
+		var err error
+		saved, err = scanBudget(tx.QueryRow(ctx, query, b.UserID, b.ServiceName, b.Amount))
+		return err
+	})
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && pgErr.Code == PGErrUniqueViolation {
+			log.WarnContext(ctx, "budget already exists", slogx.Err(err))
+			return models.Budget{}, fmt.Errorf("%s: %w", op, storage.ErrBudgetExists)
+		}
+
+		log.ErrorContext(ctx, "failed to save budget", slogx.Err(err))
+		return models.Budget{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saved, nil
+}
+
+// BudgetByID implementation of the budget Getter interface.
+func (s *Storage) BudgetByID(ctx context.Context, id uuid.UUID) (models.Budget, error) {
+	const op = "storage.postgres.BudgetByID"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        SELECT ` + budgetColumns + `
+        FROM budgets
+        WHERE id = $1
+    `
+
+	var b models.Budget
+
+	err := s.read(ctx, func(tx pgx.Tx) error {
+		var err error
+		b, err = scanBudget(tx.QueryRow(ctx, query, id))
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Budget{}, storage.ErrBudgetNotFound
+		}
+
+		log.ErrorContext(ctx, "failed to get budget", slogx.Err(err))
+		return models.Budget{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return b, nil
+}
+
+// BudgetsByUser implementation of the budget Lister interface.
+func (s *Storage) BudgetsByUser(ctx context.Context, userID uuid.UUID) ([]models.Budget, error) {
+	const op = "storage.postgres.BudgetsByUser"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        SELECT ` + budgetColumns + `
+        FROM budgets
+        WHERE user_id = $1
+        ORDER BY service_name NULLS FIRST
+    `
+
+	var budgets []models.Budget
+
+	err := s.read(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, query, userID)
+		if err != nil {
+			return err
+		}
+
+		budgets, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Budget, error) {
+			return scanBudget(row)
+		})
+		return err
+	})
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list budgets", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return budgets, nil
+}
+
+// UpdateBudget implementation of the budget Updater interface.
+func (s *Storage) UpdateBudget(ctx context.Context, id uuid.UUID, amount int64) (models.Budget, error) {
+	const op = "storage.postgres.UpdateBudget"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        UPDATE budgets
+        SET amount = $1, updated_at = NOW()
+        WHERE id = $2
+        RETURNING ` + budgetColumns + `;
+    `
+
+	var b models.Budget
+
+	err := s.write(ctx, func(tx pgx.Tx) error {
+		var err error
+		b, err = scanBudget(tx.QueryRow(ctx, query, amount, id))
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.WarnContext(ctx, "budget does not exist", slogx.Err(err))
+			return models.Budget{}, storage.ErrBudgetNotFound
+		}
+
+		log.ErrorContext(ctx, "failed to update budget", slogx.Err(err))
+		return models.Budget{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return b, nil
+}
+
+// DeleteBudget implementation of the budget Deleter interface.
+func (s *Storage) DeleteBudget(ctx context.Context, id uuid.UUID) error {
+	const op = "storage.postgres.DeleteBudget"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	var cmd pgconn.CommandTag
+
+	err := s.write(ctx, func(tx pgx.Tx) error {
+		var err error
+		cmd, err = tx.Exec(ctx, `DELETE FROM budgets WHERE id = $1`, id)
+		return err
+	})
+	if err != nil {
+		log.ErrorContext(ctx, "failed to delete budget", slogx.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return storage.ErrBudgetNotFound
+	}
+
+	return nil
+}
+
+// MarkBudgetAlert implementation of the budget AlertMarker interface.
+func (s *Storage) MarkBudgetAlert(ctx context.Context, budgetID uuid.UUID, month time.Time) (bool, error) {
+	const op = "storage.postgres.MarkBudgetAlert"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        INSERT INTO budget_alerts (budget_id, month)
+        VALUES ($1, $2)
+        ON CONFLICT (budget_id, month) DO NOTHING
+    `
+
+	var cmd pgconn.CommandTag
+
+	err := s.write(ctx, func(tx pgx.Tx) error {
+		var err error
+		cmd, err = tx.Exec(ctx, query, budgetID, month)
+		return err
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && pgErr.Code == PGErrForeignKeyViolation {
+			return false, storage.ErrBudgetNotFound
+		}
+
+		log.ErrorContext(ctx, "failed to mark budget alert", slogx.Err(err))
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return cmd.RowsAffected() == 1, nil
+}
+
+// UnmarkBudgetAlert implementation of the budget AlertMarker interface.
+func (s *Storage) UnmarkBudgetAlert(ctx context.Context, budgetID uuid.UUID, month time.Time) error {
+	const op = "storage.postgres.UnmarkBudgetAlert"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	err := s.write(ctx, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `DELETE FROM budget_alerts WHERE budget_id = $1 AND month = $2`, budgetID, month)
+		return err
+	})
+	if err != nil {
+		log.ErrorContext(ctx, "failed to unmark budget alert", slogx.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+const budgetColumns = `id, user_id, service_name, amount, created_at, updated_at`
+
+func scanBudget(row pgx.Row) (models.Budget, error) {
+	var b models.Budget
+
+	err := row.Scan(
+		&b.ID,
+		&b.UserID,
+		&b.ServiceName,
+		&b.Amount,
+		&b.CreatedAt,
+		&b.UpdatedAt,
+	)
+
+	return b, err
+}
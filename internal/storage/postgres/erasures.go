@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/salivare-io/slogx"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+)
+
+// EraseUser implementation of the privacy Eraser interface. Price changes and pauses go
+// with their subscriptions through ON DELETE CASCADE. Every tenant has its own chain.
+func (s *Storage) EraseUser(ctx context.Context, userID uuid.UUID) (models.Erasure, error) {
+	const op = "storage.postgres.EraseUser"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	var erasure models.Erasure
+
+	err := s.write(ctx, func(tx pgx.Tx) error {
+		// Concurrent erasures would both link to the same predecessor and fork the chain.
+		if _, err := tx.Exec(ctx, `LOCK TABLE erasures IN EXCLUSIVE MODE`); err != nil {
+			return err
+		}
+
+		var prev models.Erasure
+		err := tx.QueryRow(ctx, `SELECT seq, hash FROM erasures ORDER BY seq DESC LIMIT 1`).Scan(&prev.Seq, &prev.Hash)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		subs, err := tx.Exec(ctx, `DELETE FROM subscriptions WHERE user_id = $1`, userID)
+		if err != nil {
+			return err
+		}
+
+		budgets, err := tx.Exec(ctx, `DELETE FROM budgets WHERE user_id = $1`, userID)
+		if err != nil {
+			return err
+		}
+
+		erasure = models.NewErasure(
+			prev,
+			userID,
+			int(subs.RowsAffected()),
+			int(budgets.RowsAffected()),
+			time.Now().UTC().Truncate(time.Microsecond),
+		)
+
+		_, err = tx.Exec(
+			ctx,
+			`INSERT INTO erasures (seq, user_hash, subscriptions, budgets, erased_at, prev_hash, hash)
+             VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			erasure.Seq,
+			erasure.UserHash,
+			erasure.Subscriptions,
+			erasure.Budgets,
+			erasure.ErasedAt,
+			erasure.PrevHash,
+			erasure.Hash,
+		)
+
+		return err
+	})
+	if err != nil {
+		log.ErrorContext(ctx, "failed to erase user", slogx.Err(err))
+		return models.Erasure{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return erasure, nil
+}
+
+// Erasures implementation of the privacy ErasureLister interface.
+func (s *Storage) Erasures(ctx context.Context) ([]models.Erasure, error) {
+	const op = "storage.postgres.Erasures"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	var res []models.Erasure
+
+	err := s.read(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(
+			ctx,
+			`SELECT seq, user_hash, subscriptions, budgets, erased_at, prev_hash, hash FROM erasures ORDER BY seq`,
+		)
+		if err != nil {
+			return err
+		}
+
+		res, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Erasure, error) {
+			var e models.Erasure
+			err := row.Scan(&e.Seq, &e.UserHash, &e.Subscriptions, &e.Budgets, &e.ErasedAt, &e.PrevHash, &e.Hash)
+			e.ErasedAt = e.ErasedAt.UTC()
+			return e, err
+		})
+		return err
+	})
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list erasures", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, nil
+}
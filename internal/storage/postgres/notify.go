@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/salivare-io/slogx"
+)
+
+const listenRetryDelay = time.Second
+
+// Notify sends payload to every session listening on channel.
+func (s *Storage) Notify(ctx context.Context, channel, payload string) error {
+	const op = "storage.postgres.Notify"
+
+	if _, err := s.primary().Exec(ctx, `SELECT pg_notify($1, $2)`, channel, payload); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Listen calls fn for every notification on channel until ctx is cancelled.
+// LISTEN runs on a dedicated connection outside the pool, so pool resets do not
+// interrupt it; it is re-established if it breaks and notifications sent meanwhile are lost.
+// onListen is called after every successful LISTEN, the first one included, so the caller
+// can drop whatever those lost notifications would have changed.
+func (s *Storage) Listen(ctx context.Context, channel string, onListen func(), fn func(payload string)) error {
+	log := slogx.FromContext(ctx).With(slog.String("op", "storage.postgres.Listen"))
+
+	for {
+		err := s.listen(ctx, channel, onListen, fn)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		log.WarnContext(ctx, "listen connection lost, reconnecting", slogx.Err(err))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+func (s *Storage) listen(ctx context.Context, channel string, onListen func(), fn func(payload string)) error {
+	const op = "storage.postgres.listen"
+
+	conn, err := pgx.ConnectConfig(ctx, s.primary().Config().ConnConfig)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	onListen()
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		fn(n.Payload)
+	}
+}
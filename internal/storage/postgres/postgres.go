@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,37 +21,35 @@ import (
 )
 
 const (
-	PGErrUniqueViolation = "23505"
+	PGErrUniqueViolation     = "23505"
+	PGErrForeignKeyViolation = "23503"
+	PGErrExclusionViolation  = "23P01"
+	PGErrCheckViolation      = "23514"
+	// PGErrRLSViolation is raised when a write touches a row of another tenant.
+	PGErrRLSViolation = "42501"
 )
 
+// poolResetTimeout bounds connecting a replacement pool on ResetPool.
+const poolResetTimeout = 10 * time.Second
+
 type Storage struct {
-	pool *pgxpool.Pool
+	dsn      string
+	pool     atomic.Pointer[pgxpool.Pool]
+	replicas atomic.Pointer[replicaSet]
 }
 
 // New Storage constructor.
 func New(cfg config.PostgresConfig) (*Storage, error) {
 	const op = "storage.postgres.New"
 
-	dsn := fmt.Sprintf(
-		"postgres://%s:%s@%s:%d/%s?sslmode=%s",
-		cfg.User,
-		cfg.Password,
-		cfg.Host,
-		cfg.Port,
-		cfg.DBName,
-		cfg.SSLMode,
-	)
+	dsn := cfg.DSN()
 
 	config, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to parse DSN: %w", op, err)
 	}
 
-	config.MaxConns = int32(cfg.MaxConns)
-	config.MinConns = int32(cfg.MinConns)
-	config.MaxConnIdleTime = cfg.MaxConnIdleTime
-	config.MaxConnLifetime = cfg.MaxConnLifetime
-	config.HealthCheckPeriod = cfg.HealthCheckPeriod
+	applyPoolConfig(config, cfg)
 
 	var pool *pgxpool.Pool
 
@@ -68,7 +67,93 @@ func New(cfg config.PostgresConfig) (*Storage, error) {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &Storage{pool: pool}, nil
+	replicas, err := newReplicaSet(cfg)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s := &Storage{dsn: dsn}
+	s.pool.Store(pool)
+	s.replicas.Store(replicas)
+
+	return s, nil
+}
+
+func applyPoolConfig(config *pgxpool.Config, cfg config.PostgresConfig) {
+	config.MaxConns = int32(cfg.MaxConns)
+	config.MinConns = int32(cfg.MinConns)
+	config.MaxConnIdleTime = cfg.MaxConnIdleTime
+	config.MaxConnLifetime = cfg.MaxConnLifetime
+	config.HealthCheckPeriod = cfg.HealthCheckPeriod
+}
+
+// ResetPool replaces the primary and replica pools with ones built from the pool
+// settings and replica DSNs of cfg. The connection settings of the primary are kept.
+// Queries in flight finish on the old pools, which are closed in the background.
+func (s *Storage) ResetPool(cfg config.PostgresConfig) error {
+	const op = "storage.postgres.ResetPool"
+
+	poolCfg, err := pgxpool.ParseConfig(s.dsn)
+	if err != nil {
+		return fmt.Errorf("%s: failed to parse DSN: %w", op, err)
+	}
+
+	applyPoolConfig(poolCfg, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), poolResetTimeout)
+	defer cancel()
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	replicas, err := newReplicaSet(cfg)
+	if err != nil {
+		pool.Close()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	oldPool := s.pool.Swap(pool)
+	oldReplicas := s.replicas.Swap(replicas)
+
+	go func() {
+		oldReplicas.close()
+		oldPool.Close()
+	}()
+
+	return nil
+}
+
+// Close releases the primary and replica pools.
+func (s *Storage) Close() {
+	s.replicas.Load().close()
+	s.pool.Load().Close()
+}
+
+// primary returns the pool for writes.
+func (s *Storage) primary() *pgxpool.Pool {
+	return s.pool.Load()
+}
+
+// reader returns the pool for read-only queries: a healthy replica unless ctx
+// asks for the primary or no replica is available.
+func (s *Storage) reader(ctx context.Context) *pgxpool.Pool {
+	if storage.UsePrimary(ctx) {
+		return s.primary()
+	}
+
+	if pool := s.replicas.Load().pick(); pool != nil {
+		return pool
+	}
+
+	return s.primary()
 }
 
 // SaveSubscription implementation of the Saver interface.
@@ -82,8 +167,11 @@ func (s *Storage) SaveSubscription(ctx context.Context, sub models.Subscription)
             price,
             user_id,
             start_date,
-            end_date
-        ) VALUES ($1, $2, $3, $4, $5)
+            end_date,
+            trial_months,
+            trial_price,
+            allow_overlap
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
         RETURNING id, created_at;
     `
 
@@ -92,15 +180,20 @@ func (s *Storage) SaveSubscription(ctx context.Context, sub models.Subscription)
 		createdAt time.Time
 	)
 
-	err := s.pool.QueryRow(
-		ctx,
-		query,
-		sub.ServiceName,
-		sub.Price,
-		sub.UserID,
-		sub.StartDate,
-		sub.EndDate,
-	).Scan(&id, &createdAt)
+	err := s.write(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(
+			ctx,
+			query,
+			sub.ServiceName,
+			sub.Price,
+			sub.UserID,
+			sub.StartDate,
+			sub.EndDate,
+			sub.TrialMonths,
+			sub.TrialPrice,
+			sub.AllowOverlap,
+		).Scan(&id, &createdAt)
+	})
 
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -110,6 +203,16 @@ func (s *Storage) SaveSubscription(ctx context.Context, sub models.Subscription)
 			return uuid.Nil, time.Time{}, fmt.Errorf("%s: %w", op, storage.ErrSubscriptionExists)
 		}
 
+		if errors.As(err, &pgErr) && pgErr.Code == PGErrExclusionViolation {
+			log.WarnContext(ctx, "subscription overlaps another one", slogx.Err(err))
+			return uuid.Nil, time.Time{}, fmt.Errorf("%s: %w", op, s.overlapError(ctx, sub))
+		}
+
+		if errors.As(err, &pgErr) && pgErr.Code == PGErrCheckViolation {
+			log.WarnContext(ctx, "subscription violates a check constraint", slogx.Err(err))
+			return uuid.Nil, time.Time{}, fmt.Errorf("%s: %w: %s", op, storage.ErrCheckViolation, pgErr.ConstraintName)
+		}
+
 		log.ErrorContext(ctx, "failed to save subscription", slogx.Err(err))
 		return uuid.Nil, time.Time{}, fmt.Errorf("%s: %w", op, err)
 	}
@@ -123,21 +226,21 @@ func (s *Storage) SubscriptionByID(ctx context.Context, id uuid.UUID) (models.Su
 	log := slogx.FromContext(ctx).With(slog.String("op", op))
 
 	query := `
-        SELECT id, service_name, price, user_id, start_date, end_date
+        SELECT ` + subscriptionColumns + `
         FROM subscriptions
         WHERE id = $1
     `
 
-	var sub models.Subscription
+	subs := make([]models.Subscription, 1)
 
-	err := s.pool.QueryRow(ctx, query, id).Scan(
-		&sub.ID,
-		&sub.ServiceName,
-		&sub.Price,
-		&sub.UserID,
-		&sub.StartDate,
-		&sub.EndDate,
-	)
+	err := s.read(ctx, func(tx pgx.Tx) error {
+		var err error
+		if subs[0], err = scanSubscription(tx.QueryRow(ctx, query, id)); err != nil {
+			return err
+		}
+
+		return attachPauses(ctx, tx, subs)
+	})
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -148,7 +251,7 @@ func (s *Storage) SubscriptionByID(ctx context.Context, id uuid.UUID) (models.Su
 		return models.Subscription{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return sub, nil
+	return subs[0], nil
 }
 
 // DeleteSubscription implementation of the Deleter interface.
@@ -158,7 +261,13 @@ func (s *Storage) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
 
 	query := `DELETE FROM subscriptions WHERE id = $1`
 
-	cmd, err := s.pool.Exec(ctx, query, id)
+	var cmd pgconn.CommandTag
+
+	err := s.write(ctx, func(tx pgx.Tx) error {
+		var err error
+		cmd, err = tx.Exec(ctx, query, id)
+		return err
+	})
 	if err != nil {
 		log.ErrorContext(ctx, "failed to delete subscription", slogx.Err(err))
 		return fmt.Errorf("%s: %w", op, err)
@@ -184,32 +293,44 @@ func (s *Storage) UpdateSubscription(ctx context.Context, sub models.Subscriptio
             price        = $2,
             user_id      = $3,
             start_date   = $4,
-            end_date     = $5
-        WHERE id = $6
-        RETURNING id, service_name, price, user_id, start_date, end_date, created_at, updated_at;
+            end_date     = $5,
+            trial_months = $6,
+            trial_price  = $7,
+            allow_overlap = $8,
+            updated_at   = NOW()
+        WHERE id = $9 AND ($10::timestamptz IS NULL OR updated_at = $10)
+        RETURNING ` + subscriptionColumns + `;
     `
 
-	var updated models.Subscription
-
-	err := s.pool.QueryRow(
-		ctx,
-		query,
-		sub.ServiceName,
-		sub.Price,
-		sub.UserID,
-		sub.StartDate,
-		sub.EndDate,
-		sub.ID,
-	).Scan(
-		&updated.ID,
-		&updated.ServiceName,
-		&updated.Price,
-		&updated.UserID,
-		&updated.StartDate,
-		&updated.EndDate,
-		&updated.CreatedAt,
-		&updated.UpdatedAt,
-	)
+	subs := make([]models.Subscription, 1)
+
+	err := s.write(ctx, func(tx pgx.Tx) error {
+		var err error
+		subs[0], err = scanSubscription(
+			tx.QueryRow(
+				ctx,
+				query,
+				sub.ServiceName,
+				sub.Price,
+				sub.UserID,
+				sub.StartDate,
+				sub.EndDate,
+				sub.TrialMonths,
+				sub.TrialPrice,
+				sub.AllowOverlap,
+				sub.ID,
+				version(sub),
+			),
+		)
+		if errors.Is(err, pgx.ErrNoRows) && !sub.UpdatedAt.IsZero() {
+			return modified(ctx, tx, sub.ID)
+		}
+		if err != nil {
+			return err
+		}
+
+		return attachPauses(ctx, tx, subs)
+	})
 
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -219,16 +340,255 @@ func (s *Storage) UpdateSubscription(ctx context.Context, sub models.Subscriptio
 			return models.Subscription{}, fmt.Errorf("%s: %w", op, storage.ErrSubscriptionExists)
 		}
 
+		if errors.As(err, &pgErr) && pgErr.Code == PGErrExclusionViolation {
+			log.WarnContext(ctx, "subscription overlaps another one", slogx.Err(err))
+			return models.Subscription{}, fmt.Errorf("%s: %w", op, s.overlapError(ctx, sub))
+		}
+
+		if errors.As(err, &pgErr) && pgErr.Code == PGErrCheckViolation {
+			log.WarnContext(ctx, "subscription violates a check constraint", slogx.Err(err))
+			return models.Subscription{}, fmt.Errorf("%s: %w: %s", op, storage.ErrCheckViolation, pgErr.ConstraintName)
+		}
+
 		if errors.Is(err, pgx.ErrNoRows) {
 			log.WarnContext(ctx, "subscription does not exist", slogx.Err(err))
 			return models.Subscription{}, storage.ErrNotFound
 		}
 
+		if errors.Is(err, storage.ErrModified) {
+			log.WarnContext(ctx, "subscription was modified since it was read", slogx.Err(err))
+			return models.Subscription{}, fmt.Errorf("%s: %w", op, err)
+		}
+
 		log.ErrorContext(ctx, "failed to update subscription", slogx.Err(err))
 		return models.Subscription{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return updated, nil
+	return subs[0], nil
+}
+
+// UpsertSubscription implementation of the Upserter interface. The row keeps its
+// created_at when it is replaced; created reports whether it was inserted.
+func (s *Storage) UpsertSubscription(ctx context.Context, sub models.Subscription) (models.Subscription, bool, error) {
+	const op = "storage.postgres.UpsertSubscription"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	// Only a conflict on id turns into an update; the (tenant, user, service, start_date)
+	// index and the overlap constraint still reject the row with their own errors, and
+	// the policy rejects an id taken by another tenant.
+	// xmax is 0 only for a freshly inserted row version.
+	query := `
+        INSERT INTO subscriptions (
+            id,
+            service_name,
+            price,
+            user_id,
+            start_date,
+            end_date,
+            trial_months,
+            trial_price,
+            allow_overlap
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        ON CONFLICT (id) DO UPDATE
+        SET
+            service_name  = EXCLUDED.service_name,
+            price         = EXCLUDED.price,
+            user_id       = EXCLUDED.user_id,
+            start_date    = EXCLUDED.start_date,
+            end_date      = EXCLUDED.end_date,
+            trial_months  = EXCLUDED.trial_months,
+            trial_price   = EXCLUDED.trial_price,
+            allow_overlap = EXCLUDED.allow_overlap,
+            updated_at    = NOW()
+        RETURNING ` + subscriptionColumns + `, xmax = 0;
+    `
+
+	var (
+		subs    = make([]models.Subscription, 1)
+		created bool
+	)
+
+	err := s.write(ctx, func(tx pgx.Tx) error {
+		err := tx.QueryRow(
+			ctx,
+			query,
+			sub.ID,
+			sub.ServiceName,
+			sub.Price,
+			sub.UserID,
+			sub.StartDate,
+			sub.EndDate,
+			sub.TrialMonths,
+			sub.TrialPrice,
+			sub.AllowOverlap,
+		).Scan(append(subscriptionDest(&subs[0]), &created)...)
+		if err != nil {
+			return err
+		}
+
+		return attachPauses(ctx, tx, subs)
+	})
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && (pgErr.Code == PGErrUniqueViolation || pgErr.Code == PGErrRLSViolation) {
+			log.WarnContext(ctx, "subscription already exists", slogx.Err(err))
+			return models.Subscription{}, false, fmt.Errorf("%s: %w", op, storage.ErrSubscriptionExists)
+		}
+
+		if errors.As(err, &pgErr) && pgErr.Code == PGErrExclusionViolation {
+			log.WarnContext(ctx, "subscription overlaps another one", slogx.Err(err))
+			return models.Subscription{}, false, fmt.Errorf("%s: %w", op, s.overlapError(ctx, sub))
+		}
+
+		if errors.As(err, &pgErr) && pgErr.Code == PGErrCheckViolation {
+			log.WarnContext(ctx, "subscription violates a check constraint", slogx.Err(err))
+			return models.Subscription{}, false, fmt.Errorf("%s: %w: %s", op, storage.ErrCheckViolation, pgErr.ConstraintName)
+		}
+
+		log.ErrorContext(ctx, "failed to upsert subscription", slogx.Err(err))
+		return models.Subscription{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return subs[0], created, nil
+}
+
+// TransferSubscription implementation of the Transferrer interface.
+func (s *Storage) TransferSubscription(ctx context.Context, ended, continuation models.Subscription) (uuid.UUID, error) {
+	const op = "storage.postgres.TransferSubscription"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	var id uuid.UUID
+
+	err := s.write(ctx, func(tx pgx.Tx) error {
+		cmd, err := tx.Exec(
+			ctx,
+			`UPDATE subscriptions SET end_date = $1, updated_at = NOW() WHERE id = $2`,
+			ended.EndDate,
+			ended.ID,
+		)
+		if err != nil {
+			return err
+		}
+
+		if cmd.RowsAffected() == 0 {
+			return storage.ErrNotFound
+		}
+
+		query := `
+            INSERT INTO subscriptions (
+                service_name,
+                price,
+                user_id,
+                start_date,
+                end_date,
+                trial_months,
+                trial_price,
+                allow_overlap
+            ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+            RETURNING id;
+        `
+
+		err = tx.QueryRow(
+			ctx,
+			query,
+			continuation.ServiceName,
+			continuation.Price,
+			continuation.UserID,
+			continuation.StartDate,
+			continuation.EndDate,
+			continuation.TrialMonths,
+			continuation.TrialPrice,
+			continuation.AllowOverlap,
+		).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		// Scheduled changes move with the subscription; one due in the transfer month is
+		// already part of the continuation's price.
+		_, err = tx.Exec(
+			ctx,
+			`UPDATE subscription_prices SET subscription_id = $1 WHERE subscription_id = $2 AND effective_from > $3`,
+			id,
+			ended.ID,
+			continuation.StartDate,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(
+			ctx,
+			`DELETE FROM subscription_prices WHERE subscription_id = $1 AND effective_from >= $2`,
+			ended.ID,
+			continuation.StartDate,
+		)
+
+		return err
+	})
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			log.WarnContext(ctx, "subscription does not exist")
+			return uuid.Nil, storage.ErrNotFound
+		case errors.As(err, &pgErr) && pgErr.Code == PGErrUniqueViolation:
+			log.WarnContext(ctx, "subscription already exists", slogx.Err(err))
+			return uuid.Nil, fmt.Errorf("%s: %w", op, storage.ErrSubscriptionExists)
+		case errors.As(err, &pgErr) && pgErr.Code == PGErrExclusionViolation:
+			log.WarnContext(ctx, "subscription overlaps another one", slogx.Err(err))
+			return uuid.Nil, fmt.Errorf("%s: %w", op, s.overlapError(ctx, continuation))
+		case errors.As(err, &pgErr) && pgErr.Code == PGErrCheckViolation:
+			log.WarnContext(ctx, "subscription violates a check constraint", slogx.Err(err))
+			return uuid.Nil, fmt.Errorf("%s: %w: %s", op, storage.ErrCheckViolation, pgErr.ConstraintName)
+		}
+
+		log.ErrorContext(ctx, "failed to transfer subscription", slogx.Err(err))
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// overlapError names the subscription that sub overlaps after subscriptions_no_overlap
+// rejected it. A conflict on the same start date is reported as a duplicate instead.
+// The failed write aborted its transaction, so the lookup runs in a new one.
+func (s *Storage) overlapError(ctx context.Context, sub models.Subscription) error {
+	query := `
+        SELECT id, start_date
+        FROM subscriptions
+        WHERE user_id = $1
+          AND service_name = $2
+          AND id <> $3
+          AND NOT allow_overlap
+          AND daterange(start_date, end_date, '[]') && daterange($4::date, $5::date, '[]')
+        ORDER BY start_date
+        LIMIT 1
+    `
+
+	var (
+		id    uuid.UUID
+		start time.Time
+	)
+
+	err := s.write(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx, query, sub.UserID, sub.ServiceName, sub.ID, sub.StartDate, sub.EndDate).
+			Scan(&id, &start)
+	})
+	if err != nil {
+		// The conflicting row is gone already, so only the rule itself can be reported.
+		return storage.ErrOverlap
+	}
+
+	if start.Equal(sub.StartDate) {
+		return storage.ErrSubscriptionExists
+	}
+
+	return &storage.OverlapError{ConflictingID: id}
 }
 
 // SumSubscriptions implementation of the Summer interface.
@@ -236,6 +596,85 @@ func (s *Storage) SumSubscriptions(ctx context.Context, f models.SumFilter) (int
 	const op = "storage.postgres.SumSubscriptions"
 	log := slogx.FromContext(ctx).With(slog.String("op", op))
 
+	from, args := sumSource(f)
+	query := `SELECT COALESCE(SUM(` + billedPrice + `), 0) FROM ` + from
+
+	var total int64
+
+	err := s.read(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx, query, args...).Scan(&total)
+	})
+	if err != nil {
+		log.ErrorContext(ctx, "failed to sum subscriptions", slogx.Err(err))
+		return 0, fmt.Errorf("failed to execute sum query: %w", err)
+	}
+
+	return total, nil
+}
+
+// SumSubscriptionsByService implementation of the ServiceSummer interface.
+func (s *Storage) SumSubscriptionsByService(ctx context.Context, f models.SumFilter) ([]models.ServiceCost, error) {
+	const op = "storage.postgres.SumSubscriptionsByService"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	from, args := sumSource(f)
+	query := `
+        SELECT b.service_name, SUM(` + billedPrice + `)
+        FROM ` + from + `
+        GROUP BY b.service_name
+        ORDER BY b.service_name
+    `
+
+	var costs []models.ServiceCost
+
+	err := s.read(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+
+		costs, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.ServiceCost, error) {
+			var c models.ServiceCost
+			err := row.Scan(&c.ServiceName, &c.Cost)
+			return c, err
+		})
+		return err
+	})
+	if err != nil {
+		log.ErrorContext(ctx, "failed to sum subscriptions by service", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return costs, nil
+}
+
+// billedPrice is what a subscription of the sumSource row b is billed in its billing month:
+// nothing while paused, else the trial price, else the latest price change in effect,
+// else the base price.
+const billedPrice = `
+            CASE
+                WHEN EXISTS (
+                    SELECT 1
+                    FROM subscription_pauses ps
+                    WHERE ps.subscription_id = b.id
+                      AND ps.paused_from <= b.billing_month
+                      AND (ps.resumed_from IS NULL OR ps.resumed_from > b.billing_month)
+                )
+                THEN 0
+                WHEN b.billing_month < (b.start_date + make_interval(months => b.trial_months))::date
+                THEN b.trial_price
+                ELSE COALESCE((
+                    SELECT p.price
+                    FROM subscription_prices p
+                    WHERE p.subscription_id = b.id AND p.effective_from <= b.billing_month
+                    ORDER BY p.effective_from DESC
+                    LIMIT 1
+                ), b.price)
+            END`
+
+// sumSource returns the subquery b of the subscriptions matching f, each with its
+// billing_month, and its arguments.
+func sumSource(f models.SumFilter) (string, []any) {
 	var (
 		conditions []string
 		args       []any
@@ -272,20 +711,387 @@ func (s *Storage) SumSubscriptions(ctx context.Context, f models.SumFilter) (int
 		add("end_date <= $%d", *f.EndDateTo)
 	}
 
+	if f.ActiveOnly {
+		add("start_date <= $%d", f.AsOfOrCurrent())
+		add("(end_date IS NULL OR end_date >= $%d)", f.AsOfOrCurrent())
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// billing_month clamps as_of to [start_date, end_date], see models.Subscription.BillingMonth.
+	args = append(args, f.AsOfOrCurrent())
+
+	from := fmt.Sprintf(`(
+            SELECT
+                id, service_name, price, start_date, trial_months, trial_price,
+                GREATEST(start_date, LEAST($%[1]d::date, COALESCE(end_date, $%[1]d::date))) AS billing_month
+            FROM subscriptions
+            %[2]s
+        ) b`, argIndex, where)
+
+	return from, args
+}
+
+// SubscriptionsWithTrialEnding implementation of the TrialLister interface.
+func (s *Storage) SubscriptionsWithTrialEnding(
+	ctx context.Context,
+	month time.Time,
+	userID *uuid.UUID,
+) ([]models.Subscription, error) {
+	const op = "storage.postgres.SubscriptionsWithTrialEnding"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
 	query := `
-        SELECT COALESCE(SUM(price), 0)
+        SELECT ` + subscriptionColumns + `
         FROM subscriptions
+        WHERE trial_months > 0
+          AND start_date + make_interval(months => trial_months - 1) = $1::date
+          AND (end_date IS NULL OR end_date >= $1::date)
+          AND ($2::uuid IS NULL OR user_id = $2)
+        ORDER BY created_at, id
     `
 
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
+	var subs []models.Subscription
+
+	err := s.read(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, query, month, userID)
+		if err != nil {
+			return err
+		}
+
+		subs, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Subscription, error) {
+			return scanSubscription(row)
+		})
+		if err != nil {
+			return err
+		}
+
+		return attachPauses(ctx, tx, subs)
+	})
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list trials", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var total int64
-	if err := s.pool.QueryRow(ctx, query, args...).Scan(&total); err != nil {
-		log.ErrorContext(ctx, "failed to sum subscriptions", slogx.Err(err))
-		return 0, fmt.Errorf("failed to execute sum query: %w", err)
+	return subs, nil
+}
+
+// SubscriptionsByUser implementation of the UserLister interface.
+func (s *Storage) SubscriptionsByUser(ctx context.Context, userID uuid.UUID) ([]models.Subscription, error) {
+	const op = "storage.postgres.SubscriptionsByUser"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        SELECT ` + subscriptionColumns + `
+        FROM subscriptions
+        WHERE user_id = $1
+        ORDER BY start_date, service_name, id
+    `
+
+	var subs []models.Subscription
+
+	err := s.read(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, query, userID)
+		if err != nil {
+			return err
+		}
+
+		subs, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Subscription, error) {
+			return scanSubscription(row)
+		})
+		if err != nil {
+			return err
+		}
+
+		return attachPauses(ctx, tx, subs)
+	})
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list subscriptions", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return total, nil
+	return subs, nil
+}
+
+// CancelUserSubscriptions implementation of the Canceller interface.
+func (s *Storage) CancelUserSubscriptions(ctx context.Context, userID uuid.UUID, month time.Time) ([]uuid.UUID, error) {
+	const op = "storage.postgres.CancelUserSubscriptions"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	// A subscription that starts after month ends in its first month, the earliest end
+	// subscriptions_check_period allows.
+	query := `
+        UPDATE subscriptions
+        SET end_date   = GREATEST(start_date, $2::date),
+            updated_at = NOW()
+        WHERE user_id = $1
+          AND end_date IS NULL
+        RETURNING id
+    `
+
+	var ids []uuid.UUID
+
+	err := s.write(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, query, userID, month)
+		if err != nil {
+			return err
+		}
+
+		ids, err = pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+		return err
+	})
+	if err != nil {
+		log.ErrorContext(ctx, "failed to cancel subscriptions", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
+
+// SavePriceChange implementation of the PriceScheduler interface.
+func (s *Storage) SavePriceChange(ctx context.Context, change models.PriceChange) (models.PriceChange, error) {
+	const op = "storage.postgres.SavePriceChange"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        INSERT INTO subscription_prices (subscription_id, effective_from, price)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (subscription_id, effective_from)
+            DO UPDATE SET price = EXCLUDED.price, created_at = NOW()
+        RETURNING subscription_id, effective_from, price, created_at;
+    `
+
+	var saved models.PriceChange
+
+	err := s.write(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx, query, change.SubscriptionID, change.EffectiveFrom, change.Price).Scan(
+			&saved.SubscriptionID,
+			&saved.EffectiveFrom,
+			&saved.Price,
+			&saved.CreatedAt,
+		)
+	})
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && pgErr.Code == PGErrForeignKeyViolation {
+			log.WarnContext(ctx, "subscription does not exist", slogx.Err(err))
+			return models.PriceChange{}, storage.ErrNotFound
+		}
+
+		log.ErrorContext(ctx, "failed to save price change", slogx.Err(err))
+		return models.PriceChange{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saved, nil
+}
+
+// PriceChanges implementation of the PriceLister interface.
+func (s *Storage) PriceChanges(ctx context.Context, subscriptionID uuid.UUID) ([]models.PriceChange, error) {
+	const op = "storage.postgres.PriceChanges"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        SELECT subscription_id, effective_from, price, created_at
+        FROM subscription_prices
+        WHERE subscription_id = $1
+        ORDER BY effective_from
+    `
+
+	var changes []models.PriceChange
+
+	err := s.read(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, query, subscriptionID)
+		if err != nil {
+			return err
+		}
+
+		changes, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.PriceChange, error) {
+			var c models.PriceChange
+			err := row.Scan(&c.SubscriptionID, &c.EffectiveFrom, &c.Price, &c.CreatedAt)
+			return c, err
+		})
+		return err
+	})
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list price changes", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return changes, nil
+}
+
+// PauseSubscription implementation of the Pauser interface.
+func (s *Storage) PauseSubscription(ctx context.Context, pause models.Pause) (models.Pause, error) {
+	const op = "storage.postgres.PauseSubscription"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        INSERT INTO subscription_pauses (subscription_id, paused_from)
+        VALUES ($1, $2)
+        RETURNING ` + pauseColumns + `;
+    `
+
+	var saved models.Pause
+
+	err := s.write(ctx, func(tx pgx.Tx) error {
+		var err error
+		saved, err = scanPause(tx.QueryRow(ctx, query, pause.SubscriptionID, pause.PausedFrom))
+		return err
+	})
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case PGErrUniqueViolation:
+				log.WarnContext(ctx, "subscription already paused", slogx.Err(err))
+				return models.Pause{}, storage.ErrAlreadyPaused
+			case PGErrForeignKeyViolation:
+				log.WarnContext(ctx, "subscription does not exist", slogx.Err(err))
+				return models.Pause{}, storage.ErrNotFound
+			}
+		}
+
+		log.ErrorContext(ctx, "failed to pause subscription", slogx.Err(err))
+		return models.Pause{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saved, nil
+}
+
+// ResumeSubscription implementation of the Pauser interface.
+func (s *Storage) ResumeSubscription(ctx context.Context, id uuid.UUID, month time.Time) (models.Pause, error) {
+	const op = "storage.postgres.ResumeSubscription"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        UPDATE subscription_pauses
+        SET resumed_from = $2
+        WHERE subscription_id = $1 AND resumed_from IS NULL
+        RETURNING ` + pauseColumns + `;
+    `
+
+	var resumed models.Pause
+
+	err := s.write(ctx, func(tx pgx.Tx) error {
+		var err error
+		resumed, err = scanPause(tx.QueryRow(ctx, query, id, month))
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.WarnContext(ctx, "subscription is not paused", slogx.Err(err))
+			return models.Pause{}, storage.ErrNotPaused
+		}
+
+		log.ErrorContext(ctx, "failed to resume subscription", slogx.Err(err))
+		return models.Pause{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resumed, nil
+}
+
+// version is the updated_at an update is conditioned on, nil for an unconditional one.
+func version(sub models.Subscription) *time.Time {
+	if sub.UpdatedAt.IsZero() {
+		return nil
+	}
+	return &sub.UpdatedAt
+}
+
+// modified tells why a conditional update of id matched no row: storage.ErrModified if the
+// row exists, pgx.ErrNoRows if it does not.
+func modified(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM subscriptions WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return err
+	}
+
+	if exists {
+		return storage.ErrModified
+	}
+	return pgx.ErrNoRows
+}
+
+// attachPauses loads the pause history of subs with a single query in tx.
+func attachPauses(ctx context.Context, tx pgx.Tx, subs []models.Subscription) error {
+	if len(subs) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(subs))
+	index := make(map[uuid.UUID]int, len(subs))
+	for i, sub := range subs {
+		ids[i] = sub.ID
+		index[sub.ID] = i
+	}
+
+	query := `
+        SELECT ` + pauseColumns + `
+        FROM subscription_pauses
+        WHERE subscription_id = ANY($1)
+        ORDER BY paused_from, created_at
+    `
+
+	rows, err := tx.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+
+	pauses, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Pause, error) {
+		return scanPause(row)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, p := range pauses {
+		i := index[p.SubscriptionID]
+		subs[i].Pauses = append(subs[i].Pauses, p)
+	}
+
+	return nil
+}
+
+const pauseColumns = `subscription_id, paused_from, resumed_from, created_at`
+
+func scanPause(row pgx.Row) (models.Pause, error) {
+	var p models.Pause
+	err := row.Scan(&p.SubscriptionID, &p.PausedFrom, &p.ResumedFrom, &p.CreatedAt)
+	return p, err
+}
+
+const subscriptionColumns = `id, service_name, price, user_id, start_date, end_date,
+               trial_months, trial_price, allow_overlap, created_at, updated_at`
+
+func scanSubscription(row pgx.Row) (models.Subscription, error) {
+	var sub models.Subscription
+
+	err := row.Scan(subscriptionDest(&sub)...)
+
+	return sub, err
+}
+
+// subscriptionDest returns the scan targets for subscriptionColumns.
+func subscriptionDest(sub *models.Subscription) []any {
+	return []any{
+		&sub.ID,
+		&sub.ServiceName,
+		&sub.Price,
+		&sub.UserID,
+		&sub.StartDate,
+		&sub.EndDate,
+		&sub.TrialMonths,
+		&sub.TrialPrice,
+		&sub.AllowOverlap,
+		&sub.CreatedAt,
+		&sub.UpdatedAt,
+	}
 }
@@ -0,0 +1,90 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/config"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/storage"
+	"github.com/salivare/subscriptions-service/internal/storage/postgres"
+	"github.com/salivare/subscriptions-service/internal/storage/storagetest"
+	"github.com/salivare/subscriptions-service/internal/tenant"
+)
+
+// open needs a migrated database and skips the test unless CONFIG_PATH points to its config.
+func open(t *testing.T) (*postgres.Storage, *config.Config) {
+	t.Helper()
+
+	path := os.Getenv("CONFIG_PATH")
+	if path == "" {
+		t.Skip("CONFIG_PATH is not set, skipping postgres tests")
+	}
+
+	cfg := config.MustLoadByPath(path)
+
+	s, err := postgres.New(cfg.Postgres)
+	require.NoError(t, err)
+	t.Cleanup(s.Close)
+
+	return s, cfg
+}
+
+func TestConformance(t *testing.T) {
+	s, _ := open(t)
+
+	storagetest.Run(
+		t, func(t *testing.T) storagetest.Storage {
+			return s
+		},
+	)
+}
+
+func TestTenantIsolation(t *testing.T) {
+	s, cfg := open(t)
+
+	if err := s.CheckRowSecurity(context.Background(), cfg.Postgres.MigrationsTable); err != nil {
+		t.Skipf("row-level security is not enforced for this role: %v", err)
+	}
+
+	first := tenant.WithID(context.Background(), "acme-"+uuid.NewString()[:8])
+	second := tenant.WithID(context.Background(), "globex-"+uuid.NewString()[:8])
+
+	price := int64(400)
+	sub := models.Subscription{
+		ServiceName: "Netflix",
+		Price:       &price,
+		UserID:      uuid.New(),
+		StartDate:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	id, _, err := s.SaveSubscription(first, sub)
+	require.NoError(t, err)
+
+	// The same subscription is no duplicate in another tenant.
+	_, _, err = s.SaveSubscription(second, sub)
+	require.NoError(t, err)
+
+	_, err = s.SubscriptionByID(second, id)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	assert.ErrorIs(t, s.DeleteSubscription(second, id), storage.ErrNotFound)
+
+	sub.ID = id
+	_, _, err = s.UpsertSubscription(second, sub)
+	assert.ErrorIs(t, err, storage.ErrSubscriptionExists)
+
+	subs, err := s.SubscriptionsByUser(first, sub.UserID)
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+	assert.Equal(t, id, subs[0].ID)
+
+	_, err = s.SubscriptionByID(context.Background(), id)
+	assert.ErrorIs(t, err, storage.ErrNoTenant)
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/salivare/subscriptions-service/internal/config"
+)
+
+const (
+	defaultReplicaCheckPeriod = 10 * time.Second
+	replicaPingTimeout        = 2 * time.Second
+)
+
+type replica struct {
+	pool    *pgxpool.Pool
+	healthy atomic.Bool
+}
+
+// replicaSet balances reads across replicas with round-robin, skipping replicas
+// that failed their last health check.
+type replicaSet struct {
+	replicas []*replica
+	next     atomic.Uint64
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func newReplicaSet(cfg config.PostgresConfig) (*replicaSet, error) {
+	const op = "storage.postgres.newReplicaSet"
+
+	rs := &replicaSet{stop: make(chan struct{})}
+
+	for _, dsn := range cfg.ReplicaDSNs {
+		poolCfg, err := pgxpool.ParseConfig(dsn)
+		if err != nil {
+			rs.close()
+			return nil, fmt.Errorf("%s: failed to parse replica DSN: %w", op, err)
+		}
+
+		applyPoolConfig(poolCfg, cfg)
+
+		pool, err := pgxpool.NewWithConfig(context.Background(), poolCfg)
+		if err != nil {
+			rs.close()
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		rs.replicas = append(rs.replicas, &replica{pool: pool})
+	}
+
+	if len(rs.replicas) == 0 {
+		return rs, nil
+	}
+
+	rs.check()
+
+	period := cfg.HealthCheckPeriod
+	if period <= 0 {
+		period = defaultReplicaCheckPeriod
+	}
+
+	rs.wg.Add(1)
+	go rs.watch(period)
+
+	return rs, nil
+}
+
+// pick returns the next healthy replica pool, or nil if none is available.
+func (rs *replicaSet) pick() *pgxpool.Pool {
+	n := len(rs.replicas)
+
+	for i := 0; i < n; i++ {
+		r := rs.replicas[int(rs.next.Add(1)%uint64(n))]
+		if r.healthy.Load() {
+			return r.pool
+		}
+	}
+
+	return nil
+}
+
+func (rs *replicaSet) watch(period time.Duration) {
+	defer rs.wg.Done()
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-rs.stop:
+			return
+		case <-ticker.C:
+			rs.check()
+		}
+	}
+}
+
+func (rs *replicaSet) check() {
+	for _, r := range rs.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), replicaPingTimeout)
+		r.healthy.Store(r.pool.Ping(ctx) == nil)
+		cancel()
+	}
+}
+
+func (rs *replicaSet) close() {
+	if len(rs.replicas) > 0 {
+		close(rs.stop)
+		rs.wg.Wait()
+	}
+
+	for _, r := range rs.replicas {
+		r.pool.Close()
+	}
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicaSet_Pick(t *testing.T) {
+	a, b, c := &pgxpool.Pool{}, &pgxpool.Pool{}, &pgxpool.Pool{}
+
+	newSet := func(healthy ...bool) *replicaSet {
+		rs := &replicaSet{}
+		for i, pool := range []*pgxpool.Pool{a, b, c} {
+			r := &replica{pool: pool}
+			r.healthy.Store(healthy[i])
+			rs.replicas = append(rs.replicas, r)
+		}
+		return rs
+	}
+
+	t.Run(
+		"round robin over healthy replicas", func(t *testing.T) {
+			rs := newSet(true, false, true)
+
+			for _, want := range []*pgxpool.Pool{c, a, c, a} {
+				assert.Same(t, want, rs.pick())
+			}
+		},
+	)
+
+	t.Run(
+		"no healthy replica", func(t *testing.T) {
+			rs := newSet(false, false, false)
+
+			assert.Nil(t, rs.pick())
+		},
+	)
+
+	t.Run(
+		"no replicas configured", func(t *testing.T) {
+			assert.Nil(t, (&replicaSet{}).pick())
+		},
+	)
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/salivare/subscriptions-service/internal/storage"
+	"github.com/salivare/subscriptions-service/internal/tenant"
+)
+
+// write runs fn in a transaction on the primary, scoped to the tenant of ctx.
+func (s *Storage) write(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	return inTenant(ctx, s.primary(), pgx.TxOptions{}, fn)
+}
+
+// read runs fn in a read-only transaction on the pool picked by reader, scoped to the
+// tenant of ctx.
+func (s *Storage) read(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	return inTenant(ctx, s.reader(ctx), pgx.TxOptions{AccessMode: pgx.ReadOnly}, fn)
+}
+
+// inTenant sets app.tenant_id for the transaction only, so the row-level security
+// policies hide the rows of every other tenant and the setting never outlives the
+// transaction on a pooled connection. A ctx without a tenant is refused rather than
+// left to the policies, which would silently match nothing.
+func inTenant(ctx context.Context, pool *pgxpool.Pool, opts pgx.TxOptions, fn func(tx pgx.Tx) error) error {
+	id, ok := tenant.FromContext(ctx)
+	if !ok {
+		return storage.ErrNoTenant
+	}
+
+	return pgx.BeginTxFunc(ctx, pool, opts, func(tx pgx.Tx) error {
+		// set_config with is_local is SET LOCAL, which takes no bind parameters.
+		if _, err := tx.Exec(ctx, `SELECT set_config('app.tenant_id', $1, true)`, id); err != nil {
+			return err
+		}
+
+		return fn(tx)
+	})
+}
+
+// CheckRowSecurity reports why the policies would not isolate tenants: the role of the
+// pool bypasses them, or a table other than migrationsTable lacks forced row-level
+// security, such as one added without following migration 10.
+func (s *Storage) CheckRowSecurity(ctx context.Context, migrationsTable string) error {
+	const op = "storage.postgres.CheckRowSecurity"
+
+	var (
+		role   string
+		bypass bool
+	)
+
+	err := s.primary().QueryRow(
+		ctx,
+		`SELECT rolname, rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user`,
+	).Scan(&role, &bypass)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if bypass {
+		return fmt.Errorf("%s: role %q bypasses row-level security", op, role)
+	}
+
+	rows, err := s.primary().Query(
+		ctx,
+		`SELECT relname
+         FROM pg_class
+         WHERE relnamespace = current_schema()::regnamespace
+           AND relkind IN ('r', 'p')
+           AND NOT (relrowsecurity AND relforcerowsecurity)
+           AND relname <> $1
+         ORDER BY relname`,
+		migrationsTable,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	tables, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(tables) > 0 {
+		return fmt.Errorf("%s: no forced row-level security on %s", op, strings.Join(tables, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/storage"
+)
+
+// SaveBudget implementation of the budget Saver interface.
+func (s *Storage) SaveBudget(ctx context.Context, b models.Budget) (models.Budget, error) {
+	const op = "storage.sqlite.SaveBudget"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        INSERT INTO budgets (id, user_id, service_name, amount, created_at, updated_at)
+        VALUES (?, ?, ?, ?, ?, ?)
+    `
+
+	b.ID = uuid.New()
+	b.CreatedAt = s.now()
+	b.UpdatedAt = b.CreatedAt
+
+	_, err := s.db.ExecContext(
+		ctx,
+		query,
+		b.ID.String(),
+		b.UserID.String(),
+		b.ServiceName,
+		b.Amount,
+		b.CreatedAt.Format(timestampLayout),
+		b.UpdatedAt.Format(timestampLayout),
+	)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			log.WarnContext(ctx, "budget already exists", slogx.Err(err))
+			return models.Budget{}, fmt.Errorf("%s: %w", op, storage.ErrBudgetExists)
+		}
+
+		log.ErrorContext(ctx, "failed to save budget", slogx.Err(err))
+		return models.Budget{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return b, nil
+}
+
+// BudgetByID implementation of the budget Getter interface.
+func (s *Storage) BudgetByID(ctx context.Context, id uuid.UUID) (models.Budget, error) {
+	const op = "storage.sqlite.BudgetByID"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        SELECT ` + budgetColumns + `
+        FROM budgets
+        WHERE id = ?
+    `
+
+	b, err := scanBudget(s.db.QueryRowContext(ctx, query, id.String()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Budget{}, storage.ErrBudgetNotFound
+		}
+
+		log.ErrorContext(ctx, "failed to get budget", slogx.Err(err))
+		return models.Budget{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return b, nil
+}
+
+// BudgetsByUser implementation of the budget Lister interface.
+func (s *Storage) BudgetsByUser(ctx context.Context, userID uuid.UUID) ([]models.Budget, error) {
+	const op = "storage.sqlite.BudgetsByUser"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	// NULLs sort first in SQLite, so the overall budget comes first like in Postgres.
+	query := `
+        SELECT ` + budgetColumns + `
+        FROM budgets
+        WHERE user_id = ?
+        ORDER BY service_name
+    `
+
+	rows, err := s.db.QueryContext(ctx, query, userID.String())
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list budgets", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var budgets []models.Budget
+	for rows.Next() {
+		b, err := scanBudget(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		budgets = append(budgets, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.ErrorContext(ctx, "failed to list budgets", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return budgets, nil
+}
+
+// UpdateBudget implementation of the budget Updater interface.
+func (s *Storage) UpdateBudget(ctx context.Context, id uuid.UUID, amount int64) (models.Budget, error) {
+	const op = "storage.sqlite.UpdateBudget"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        UPDATE budgets
+        SET amount = ?, updated_at = ?
+        WHERE id = ?
+        RETURNING ` + budgetColumns + `
+    `
+
+	b, err := scanBudget(s.db.QueryRowContext(ctx, query, amount, s.now().Format(timestampLayout), id.String()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.WarnContext(ctx, "budget does not exist", slogx.Err(err))
+			return models.Budget{}, storage.ErrBudgetNotFound
+		}
+
+		log.ErrorContext(ctx, "failed to update budget", slogx.Err(err))
+		return models.Budget{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return b, nil
+}
+
+// DeleteBudget implementation of the budget Deleter interface.
+func (s *Storage) DeleteBudget(ctx context.Context, id uuid.UUID) error {
+	const op = "storage.sqlite.DeleteBudget"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	res, err := s.db.ExecContext(ctx, `DELETE FROM budgets WHERE id = ?`, id.String())
+	if err != nil {
+		log.ErrorContext(ctx, "failed to delete budget", slogx.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected == 0 {
+		return storage.ErrBudgetNotFound
+	}
+
+	return nil
+}
+
+// MarkBudgetAlert implementation of the budget AlertMarker interface.
+func (s *Storage) MarkBudgetAlert(ctx context.Context, budgetID uuid.UUID, month time.Time) (bool, error) {
+	const op = "storage.sqlite.MarkBudgetAlert"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        INSERT INTO budget_alerts (budget_id, month, created_at)
+        VALUES (?, ?, ?)
+        ON CONFLICT (budget_id, month) DO NOTHING
+    `
+
+	res, err := s.db.ExecContext(
+		ctx,
+		query,
+		budgetID.String(),
+		month.Format(dateLayout),
+		s.now().Format(timestampLayout),
+	)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return false, storage.ErrBudgetNotFound
+		}
+
+		log.ErrorContext(ctx, "failed to mark budget alert", slogx.Err(err))
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return affected == 1, nil
+}
+
+// UnmarkBudgetAlert implementation of the budget AlertMarker interface.
+func (s *Storage) UnmarkBudgetAlert(ctx context.Context, budgetID uuid.UUID, month time.Time) error {
+	const op = "storage.sqlite.UnmarkBudgetAlert"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	_, err := s.db.ExecContext(
+		ctx,
+		`DELETE FROM budget_alerts WHERE budget_id = ? AND month = ?`,
+		budgetID.String(),
+		month.Format(dateLayout),
+	)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to unmark budget alert", slogx.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+const budgetColumns = `id, user_id, service_name, amount, created_at, updated_at`
+
+func scanBudget(row rowScanner) (models.Budget, error) {
+	var (
+		b                    models.Budget
+		id, userID           string
+		serviceName          sql.NullString
+		createdAt, updatedAt string
+	)
+
+	if err := row.Scan(&id, &userID, &serviceName, &b.Amount, &createdAt, &updatedAt); err != nil {
+		return models.Budget{}, err
+	}
+
+	var err error
+
+	if b.ID, err = uuid.Parse(id); err != nil {
+		return models.Budget{}, fmt.Errorf("parse id: %w", err)
+	}
+
+	if b.UserID, err = uuid.Parse(userID); err != nil {
+		return models.Budget{}, fmt.Errorf("parse user_id: %w", err)
+	}
+
+	if serviceName.Valid {
+		b.ServiceName = &serviceName.String
+	}
+
+	if b.CreatedAt, err = time.Parse(timestampLayout, createdAt); err != nil {
+		return models.Budget{}, fmt.Errorf("parse created_at: %w", err)
+	}
+
+	if b.UpdatedAt, err = time.Parse(timestampLayout, updatedAt); err != nil {
+		return models.Budget{}, fmt.Errorf("parse updated_at: %w", err)
+	}
+
+	return b, nil
+}
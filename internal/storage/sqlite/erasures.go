@@ -0,0 +1,130 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+)
+
+// EraseUser implementation of the privacy Eraser interface. Price changes and pauses go
+// with their subscriptions through ON DELETE CASCADE.
+func (s *Storage) EraseUser(ctx context.Context, userID uuid.UUID) (models.Erasure, error) {
+	const op = "storage.sqlite.EraseUser"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	erasure, err := s.erase(ctx, userID)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to erase user", slogx.Err(err))
+		return models.Erasure{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return erasure, nil
+}
+
+// erase runs in one transaction; SQLite has a single writer, so the chain cannot fork.
+func (s *Storage) erase(ctx context.Context, userID uuid.UUID) (models.Erasure, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Erasure{}, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var prev models.Erasure
+	err = tx.QueryRowContext(ctx, `SELECT seq, hash FROM erasures ORDER BY seq DESC LIMIT 1`).Scan(&prev.Seq, &prev.Hash)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return models.Erasure{}, err
+	}
+
+	subs, err := tx.ExecContext(ctx, `DELETE FROM subscriptions WHERE user_id = ?`, userID.String())
+	if err != nil {
+		return models.Erasure{}, err
+	}
+
+	budgets, err := tx.ExecContext(ctx, `DELETE FROM budgets WHERE user_id = ?`, userID.String())
+	if err != nil {
+		return models.Erasure{}, err
+	}
+
+	subCount, err := subs.RowsAffected()
+	if err != nil {
+		return models.Erasure{}, err
+	}
+
+	budgetCount, err := budgets.RowsAffected()
+	if err != nil {
+		return models.Erasure{}, err
+	}
+
+	erasure := models.NewErasure(prev, userID, int(subCount), int(budgetCount), s.now())
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO erasures (seq, user_hash, subscriptions, budgets, erased_at, prev_hash, hash)
+         VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		erasure.Seq,
+		erasure.UserHash,
+		erasure.Subscriptions,
+		erasure.Budgets,
+		erasure.ErasedAt.Format(timestampLayout),
+		erasure.PrevHash,
+		erasure.Hash,
+	)
+	if err != nil {
+		return models.Erasure{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Erasure{}, err
+	}
+
+	return erasure, nil
+}
+
+// Erasures implementation of the privacy ErasureLister interface.
+func (s *Storage) Erasures(ctx context.Context) ([]models.Erasure, error) {
+	const op = "storage.sqlite.Erasures"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT seq, user_hash, subscriptions, budgets, erased_at, prev_hash, hash FROM erasures ORDER BY seq`,
+	)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list erasures", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var res []models.Erasure
+	for rows.Next() {
+		var (
+			e        models.Erasure
+			erasedAt string
+		)
+
+		if err := rows.Scan(&e.Seq, &e.UserHash, &e.Subscriptions, &e.Budgets, &erasedAt, &e.PrevHash, &e.Hash); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if e.ErasedAt, err = time.Parse(timestampLayout, erasedAt); err != nil {
+			return nil, fmt.Errorf("%s: parse erased_at: %w", op, err)
+		}
+
+		res = append(res, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.ErrorContext(ctx, "failed to list erasures", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, nil
+}
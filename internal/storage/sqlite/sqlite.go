@@ -0,0 +1,1239 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/salivare-io/slogx"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/salivare/subscriptions-service/internal/config"
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/storage"
+)
+
+const (
+	// dateLayout stores DATE values as text, which keeps range comparisons lexicographic.
+	dateLayout = time.DateOnly
+	// timestampLayout is fixed-width UTC with microseconds, the precision of TIMESTAMPTZ.
+	timestampLayout = "2006-01-02 15:04:05.000000"
+)
+
+type Storage struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+// New Storage constructor. It creates the database file if needed and applies migrations.
+func New(cfg config.SQLiteConfig) (*Storage, error) {
+	const op = "storage.sqlite.New"
+
+	if dir := filepath.Dir(cfg.Path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := migrateUp(cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	dsn := fmt.Sprintf(
+		"file:%s?_pragma=busy_timeout(%d)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)",
+		cfg.Path,
+		cfg.BusyTimeout.Milliseconds(),
+	)
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// SQLite has a single writer; one connection avoids SQLITE_BUSY between our own goroutines.
+	db.SetMaxOpenConns(1)
+
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Storage{
+		db: db,
+		now: func() time.Time {
+			return time.Now().UTC().Truncate(time.Microsecond)
+		},
+	}, nil
+}
+
+func migrateUp(cfg config.SQLiteConfig) error {
+	m, err := migrate.New(
+		"file://"+cfg.MigrationsPath,
+		fmt.Sprintf("sqlite://%s?x-migrations-table=%s", cfg.Path, cfg.MigrationsTable),
+	)
+	if err != nil {
+		return fmt.Errorf("init migrations: %w", err)
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("apply migrations: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the database.
+func (s *Storage) Close() {
+	_ = s.db.Close()
+}
+
+// SaveSubscription implementation of the Saver interface.
+func (s *Storage) SaveSubscription(ctx context.Context, sub models.Subscription) (uuid.UUID, time.Time, error) {
+	const op = "storage.sqlite.SaveSubscription"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        INSERT INTO subscriptions (
+            id,
+            service_name,
+            price,
+            user_id,
+            start_date,
+            end_date,
+            trial_months,
+            trial_price,
+            allow_overlap,
+            created_at,
+            updated_at
+        ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `
+
+	id := uuid.New()
+	createdAt := s.now()
+
+	_, err := s.db.ExecContext(
+		ctx,
+		query,
+		id.String(),
+		sub.ServiceName,
+		sub.Price,
+		sub.UserID.String(),
+		formatDate(sub.StartDate),
+		formatNullDate(sub.EndDate),
+		sub.TrialMonths,
+		sub.TrialPrice,
+		sub.AllowOverlap,
+		createdAt.Format(timestampLayout),
+		createdAt.Format(timestampLayout),
+	)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			log.WarnContext(ctx, "subscription already exists", slogx.Err(err))
+			return uuid.Nil, time.Time{}, fmt.Errorf("%s: %w", op, storage.ErrSubscriptionExists)
+		}
+
+		if isOverlapViolation(err) {
+			log.WarnContext(ctx, "subscription overlaps another one", slogx.Err(err))
+			return uuid.Nil, time.Time{}, fmt.Errorf("%s: %w", op, s.overlapError(ctx, sub))
+		}
+
+		if isCheckViolation(err) {
+			log.WarnContext(ctx, "subscription violates a check constraint", slogx.Err(err))
+			return uuid.Nil, time.Time{}, fmt.Errorf("%s: %w: %w", op, storage.ErrCheckViolation, err)
+		}
+
+		log.ErrorContext(ctx, "failed to save subscription", slogx.Err(err))
+		return uuid.Nil, time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, createdAt, nil
+}
+
+// SubscriptionByID implementation of the Getter interface.
+func (s *Storage) SubscriptionByID(ctx context.Context, id uuid.UUID) (models.Subscription, error) {
+	const op = "storage.sqlite.GetSubscription"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        SELECT ` + subscriptionColumns + `
+        FROM subscriptions
+        WHERE id = ?
+    `
+
+	sub, err := scanSubscription(s.db.QueryRowContext(ctx, query, id.String()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Subscription{}, storage.ErrNotFound
+		}
+
+		log.ErrorContext(ctx, "failed to get subscription", slogx.Err(err))
+		return models.Subscription{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	subs := []models.Subscription{sub}
+	if err := s.attachPauses(ctx, subs); err != nil {
+		log.ErrorContext(ctx, "failed to load pauses", slogx.Err(err))
+		return models.Subscription{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return subs[0], nil
+}
+
+// DeleteSubscription implementation of the Deleter interface.
+func (s *Storage) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	const op = "storage.sqlite.DeleteSubscription"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	res, err := s.db.ExecContext(ctx, `DELETE FROM subscriptions WHERE id = ?`, id.String())
+	if err != nil {
+		log.ErrorContext(ctx, "failed to delete subscription", slogx.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected == 0 {
+		log.ErrorContext(ctx, "subscription not found")
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateSubscription implementation of the Updater interface.
+func (s *Storage) UpdateSubscription(ctx context.Context, sub models.Subscription) (models.Subscription, error) {
+	const op = "storage.sqlite.UpdateSubscription"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        UPDATE subscriptions
+        SET
+            service_name = ?,
+            price        = ?,
+            user_id      = ?,
+            start_date   = ?,
+            end_date     = ?,
+            trial_months = ?,
+            trial_price  = ?,
+            allow_overlap = ?,
+            updated_at   = ?
+        WHERE id = ? AND (? IS NULL OR updated_at = ?)
+        RETURNING ` + subscriptionColumns + `
+    `
+
+	var version any
+	if !sub.UpdatedAt.IsZero() {
+		version = sub.UpdatedAt.UTC().Format(timestampLayout)
+	}
+
+	updated, err := scanSubscription(
+		s.db.QueryRowContext(
+			ctx,
+			query,
+			sub.ServiceName,
+			sub.Price,
+			sub.UserID.String(),
+			formatDate(sub.StartDate),
+			formatNullDate(sub.EndDate),
+			sub.TrialMonths,
+			sub.TrialPrice,
+			sub.AllowOverlap,
+			s.now().Format(timestampLayout),
+			sub.ID.String(),
+			version,
+			version,
+		),
+	)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			log.WarnContext(ctx, "subscription already exists", slogx.Err(err))
+			return models.Subscription{}, fmt.Errorf("%s: %w", op, storage.ErrSubscriptionExists)
+		}
+
+		if isOverlapViolation(err) {
+			log.WarnContext(ctx, "subscription overlaps another one", slogx.Err(err))
+			return models.Subscription{}, fmt.Errorf("%s: %w", op, s.overlapError(ctx, sub))
+		}
+
+		if isCheckViolation(err) {
+			log.WarnContext(ctx, "subscription violates a check constraint", slogx.Err(err))
+			return models.Subscription{}, fmt.Errorf("%s: %w: %w", op, storage.ErrCheckViolation, err)
+		}
+
+		if errors.Is(err, sql.ErrNoRows) && version != nil {
+			err = s.modified(ctx, sub.ID)
+		}
+
+		if errors.Is(err, storage.ErrModified) {
+			log.WarnContext(ctx, "subscription was modified since it was read", slogx.Err(err))
+			return models.Subscription{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			log.WarnContext(ctx, "subscription does not exist", slogx.Err(err))
+			return models.Subscription{}, storage.ErrNotFound
+		}
+
+		log.ErrorContext(ctx, "failed to update subscription", slogx.Err(err))
+		return models.Subscription{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	subs := []models.Subscription{updated}
+	if err := s.attachPauses(ctx, subs); err != nil {
+		log.ErrorContext(ctx, "failed to load pauses", slogx.Err(err))
+		return models.Subscription{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return subs[0], nil
+}
+
+// UpsertSubscription implementation of the Upserter interface. The row keeps its
+// created_at when it is replaced; created reports whether it was inserted.
+func (s *Storage) UpsertSubscription(ctx context.Context, sub models.Subscription) (models.Subscription, bool, error) {
+	const op = "storage.sqlite.UpsertSubscription"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	stored, created, err := s.upsert(ctx, sub)
+	if err != nil {
+		switch {
+		case isUniqueViolation(err):
+			log.WarnContext(ctx, "subscription already exists", slogx.Err(err))
+			return models.Subscription{}, false, fmt.Errorf("%s: %w", op, storage.ErrSubscriptionExists)
+		case isOverlapViolation(err):
+			log.WarnContext(ctx, "subscription overlaps another one", slogx.Err(err))
+			return models.Subscription{}, false, fmt.Errorf("%s: %w", op, s.overlapError(ctx, sub))
+		case isCheckViolation(err):
+			log.WarnContext(ctx, "subscription violates a check constraint", slogx.Err(err))
+			return models.Subscription{}, false, fmt.Errorf("%s: %w: %w", op, storage.ErrCheckViolation, err)
+		}
+
+		log.ErrorContext(ctx, "failed to upsert subscription", slogx.Err(err))
+		return models.Subscription{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	subs := []models.Subscription{stored}
+	if err := s.attachPauses(ctx, subs); err != nil {
+		log.ErrorContext(ctx, "failed to load pauses", slogx.Err(err))
+		return models.Subscription{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return subs[0], created, nil
+}
+
+// modified tells why a conditional update of id matched no row: storage.ErrModified if the
+// row exists, sql.ErrNoRows if it does not.
+func (s *Storage) modified(ctx context.Context, id uuid.UUID) error {
+	var exists bool
+	if err := s.db.QueryRowContext(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM subscriptions WHERE id = ?)`,
+		id.String(),
+	).Scan(&exists); err != nil {
+		return err
+	}
+
+	if exists {
+		return storage.ErrModified
+	}
+	return sql.ErrNoRows
+}
+
+// upsert is INSERT ... ON CONFLICT (id) DO UPDATE. SQLite has no way to tell which of the
+// two happened and would fire the insert triggers for a replaced row as well, so the
+// existence check and the write run in one transaction instead.
+func (s *Storage) upsert(ctx context.Context, sub models.Subscription) (models.Subscription, bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Subscription{}, false, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var exists bool
+	if err := tx.QueryRowContext(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM subscriptions WHERE id = ?)`,
+		sub.ID.String(),
+	).Scan(&exists); err != nil {
+		return models.Subscription{}, false, err
+	}
+
+	now := s.now().Format(timestampLayout)
+
+	var query string
+	args := []any{
+		sub.ServiceName,
+		sub.Price,
+		sub.UserID.String(),
+		formatDate(sub.StartDate),
+		formatNullDate(sub.EndDate),
+		sub.TrialMonths,
+		sub.TrialPrice,
+		sub.AllowOverlap,
+		now,
+		sub.ID.String(),
+	}
+
+	if exists {
+		query = `
+            UPDATE subscriptions
+            SET
+                service_name = ?,
+                price        = ?,
+                user_id      = ?,
+                start_date   = ?,
+                end_date     = ?,
+                trial_months = ?,
+                trial_price  = ?,
+                allow_overlap = ?,
+                updated_at   = ?
+            WHERE id = ?
+            RETURNING ` + subscriptionColumns + `
+        `
+	} else {
+		query = `
+            INSERT INTO subscriptions (
+                service_name,
+                price,
+                user_id,
+                start_date,
+                end_date,
+                trial_months,
+                trial_price,
+                allow_overlap,
+                updated_at,
+                id,
+                created_at
+            ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+            RETURNING ` + subscriptionColumns + `
+        `
+		args = append(args, now)
+	}
+
+	stored, err := scanSubscription(tx.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		return models.Subscription{}, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Subscription{}, false, err
+	}
+
+	return stored, !exists, nil
+}
+
+// TransferSubscription implementation of the Transferrer interface.
+func (s *Storage) TransferSubscription(ctx context.Context, ended, continuation models.Subscription) (uuid.UUID, error) {
+	const op = "storage.sqlite.TransferSubscription"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	id := uuid.New()
+
+	err := s.transfer(ctx, id, ended, continuation)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			log.WarnContext(ctx, "subscription does not exist")
+			return uuid.Nil, storage.ErrNotFound
+		case isUniqueViolation(err):
+			log.WarnContext(ctx, "subscription already exists", slogx.Err(err))
+			return uuid.Nil, fmt.Errorf("%s: %w", op, storage.ErrSubscriptionExists)
+		case isOverlapViolation(err):
+			log.WarnContext(ctx, "subscription overlaps another one", slogx.Err(err))
+			return uuid.Nil, fmt.Errorf("%s: %w", op, s.overlapError(ctx, continuation))
+		case isCheckViolation(err):
+			log.WarnContext(ctx, "subscription violates a check constraint", slogx.Err(err))
+			return uuid.Nil, fmt.Errorf("%s: %w: %w", op, storage.ErrCheckViolation, err)
+		}
+
+		log.ErrorContext(ctx, "failed to transfer subscription", slogx.Err(err))
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) transfer(ctx context.Context, id uuid.UUID, ended, continuation models.Subscription) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := s.now().Format(timestampLayout)
+
+	res, err := tx.ExecContext(
+		ctx,
+		`UPDATE subscriptions SET end_date = ?, updated_at = ? WHERE id = ?`,
+		formatNullDate(ended.EndDate),
+		now,
+		ended.ID.String(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := res.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return storage.ErrNotFound
+	}
+
+	query := `
+        INSERT INTO subscriptions (
+            id,
+            service_name,
+            price,
+            user_id,
+            start_date,
+            end_date,
+            trial_months,
+            trial_price,
+            allow_overlap,
+            created_at,
+            updated_at
+        ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `
+
+	_, err = tx.ExecContext(
+		ctx,
+		query,
+		id.String(),
+		continuation.ServiceName,
+		continuation.Price,
+		continuation.UserID.String(),
+		formatDate(continuation.StartDate),
+		formatNullDate(continuation.EndDate),
+		continuation.TrialMonths,
+		continuation.TrialPrice,
+		continuation.AllowOverlap,
+		now,
+		now,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Scheduled changes move with the subscription; one due in the transfer month is
+	// already part of the continuation's price.
+	start := formatDate(continuation.StartDate)
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE subscription_prices SET subscription_id = ? WHERE subscription_id = ? AND effective_from > ?`,
+		id.String(),
+		ended.ID.String(),
+		start,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`DELETE FROM subscription_prices WHERE subscription_id = ? AND effective_from >= ?`,
+		ended.ID.String(),
+		start,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// overlapError names the subscription that sub overlaps after the subscriptions_no_overlap
+// triggers rejected it.
+func (s *Storage) overlapError(ctx context.Context, sub models.Subscription) error {
+	query := `
+        SELECT id
+        FROM subscriptions
+        WHERE user_id = ?
+          AND service_name = ?
+          AND id <> ?
+          AND NOT allow_overlap
+          AND start_date <> ?
+          AND start_date <= COALESCE(?, '9999-12-31')
+          AND ? <= COALESCE(end_date, '9999-12-31')
+        ORDER BY start_date
+        LIMIT 1
+    `
+
+	start := formatDate(sub.StartDate)
+
+	var id string
+
+	err := s.db.QueryRowContext(
+		ctx,
+		query,
+		sub.UserID.String(),
+		sub.ServiceName,
+		sub.ID.String(),
+		start,
+		formatNullDate(sub.EndDate),
+		start,
+	).Scan(&id)
+	if err != nil {
+		return storage.ErrOverlap
+	}
+
+	conflicting, err := uuid.Parse(id)
+	if err != nil {
+		return storage.ErrOverlap
+	}
+
+	return &storage.OverlapError{ConflictingID: conflicting}
+}
+
+// SumSubscriptions implementation of the Summer interface.
+func (s *Storage) SumSubscriptions(ctx context.Context, f models.SumFilter) (int64, error) {
+	const op = "storage.sqlite.SumSubscriptions"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	from, args, err := sumSource(f)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query := `SELECT COALESCE(SUM(` + billedPrice + `), 0) FROM ` + from
+
+	var total int64
+	if err := s.db.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		log.ErrorContext(ctx, "failed to sum subscriptions", slogx.Err(err))
+		return 0, fmt.Errorf("failed to execute sum query: %w", err)
+	}
+
+	return total, nil
+}
+
+// SumSubscriptionsByService implementation of the ServiceSummer interface.
+func (s *Storage) SumSubscriptionsByService(ctx context.Context, f models.SumFilter) ([]models.ServiceCost, error) {
+	const op = "storage.sqlite.SumSubscriptionsByService"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	from, args, err := sumSource(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query := `
+        SELECT b.service_name, SUM(` + billedPrice + `)
+        FROM ` + from + `
+        GROUP BY b.service_name
+        ORDER BY b.service_name
+    `
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to sum subscriptions by service", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var costs []models.ServiceCost
+	for rows.Next() {
+		var c models.ServiceCost
+		if err := rows.Scan(&c.ServiceName, &c.Cost); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		costs = append(costs, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.ErrorContext(ctx, "failed to sum subscriptions by service", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return costs, nil
+}
+
+// billedPrice is what a subscription of the sumSource row b is billed in its billing month:
+// nothing while paused, else the trial price, else the latest price change in effect,
+// else the base price.
+const billedPrice = `
+            CASE
+                WHEN EXISTS (
+                    SELECT 1
+                    FROM subscription_pauses ps
+                    WHERE ps.subscription_id = b.id
+                      AND ps.paused_from <= b.billing_month
+                      AND (ps.resumed_from IS NULL OR ps.resumed_from > b.billing_month)
+                )
+                THEN 0
+                WHEN b.billing_month < date(b.start_date, '+' || b.trial_months || ' months')
+                THEN b.trial_price
+                ELSE COALESCE((
+                    SELECT p.price
+                    FROM subscription_prices p
+                    WHERE p.subscription_id = b.id AND p.effective_from <= b.billing_month
+                    ORDER BY p.effective_from DESC
+                    LIMIT 1
+                ), b.price)
+            END`
+
+// sumSource returns the subquery b of the subscriptions matching f, each with its
+// billing_month, and its arguments.
+func sumSource(f models.SumFilter) (string, []any, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	add := func(cond string, val any) {
+		conditions = append(conditions, cond)
+		args = append(args, val)
+	}
+
+	if f.UserID != nil {
+		// user_id is a UUID column in Postgres, so compare canonical forms and reject garbage the same way.
+		userID, err := uuid.Parse(*f.UserID)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid user_id: %w", err)
+		}
+		add("user_id = ?", userID.String())
+	}
+
+	if f.ServiceName != nil {
+		add("service_name = ?", *f.ServiceName)
+	}
+
+	if f.StartDateFrom != nil {
+		add("start_date >= ?", formatDate(*f.StartDateFrom))
+	}
+
+	if f.StartDateTo != nil {
+		add("start_date <= ?", formatDate(*f.StartDateTo))
+	}
+
+	if f.EndDateFrom != nil {
+		add("end_date >= ?", formatDate(*f.EndDateFrom))
+	}
+
+	if f.EndDateTo != nil {
+		add("end_date <= ?", formatDate(*f.EndDateTo))
+	}
+
+	if f.ActiveOnly {
+		add("start_date <= ?", formatDate(f.AsOfOrCurrent()))
+		add("(end_date IS NULL OR end_date >= ?)", formatDate(f.AsOfOrCurrent()))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// billing_month clamps as_of to [start_date, end_date], see models.Subscription.BillingMonth.
+	// Dates are ISO text, so max/min compare them correctly.
+	from := `(
+            SELECT
+                id, service_name, price, start_date, trial_months, trial_price,
+                max(start_date, min(?, COALESCE(end_date, ?))) AS billing_month
+            FROM subscriptions
+            ` + where + `
+        ) b`
+
+	asOf := formatDate(f.AsOfOrCurrent())
+
+	return from, append([]any{asOf, asOf}, args...), nil
+}
+
+// SubscriptionsWithTrialEnding implementation of the TrialLister interface.
+func (s *Storage) SubscriptionsWithTrialEnding(
+	ctx context.Context,
+	month time.Time,
+	userID *uuid.UUID,
+) ([]models.Subscription, error) {
+	const op = "storage.sqlite.SubscriptionsWithTrialEnding"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        SELECT ` + subscriptionColumns + `
+        FROM subscriptions
+        WHERE trial_months > 0
+          AND date(start_date, '+' || (trial_months - 1) || ' months') = :month
+          AND (end_date IS NULL OR end_date >= :month)
+          AND (:user_id IS NULL OR user_id = :user_id)
+        ORDER BY created_at, id
+    `
+
+	var uid sql.NullString
+	if userID != nil {
+		uid = sql.NullString{String: userID.String(), Valid: true}
+	}
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		query,
+		sql.Named("month", formatDate(month)),
+		sql.Named("user_id", uid),
+	)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list trials", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var subs []models.Subscription
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		subs = append(subs, sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.ErrorContext(ctx, "failed to list trials", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.attachPauses(ctx, subs); err != nil {
+		log.ErrorContext(ctx, "failed to load pauses", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return subs, nil
+}
+
+// SubscriptionsByUser implementation of the UserLister interface.
+func (s *Storage) SubscriptionsByUser(ctx context.Context, userID uuid.UUID) ([]models.Subscription, error) {
+	const op = "storage.sqlite.SubscriptionsByUser"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        SELECT ` + subscriptionColumns + `
+        FROM subscriptions
+        WHERE user_id = ?
+        ORDER BY start_date, service_name, id
+    `
+
+	rows, err := s.db.QueryContext(ctx, query, userID.String())
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list subscriptions", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var subs []models.Subscription
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		subs = append(subs, sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.ErrorContext(ctx, "failed to list subscriptions", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.attachPauses(ctx, subs); err != nil {
+		log.ErrorContext(ctx, "failed to load pauses", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return subs, nil
+}
+
+// CancelUserSubscriptions implementation of the Canceller interface.
+func (s *Storage) CancelUserSubscriptions(ctx context.Context, userID uuid.UUID, month time.Time) ([]uuid.UUID, error) {
+	const op = "storage.sqlite.CancelUserSubscriptions"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	// A subscription that starts after month ends in its first month, the earliest end
+	// subscriptions_check_update allows. Dates are ISO text, so MAX compares them correctly.
+	query := `
+        UPDATE subscriptions
+        SET end_date   = MAX(start_date, ?),
+            updated_at = ?
+        WHERE user_id = ?
+          AND end_date IS NULL
+        RETURNING id
+    `
+
+	rows, err := s.db.QueryContext(ctx, query, formatDate(month), s.now().Format(timestampLayout), userID.String())
+	if err != nil {
+		log.ErrorContext(ctx, "failed to cancel subscriptions", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var raw string
+		if err := rows.Scan(&raw); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: parse id: %w", op, err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.ErrorContext(ctx, "failed to cancel subscriptions", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
+
+// SavePriceChange implementation of the PriceScheduler interface.
+func (s *Storage) SavePriceChange(ctx context.Context, change models.PriceChange) (models.PriceChange, error) {
+	const op = "storage.sqlite.SavePriceChange"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        INSERT INTO subscription_prices (subscription_id, effective_from, price, created_at)
+        VALUES (?, ?, ?, ?)
+        ON CONFLICT (subscription_id, effective_from)
+            DO UPDATE SET price = excluded.price, created_at = excluded.created_at
+    `
+
+	change.CreatedAt = s.now()
+
+	_, err := s.db.ExecContext(
+		ctx,
+		query,
+		change.SubscriptionID.String(),
+		formatDate(change.EffectiveFrom),
+		change.Price,
+		change.CreatedAt.Format(timestampLayout),
+	)
+
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			log.WarnContext(ctx, "subscription does not exist", slogx.Err(err))
+			return models.PriceChange{}, storage.ErrNotFound
+		}
+
+		log.ErrorContext(ctx, "failed to save price change", slogx.Err(err))
+		return models.PriceChange{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return change, nil
+}
+
+// PriceChanges implementation of the PriceLister interface.
+func (s *Storage) PriceChanges(ctx context.Context, subscriptionID uuid.UUID) ([]models.PriceChange, error) {
+	const op = "storage.sqlite.PriceChanges"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        SELECT effective_from, price, created_at
+        FROM subscription_prices
+        WHERE subscription_id = ?
+        ORDER BY effective_from
+    `
+
+	rows, err := s.db.QueryContext(ctx, query, subscriptionID.String())
+	if err != nil {
+		log.ErrorContext(ctx, "failed to list price changes", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var changes []models.PriceChange
+	for rows.Next() {
+		var (
+			c                        models.PriceChange
+			effectiveFrom, createdAt string
+		)
+
+		if err := rows.Scan(&effectiveFrom, &c.Price, &createdAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if c.EffectiveFrom, err = time.Parse(dateLayout, effectiveFrom); err != nil {
+			return nil, fmt.Errorf("%s: parse effective_from: %w", op, err)
+		}
+
+		if c.CreatedAt, err = time.Parse(timestampLayout, createdAt); err != nil {
+			return nil, fmt.Errorf("%s: parse created_at: %w", op, err)
+		}
+
+		c.SubscriptionID = subscriptionID
+		changes = append(changes, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.ErrorContext(ctx, "failed to list price changes", slogx.Err(err))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return changes, nil
+}
+
+// PauseSubscription implementation of the Pauser interface.
+func (s *Storage) PauseSubscription(ctx context.Context, pause models.Pause) (models.Pause, error) {
+	const op = "storage.sqlite.PauseSubscription"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        INSERT INTO subscription_pauses (id, subscription_id, paused_from, created_at)
+        VALUES (?, ?, ?, ?)
+    `
+
+	pause.ResumedFrom = nil
+	pause.CreatedAt = s.now()
+
+	_, err := s.db.ExecContext(
+		ctx,
+		query,
+		uuid.NewString(),
+		pause.SubscriptionID.String(),
+		formatDate(pause.PausedFrom),
+		pause.CreatedAt.Format(timestampLayout),
+	)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			log.WarnContext(ctx, "subscription already paused", slogx.Err(err))
+			return models.Pause{}, storage.ErrAlreadyPaused
+		}
+
+		if isForeignKeyViolation(err) {
+			log.WarnContext(ctx, "subscription does not exist", slogx.Err(err))
+			return models.Pause{}, storage.ErrNotFound
+		}
+
+		log.ErrorContext(ctx, "failed to pause subscription", slogx.Err(err))
+		return models.Pause{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pause, nil
+}
+
+// ResumeSubscription implementation of the Pauser interface.
+func (s *Storage) ResumeSubscription(ctx context.Context, id uuid.UUID, month time.Time) (models.Pause, error) {
+	const op = "storage.sqlite.ResumeSubscription"
+	log := slogx.FromContext(ctx).With(slog.String("op", op))
+
+	query := `
+        UPDATE subscription_pauses
+        SET resumed_from = ?
+        WHERE subscription_id = ? AND resumed_from IS NULL
+        RETURNING ` + pauseColumns + `
+    `
+
+	resumed, err := scanPause(s.db.QueryRowContext(ctx, query, formatDate(month), id.String()))
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.WarnContext(ctx, "subscription is not paused", slogx.Err(err))
+			return models.Pause{}, storage.ErrNotPaused
+		}
+
+		log.ErrorContext(ctx, "failed to resume subscription", slogx.Err(err))
+		return models.Pause{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resumed, nil
+}
+
+// attachPauses loads the pause history of subs with a single query.
+func (s *Storage) attachPauses(ctx context.Context, subs []models.Subscription) error {
+	if len(subs) == 0 {
+		return nil
+	}
+
+	args := make([]any, len(subs))
+	index := make(map[uuid.UUID]int, len(subs))
+	for i, sub := range subs {
+		args[i] = sub.ID.String()
+		index[sub.ID] = i
+	}
+
+	query := `
+        SELECT ` + pauseColumns + `
+        FROM subscription_pauses
+        WHERE subscription_id IN (?` + strings.Repeat(", ?", len(subs)-1) + `)
+        ORDER BY paused_from, created_at
+    `
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p, err := scanPause(rows)
+		if err != nil {
+			return err
+		}
+
+		i := index[p.SubscriptionID]
+		subs[i].Pauses = append(subs[i].Pauses, p)
+	}
+
+	return rows.Err()
+}
+
+const pauseColumns = `subscription_id, paused_from, resumed_from, created_at`
+
+func scanPause(row rowScanner) (models.Pause, error) {
+	var (
+		p                     models.Pause
+		subscriptionID        string
+		pausedFrom, createdAt string
+		resumedFrom           sql.NullString
+	)
+
+	if err := row.Scan(&subscriptionID, &pausedFrom, &resumedFrom, &createdAt); err != nil {
+		return models.Pause{}, err
+	}
+
+	var err error
+
+	if p.SubscriptionID, err = uuid.Parse(subscriptionID); err != nil {
+		return models.Pause{}, fmt.Errorf("parse subscription_id: %w", err)
+	}
+
+	if p.PausedFrom, err = time.Parse(dateLayout, pausedFrom); err != nil {
+		return models.Pause{}, fmt.Errorf("parse paused_from: %w", err)
+	}
+
+	if resumedFrom.Valid {
+		t, err := time.Parse(dateLayout, resumedFrom.String)
+		if err != nil {
+			return models.Pause{}, fmt.Errorf("parse resumed_from: %w", err)
+		}
+		p.ResumedFrom = &t
+	}
+
+	if p.CreatedAt, err = time.Parse(timestampLayout, createdAt); err != nil {
+		return models.Pause{}, fmt.Errorf("parse created_at: %w", err)
+	}
+
+	return p, nil
+}
+
+const subscriptionColumns = `id, service_name, price, user_id, start_date, end_date,
+               trial_months, trial_price, allow_overlap, created_at, updated_at`
+
+// rowScanner is implemented by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSubscription(row rowScanner) (models.Subscription, error) {
+	var (
+		sub                  models.Subscription
+		id, userID           string
+		startDate            string
+		endDate              sql.NullString
+		createdAt, updatedAt string
+	)
+
+	if err := row.Scan(
+		&id,
+		&sub.ServiceName,
+		&sub.Price,
+		&userID,
+		&startDate,
+		&endDate,
+		&sub.TrialMonths,
+		&sub.TrialPrice,
+		&sub.AllowOverlap,
+		&createdAt,
+		&updatedAt,
+	); err != nil {
+		return models.Subscription{}, err
+	}
+
+	var err error
+
+	if sub.ID, err = uuid.Parse(id); err != nil {
+		return models.Subscription{}, fmt.Errorf("parse id: %w", err)
+	}
+
+	if sub.UserID, err = uuid.Parse(userID); err != nil {
+		return models.Subscription{}, fmt.Errorf("parse user_id: %w", err)
+	}
+
+	if sub.StartDate, err = time.Parse(dateLayout, startDate); err != nil {
+		return models.Subscription{}, fmt.Errorf("parse start_date: %w", err)
+	}
+
+	if endDate.Valid {
+		t, err := time.Parse(dateLayout, endDate.String)
+		if err != nil {
+			return models.Subscription{}, fmt.Errorf("parse end_date: %w", err)
+		}
+		sub.EndDate = &t
+	}
+
+	if sub.CreatedAt, err = time.Parse(timestampLayout, createdAt); err != nil {
+		return models.Subscription{}, fmt.Errorf("parse created_at: %w", err)
+	}
+
+	if sub.UpdatedAt, err = time.Parse(timestampLayout, updatedAt); err != nil {
+		return models.Subscription{}, fmt.Errorf("parse updated_at: %w", err)
+	}
+
+	return sub, nil
+}
+
+// formatDate keeps the calendar date of t as is, like pgx does when binding a DATE parameter.
+func formatDate(t time.Time) string {
+	return t.Format(dateLayout)
+}
+
+func formatNullDate(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: formatDate(*t), Valid: true}
+}
+
+func isForeignKeyViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY
+}
+
+func isUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
+
+// isOverlapViolation reports whether err was raised by the subscriptions_no_overlap triggers.
+func isOverlapViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+
+	return errors.As(err, &sqliteErr) &&
+		sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_TRIGGER &&
+		strings.Contains(sqliteErr.Error(), "subscriptions_no_overlap")
+}
+
+// isCheckViolation reports whether err was raised by the subscriptions_check triggers.
+func isCheckViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+
+	return errors.As(err, &sqliteErr) &&
+		sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_TRIGGER &&
+		strings.Contains(sqliteErr.Error(), "subscriptions_check_")
+}
@@ -0,0 +1,32 @@
+package sqlite_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/config"
+	"github.com/salivare/subscriptions-service/internal/storage/sqlite"
+	"github.com/salivare/subscriptions-service/internal/storage/storagetest"
+)
+
+func TestConformance(t *testing.T) {
+	storagetest.Run(
+		t, func(t *testing.T) storagetest.Storage {
+			s, err := sqlite.New(
+				config.SQLiteConfig{
+					Path:            filepath.Join(t.TempDir(), "subscriptions.db"),
+					BusyTimeout:     time.Second,
+					MigrationsPath:  "../../../migrations/sqlite",
+					MigrationsTable: "schema_migrations",
+				},
+			)
+			require.NoError(t, err)
+			t.Cleanup(s.Close)
+
+			return s
+		},
+	)
+}
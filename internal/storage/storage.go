@@ -1,18 +1,57 @@
 package storage
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/salivare/subscriptions-service/internal/config"
 )
 
 var (
 	ErrSubscriptionExists = errors.New("subscription already exists")
 	ErrNotFound           = errors.New("subscription not found")
+	ErrAlreadyPaused      = errors.New("subscription is already paused")
+	ErrNotPaused          = errors.New("subscription is not paused")
+	ErrBudgetNotFound     = errors.New("budget not found")
+	ErrBudgetExists       = errors.New("budget already exists")
+	ErrOverlap            = errors.New("subscription overlaps another one")
+	ErrCheckViolation     = errors.New("subscription violates a check constraint")
+	ErrNoTenant           = errors.New("no tenant in context")
+	ErrModified           = errors.New("subscription was modified since it was read")
 )
 
+// OverlapError reports the subscription whose period a write would overlap.
+// It matches ErrOverlap with errors.Is.
+type OverlapError struct {
+	ConflictingID uuid.UUID
+}
+
+func (e *OverlapError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrOverlap, e.ConflictingID)
+}
+
+func (e *OverlapError) Is(target error) bool {
+	return target == ErrOverlap
+}
+
+type primaryKey struct{}
+
+// WithPrimary pins reads made with the returned context to the primary database,
+// so a caller sees its own writes even when replicas lag behind.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// UsePrimary reports whether reads made with ctx must go to the primary database.
+func UsePrimary(ctx context.Context) bool {
+	v, _ := ctx.Value(primaryKey{}).(bool)
+	return v
+}
+
 // RetryBackoff retry to run bd if there was a container race in the dock.
 func RetryBackoff(cfg config.RetryConfig, fn func() error) error {
 	delay := cfg.InitialDelay
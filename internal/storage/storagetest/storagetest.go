@@ -0,0 +1,1163 @@
+// Package storagetest contains a conformance suite that every storage backend must pass.
+package storagetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/internal/domain/models"
+	"github.com/salivare/subscriptions-service/internal/services/budget"
+	"github.com/salivare/subscriptions-service/internal/services/privacy"
+	"github.com/salivare/subscriptions-service/internal/services/subscription"
+	"github.com/salivare/subscriptions-service/internal/storage"
+	"github.com/salivare/subscriptions-service/internal/tenant"
+)
+
+// Storage is the set of interfaces the subscription service needs from a backend.
+type Storage interface {
+	subscription.Saver
+	subscription.Updater
+	subscription.Deleter
+	subscription.Getter
+	subscription.Summer
+	subscription.ServiceSummer
+	subscription.TrialLister
+	subscription.PriceScheduler
+	subscription.PriceLister
+	subscription.Pauser
+	subscription.Transferrer
+	subscription.Upserter
+	subscription.UserLister
+	subscription.Canceller
+}
+
+// BudgetStorage is the set of interfaces the budget service needs from a backend.
+type BudgetStorage interface {
+	budget.Saver
+	budget.Updater
+	budget.Deleter
+	budget.Getter
+	budget.Lister
+	budget.AlertMarker
+}
+
+// PrivacyStorage is the set of interfaces the privacy service needs from a backend.
+type PrivacyStorage interface {
+	Storage
+	BudgetStorage
+	privacy.Eraser
+	privacy.ErasureLister
+}
+
+// Run executes the conformance suite. newStorage is called once per subtest;
+// backends sharing a database are fine because every subtest uses fresh user IDs.
+func Run(t *testing.T, newStorage func(t *testing.T) Storage) {
+	t.Run("save and get", func(t *testing.T) { testSaveAndGet(t, newStorage(t)) })
+	t.Run("save duplicate", func(t *testing.T) { testSaveDuplicate(t, newStorage(t)) })
+	t.Run("get missing", func(t *testing.T) { testGetMissing(t, newStorage(t)) })
+	t.Run("update", func(t *testing.T) { testUpdate(t, newStorage(t)) })
+	t.Run("update missing", func(t *testing.T) { testUpdateMissing(t, newStorage(t)) })
+	t.Run("update conflict", func(t *testing.T) { testUpdateConflict(t, newStorage(t)) })
+	t.Run("update version", func(t *testing.T) { testUpdateVersion(t, newStorage(t)) })
+	t.Run("overlap", func(t *testing.T) { testOverlap(t, newStorage(t)) })
+	t.Run("check constraints", func(t *testing.T) { testCheckConstraints(t, newStorage(t)) })
+	t.Run("transfer", func(t *testing.T) { testTransfer(t, newStorage(t)) })
+	t.Run("upsert", func(t *testing.T) { testUpsert(t, newStorage(t)) })
+	t.Run("by user", func(t *testing.T) { testByUser(t, newStorage(t)) })
+	t.Run("cancel user subscriptions", func(t *testing.T) { testCancelUserSubscriptions(t, newStorage(t)) })
+	t.Run("delete", func(t *testing.T) { testDelete(t, newStorage(t)) })
+	t.Run("sum", func(t *testing.T) { testSum(t, newStorage(t)) })
+	t.Run("sum by service", func(t *testing.T) { testSumByService(t, newStorage(t)) })
+	t.Run("trial", func(t *testing.T) { testTrial(t, newStorage(t)) })
+	t.Run("trials ending", func(t *testing.T) { testTrialsEnding(t, newStorage(t)) })
+	t.Run("price changes", func(t *testing.T) { testPriceChanges(t, newStorage(t)) })
+	t.Run("pauses", func(t *testing.T) { testPauses(t, newStorage(t)) })
+	t.Run("budgets", func(t *testing.T) {
+		s, ok := newStorage(t).(BudgetStorage)
+		if !ok {
+			t.Skip("backend does not store budgets")
+		}
+		testBudgets(t, s)
+	})
+	t.Run("erase user", func(t *testing.T) {
+		s, ok := newStorage(t).(PrivacyStorage)
+		if !ok {
+			t.Skip("backend does not erase users")
+		}
+		testEraseUser(t, s)
+	})
+}
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// background is the context of every test. It carries the default tenant, since the
+// postgres storage refuses a context without one.
+func background() context.Context {
+	return tenant.WithID(context.Background(), tenant.Default)
+}
+
+func newSubscription(userID uuid.UUID, service string, price int64, start time.Time, end *time.Time) models.Subscription {
+	return models.Subscription{
+		ServiceName: service,
+		Price:       &price,
+		UserID:      userID,
+		StartDate:   start,
+		EndDate:     end,
+	}
+}
+
+func testSaveAndGet(t *testing.T, s Storage) {
+	ctx := background()
+	end := month(2024, time.June)
+	sub := newSubscription(uuid.New(), "Netflix", 400, month(2024, time.January), &end)
+
+	id, createdAt, err := s.SaveSubscription(ctx, sub)
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, id)
+	assert.False(t, createdAt.IsZero())
+
+	got, err := s.SubscriptionByID(ctx, id)
+	require.NoError(t, err)
+
+	assert.Equal(t, id, got.ID)
+	assert.Equal(t, sub.ServiceName, got.ServiceName)
+	assert.Equal(t, *sub.Price, *got.Price)
+	assert.Equal(t, sub.UserID, got.UserID)
+	assert.True(t, sub.StartDate.Equal(got.StartDate))
+	require.NotNil(t, got.EndDate)
+	assert.True(t, end.Equal(*got.EndDate))
+	assert.True(t, createdAt.Equal(got.CreatedAt))
+	assert.True(t, got.UpdatedAt.Equal(got.CreatedAt))
+}
+
+func testSaveDuplicate(t *testing.T, s Storage) {
+	ctx := background()
+	sub := newSubscription(uuid.New(), "Netflix", 400, month(2024, time.January), nil)
+
+	_, _, err := s.SaveSubscription(ctx, sub)
+	require.NoError(t, err)
+
+	*sub.Price = 500
+	_, _, err = s.SaveSubscription(ctx, sub)
+	assert.ErrorIs(t, err, storage.ErrSubscriptionExists)
+
+	sub.StartDate = month(2024, time.February)
+	sub.AllowOverlap = true
+	_, _, err = s.SaveSubscription(ctx, sub)
+	assert.NoError(t, err)
+}
+
+func testGetMissing(t *testing.T, s Storage) {
+	_, err := s.SubscriptionByID(background(), uuid.New())
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func testUpdate(t *testing.T, s Storage) {
+	ctx := background()
+	sub := newSubscription(uuid.New(), "Netflix", 400, month(2024, time.January), nil)
+
+	id, createdAt, err := s.SaveSubscription(ctx, sub)
+	require.NoError(t, err)
+
+	end := month(2024, time.December)
+	sub.ID = id
+	sub.ServiceName = "Netflix Premium"
+	*sub.Price = 800
+	sub.EndDate = &end
+
+	updated, err := s.UpdateSubscription(ctx, sub)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Netflix Premium", updated.ServiceName)
+	assert.Equal(t, int64(800), *updated.Price)
+	require.NotNil(t, updated.EndDate)
+	assert.True(t, end.Equal(*updated.EndDate))
+	assert.True(t, createdAt.Equal(updated.CreatedAt))
+	assert.False(t, updated.UpdatedAt.Before(updated.CreatedAt))
+
+	got, err := s.SubscriptionByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "Netflix Premium", got.ServiceName)
+	assert.True(t, updated.UpdatedAt.Equal(got.UpdatedAt))
+}
+
+func testUpdateMissing(t *testing.T, s Storage) {
+	sub := newSubscription(uuid.New(), "Netflix", 400, month(2024, time.January), nil)
+	sub.ID = uuid.New()
+
+	_, err := s.UpdateSubscription(background(), sub)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func testUpdateConflict(t *testing.T, s Storage) {
+	ctx := background()
+	userID := uuid.New()
+
+	febEnd := month(2024, time.February)
+	_, _, err := s.SaveSubscription(ctx, newSubscription(userID, "Netflix", 400, month(2024, time.January), &febEnd))
+	require.NoError(t, err)
+
+	other := newSubscription(userID, "Netflix", 400, month(2024, time.March), nil)
+	id, _, err := s.SaveSubscription(ctx, other)
+	require.NoError(t, err)
+
+	other.ID = id
+	other.StartDate = month(2024, time.January)
+
+	_, err = s.UpdateSubscription(ctx, other)
+	assert.ErrorIs(t, err, storage.ErrSubscriptionExists)
+}
+
+func testUpdateVersion(t *testing.T, s Storage) {
+	ctx := background()
+
+	id, _, err := s.SaveSubscription(ctx, newSubscription(uuid.New(), "Netflix", 400, month(2024, time.January), nil))
+	require.NoError(t, err)
+
+	read, err := s.SubscriptionByID(ctx, id)
+	require.NoError(t, err)
+
+	first, price := read, int64(500)
+	first.Price = &price
+	updated, err := s.UpdateSubscription(ctx, first)
+	require.NoError(t, err)
+	assert.True(t, updated.UpdatedAt.After(read.UpdatedAt))
+
+	stale, err := s.SubscriptionByID(ctx, id)
+	require.NoError(t, err)
+	stalePrice := int64(600)
+	stale.UpdatedAt = read.UpdatedAt
+	stale.Price = &stalePrice
+
+	_, err = s.UpdateSubscription(ctx, stale)
+	assert.ErrorIs(t, err, storage.ErrModified)
+
+	got, err := s.SubscriptionByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), *got.Price)
+
+	missing := read
+	missing.ID = uuid.New()
+	_, err = s.UpdateSubscription(ctx, missing)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func testUpsert(t *testing.T, s Storage) {
+	ctx := background()
+	userID := uuid.New()
+
+	sub := newSubscription(userID, "Netflix", 400, month(2024, time.January), nil)
+	sub.ID = uuid.New()
+
+	created, isNew, err := s.UpsertSubscription(ctx, sub)
+	require.NoError(t, err)
+	assert.True(t, isNew)
+	assert.Equal(t, sub.ID, created.ID)
+
+	got, err := s.SubscriptionByID(ctx, sub.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(400), *got.Price)
+
+	// Every field is replaced, including ones left at their zero value, and created_at is kept.
+	end := month(2024, time.June)
+	price := int64(500)
+	sub.Price = &price
+	sub.StartDate = month(2024, time.February)
+	sub.EndDate = &end
+	sub.TrialMonths = 1
+
+	replaced, isNew, err := s.UpsertSubscription(ctx, sub)
+	require.NoError(t, err)
+	assert.False(t, isNew)
+	assert.Equal(t, int64(500), *replaced.Price)
+	assert.True(t, replaced.StartDate.Equal(month(2024, time.February)))
+	require.NotNil(t, replaced.EndDate)
+	assert.True(t, replaced.EndDate.Equal(end))
+	assert.Equal(t, 1, replaced.TrialMonths)
+	assert.True(t, replaced.CreatedAt.Equal(created.CreatedAt))
+
+	sub.TrialMonths = 0
+	replaced, _, err = s.UpsertSubscription(ctx, sub)
+	require.NoError(t, err)
+	assert.Zero(t, replaced.TrialMonths)
+
+	// Uniqueness and overlaps are still enforced against the other rows.
+	julEnd := month(2024, time.December)
+	other := newSubscription(userID, "Netflix", 400, month(2024, time.July), &julEnd)
+	other.ID = uuid.New()
+	_, _, err = s.UpsertSubscription(ctx, other)
+	require.NoError(t, err)
+
+	clash := newSubscription(userID, "Netflix", 400, month(2024, time.July), nil)
+	clash.ID = uuid.New()
+	_, _, err = s.UpsertSubscription(ctx, clash)
+	assert.ErrorIs(t, err, storage.ErrSubscriptionExists)
+
+	clash.StartDate = month(2024, time.August)
+	_, _, err = s.UpsertSubscription(ctx, clash)
+	assert.ErrorIs(t, err, storage.ErrOverlap)
+
+	_, err = s.SubscriptionByID(ctx, clash.ID)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func testByUser(t *testing.T, s Storage) {
+	ctx := background()
+	userID := uuid.New()
+
+	spotify, _, err := s.SaveSubscription(ctx, newSubscription(userID, "Spotify", 200, month(2024, time.March), nil))
+	require.NoError(t, err)
+	netflix, _, err := s.SaveSubscription(ctx, newSubscription(userID, "Netflix", 400, month(2024, time.March), nil))
+	require.NoError(t, err)
+	earliest, _, err := s.SaveSubscription(ctx, newSubscription(userID, "Yandex Plus", 300, month(2024, time.January), nil))
+	require.NoError(t, err)
+	_, _, err = s.SaveSubscription(ctx, newSubscription(uuid.New(), "Netflix", 400, month(2024, time.January), nil))
+	require.NoError(t, err)
+
+	_, err = s.PauseSubscription(ctx, models.Pause{SubscriptionID: netflix, PausedFrom: month(2024, time.May)})
+	require.NoError(t, err)
+
+	subs, err := s.SubscriptionsByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, subs, 3)
+
+	// Ordered by start date, then service.
+	assert.Equal(t, earliest, subs[0].ID)
+	assert.Equal(t, netflix, subs[1].ID)
+	assert.Equal(t, spotify, subs[2].ID)
+	assert.Len(t, subs[1].Pauses, 1)
+
+	subs, err = s.SubscriptionsByUser(ctx, uuid.New())
+	require.NoError(t, err)
+	assert.Empty(t, subs)
+}
+
+func testCancelUserSubscriptions(t *testing.T, s Storage) {
+	ctx := background()
+	userID := uuid.New()
+	cancelAt := month(2024, time.June)
+	ended := month(2024, time.February)
+
+	open, _, err := s.SaveSubscription(ctx, newSubscription(userID, "Netflix", 400, month(2024, time.January), nil))
+	require.NoError(t, err)
+	future, _, err := s.SaveSubscription(ctx, newSubscription(userID, "Spotify", 200, month(2024, time.September), nil))
+	require.NoError(t, err)
+	closed, _, err := s.SaveSubscription(ctx, newSubscription(userID, "Kion", 300, month(2024, time.January), &ended))
+	require.NoError(t, err)
+	other, _, err := s.SaveSubscription(ctx, newSubscription(uuid.New(), "Netflix", 400, month(2024, time.January), nil))
+	require.NoError(t, err)
+
+	ids, err := s.CancelUserSubscriptions(ctx, userID, cancelAt)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{open, future}, ids)
+
+	end := func(id uuid.UUID) *time.Time {
+		sub, err := s.SubscriptionByID(ctx, id)
+		require.NoError(t, err)
+		return sub.EndDate
+	}
+
+	require.NotNil(t, end(open))
+	assert.True(t, end(open).Equal(cancelAt))
+
+	// One that has not started yet ends in its first month.
+	require.NotNil(t, end(future))
+	assert.True(t, end(future).Equal(month(2024, time.September)))
+
+	require.NotNil(t, end(closed))
+	assert.True(t, end(closed).Equal(ended), "already ended subscriptions are left alone")
+	assert.Nil(t, end(other), "other users are not affected")
+
+	ids, err = s.CancelUserSubscriptions(ctx, userID, cancelAt)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func testOverlap(t *testing.T, s Storage) {
+	ctx := background()
+	userID := uuid.New()
+	junEnd := month(2024, time.June)
+
+	first, _, err := s.SaveSubscription(ctx, newSubscription(userID, "Netflix", 400, month(2024, time.January), &junEnd))
+	require.NoError(t, err)
+
+	// Periods are inclusive, so starting in the end month of another one overlaps it.
+	_, _, err = s.SaveSubscription(ctx, newSubscription(userID, "Netflix", 400, month(2024, time.June), nil))
+	require.ErrorIs(t, err, storage.ErrOverlap)
+
+	var overlap *storage.OverlapError
+	require.ErrorAs(t, err, &overlap)
+	assert.Equal(t, first, overlap.ConflictingID)
+
+	// Other users and other services are not affected.
+	_, _, err = s.SaveSubscription(ctx, newSubscription(uuid.New(), "Netflix", 400, month(2024, time.March), nil))
+	require.NoError(t, err)
+	_, _, err = s.SaveSubscription(ctx, newSubscription(userID, "Spotify", 200, month(2024, time.March), nil))
+	require.NoError(t, err)
+
+	next := newSubscription(userID, "Netflix", 400, month(2024, time.July), nil)
+	nextID, _, err := s.SaveSubscription(ctx, next)
+	require.NoError(t, err)
+
+	// A legitimate duplicate is saved when flagged and is not checked against later rows.
+	seat := newSubscription(userID, "Netflix", 400, month(2024, time.March), nil)
+	seat.AllowOverlap = true
+	seatID, _, err := s.SaveSubscription(ctx, seat)
+	require.NoError(t, err)
+
+	got, err := s.SubscriptionByID(ctx, seatID)
+	require.NoError(t, err)
+	assert.True(t, got.AllowOverlap)
+
+	// Moving a row into another period is rejected the same way.
+	next.ID = nextID
+	next.StartDate = month(2024, time.May)
+	_, err = s.UpdateSubscription(ctx, next)
+	require.ErrorAs(t, err, &overlap)
+	assert.Equal(t, first, overlap.ConflictingID)
+
+	next.AllowOverlap = true
+	updated, err := s.UpdateSubscription(ctx, next)
+	require.NoError(t, err)
+	assert.True(t, updated.AllowOverlap)
+
+	// Clearing the flag puts the row under the rule again.
+	seat.ID = seatID
+	seat.AllowOverlap = false
+	_, err = s.UpdateSubscription(ctx, seat)
+	assert.ErrorIs(t, err, storage.ErrOverlap)
+}
+
+func testCheckConstraints(t *testing.T, s Storage) {
+	ctx := background()
+	userID := uuid.New()
+	before := month(2023, time.December)
+
+	tests := []struct {
+		name   string
+		mutate func(sub *models.Subscription)
+	}{
+		{name: "end before start", mutate: func(sub *models.Subscription) { sub.EndDate = &before }},
+		{name: "negative price", mutate: func(sub *models.Subscription) { *sub.Price = -1 }},
+		{name: "blank service", mutate: func(sub *models.Subscription) { sub.ServiceName = " " }},
+		{name: "negative trial price", mutate: func(sub *models.Subscription) { sub.TrialMonths, sub.TrialPrice = 1, -1 }},
+		{name: "trial too long", mutate: func(sub *models.Subscription) { sub.TrialMonths = models.MaxTrialMonths + 1 }},
+		{name: "trial price without trial", mutate: func(sub *models.Subscription) { sub.TrialMonths, sub.TrialPrice = 0, 100 }},
+	}
+
+	for i, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				service := fmt.Sprintf("Service %d", i)
+
+				sub := newSubscription(userID, service, 400, month(2024, time.January), nil)
+				tt.mutate(&sub)
+				_, _, err := s.SaveSubscription(ctx, sub)
+				assert.ErrorIs(t, err, storage.ErrCheckViolation)
+
+				valid := newSubscription(userID, service, 400, month(2024, time.January), nil)
+				id, _, err := s.SaveSubscription(ctx, valid)
+				require.NoError(t, err)
+
+				valid.ID = id
+				tt.mutate(&valid)
+				_, err = s.UpdateSubscription(ctx, valid)
+				assert.ErrorIs(t, err, storage.ErrCheckViolation)
+			},
+		)
+	}
+
+	// A free subscription and a single-month period are valid.
+	start := month(2024, time.March)
+	free := newSubscription(userID, "Free", 0, start, &start)
+	_, _, err := s.SaveSubscription(ctx, free)
+	assert.NoError(t, err)
+}
+
+func testTransfer(t *testing.T, s Storage) {
+	ctx := background()
+	owner := uuid.New()
+	start := month(2024, time.January)
+
+	sub := newSubscription(owner, "Netflix", 400, start, nil)
+	id, _, err := s.SaveSubscription(ctx, sub)
+	require.NoError(t, err)
+	sub.ID = id
+
+	for _, m := range []time.Month{time.March, time.June, time.September} {
+		_, err := s.SavePriceChange(ctx, models.PriceChange{SubscriptionID: id, EffectiveFrom: month(2024, m), Price: 500})
+		require.NoError(t, err)
+	}
+
+	transfer := func(to uuid.UUID, from time.Time) (uuid.UUID, error) {
+		ended := sub
+		end := from.AddDate(0, -1, 0)
+		ended.EndDate = &end
+
+		return s.TransferSubscription(ctx, ended, newSubscription(to, "Netflix", 500, from, nil))
+	}
+
+	assertUnchanged := func(t *testing.T) {
+		got, err := s.SubscriptionByID(ctx, id)
+		require.NoError(t, err)
+		assert.Nil(t, got.EndDate, "a failed transfer must not end the subscription")
+
+		changes, err := s.PriceChanges(ctx, id)
+		require.NoError(t, err)
+		assert.Len(t, changes, 3)
+	}
+
+	t.Run(
+		"unique conflict", func(t *testing.T) {
+			taken := uuid.New()
+			_, _, err := s.SaveSubscription(ctx, newSubscription(taken, "Netflix", 400, month(2024, time.June), nil))
+			require.NoError(t, err)
+
+			_, err = transfer(taken, month(2024, time.June))
+			assert.ErrorIs(t, err, storage.ErrSubscriptionExists)
+			assertUnchanged(t)
+		},
+	)
+
+	t.Run(
+		"overlap", func(t *testing.T) {
+			busy := uuid.New()
+			existing, _, err := s.SaveSubscription(ctx, newSubscription(busy, "Netflix", 400, month(2024, time.May), nil))
+			require.NoError(t, err)
+
+			_, err = transfer(busy, month(2024, time.June))
+
+			var overlap *storage.OverlapError
+			require.ErrorAs(t, err, &overlap)
+			assert.Equal(t, existing, overlap.ConflictingID)
+			assertUnchanged(t)
+		},
+	)
+
+	t.Run(
+		"missing", func(t *testing.T) {
+			missing := sub
+			missing.ID = uuid.New()
+			end := month(2024, time.May)
+			missing.EndDate = &end
+
+			_, err := s.TransferSubscription(ctx, missing, newSubscription(uuid.New(), "Netflix", 500, month(2024, time.June), nil))
+			assert.ErrorIs(t, err, storage.ErrNotFound)
+		},
+	)
+
+	newOwner := uuid.New()
+	newID, err := transfer(newOwner, month(2024, time.June))
+	require.NoError(t, err)
+
+	ended, err := s.SubscriptionByID(ctx, id)
+	require.NoError(t, err)
+	require.NotNil(t, ended.EndDate)
+	assert.True(t, month(2024, time.May).Equal(*ended.EndDate))
+	assert.Equal(t, owner, ended.UserID)
+
+	continuation, err := s.SubscriptionByID(ctx, newID)
+	require.NoError(t, err)
+	assert.Equal(t, newOwner, continuation.UserID)
+	assert.True(t, month(2024, time.June).Equal(continuation.StartDate))
+	assert.Nil(t, continuation.EndDate)
+
+	// March stays behind, September moves, and June is folded into the continuation's price.
+	changes, err := s.PriceChanges(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.True(t, month(2024, time.March).Equal(changes[0].EffectiveFrom))
+
+	changes, err = s.PriceChanges(ctx, newID)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.True(t, month(2024, time.September).Equal(changes[0].EffectiveFrom))
+	assert.Equal(t, newID, changes[0].SubscriptionID)
+}
+
+func testDelete(t *testing.T, s Storage) {
+	ctx := background()
+
+	id, _, err := s.SaveSubscription(ctx, newSubscription(uuid.New(), "Netflix", 400, month(2024, time.January), nil))
+	require.NoError(t, err)
+
+	require.NoError(t, s.DeleteSubscription(ctx, id))
+
+	_, err = s.SubscriptionByID(ctx, id)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	assert.ErrorIs(t, s.DeleteSubscription(ctx, id), storage.ErrNotFound)
+}
+
+func testSum(t *testing.T, s Storage) {
+	ctx := background()
+	userID := uuid.New()
+	otherUser := uuid.New()
+	marEnd := month(2024, time.March)
+	octEnd := month(2024, time.October)
+
+	for _, sub := range []models.Subscription{
+		newSubscription(userID, "Netflix", 400, month(2024, time.January), &marEnd),
+		newSubscription(userID, "Spotify", 200, month(2024, time.May), nil),
+		newSubscription(userID, "Yandex Plus", 300, month(2024, time.July), &octEnd),
+		newSubscription(userID, "Netflix", 500, month(2025, time.January), nil),
+		newSubscription(otherUser, "Netflix", 1000, month(2024, time.February), nil),
+	} {
+		_, _, err := s.SaveSubscription(ctx, sub)
+		require.NoError(t, err)
+	}
+
+	ptr := func(t time.Time) *time.Time { return &t }
+	str := func(s string) *string { return &s }
+	uid := userID.String()
+
+	tests := []struct {
+		name   string
+		filter models.SumFilter
+		want   int64
+	}{
+		{
+			name:   "by user",
+			filter: models.SumFilter{UserID: &uid},
+			want:   1400,
+		},
+		{
+			name:   "by user and service",
+			filter: models.SumFilter{UserID: &uid, ServiceName: str("Netflix")},
+			want:   900,
+		},
+		{
+			name: "start date range is inclusive",
+			filter: models.SumFilter{
+				UserID:        &uid,
+				StartDateFrom: ptr(month(2024, time.January)),
+				StartDateTo:   ptr(month(2024, time.July)),
+			},
+			want: 900,
+		},
+		{
+			name: "end date range skips open-ended subscriptions",
+			filter: models.SumFilter{
+				UserID:      &uid,
+				EndDateFrom: ptr(month(2024, time.January)),
+				EndDateTo:   ptr(month(2024, time.December)),
+			},
+			want: 700,
+		},
+		{
+			name:   "nothing matches",
+			filter: models.SumFilter{UserID: &uid, ServiceName: str("HBO")},
+			want:   0,
+		},
+		{
+			name:   "active only leaves out ended and future subscriptions",
+			filter: models.SumFilter{UserID: &uid, AsOf: ptr(month(2024, time.August)), ActiveOnly: true},
+			want:   500,
+		},
+		{
+			name:   "active only includes the last month",
+			filter: models.SumFilter{UserID: &uid, AsOf: ptr(month(2024, time.March)), ActiveOnly: true},
+			want:   400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				total, err := s.SumSubscriptions(ctx, tt.filter)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, total)
+			},
+		)
+	}
+}
+
+func testSumByService(t *testing.T, s Storage) {
+	ctx := background()
+	userID := uuid.New()
+	marEnd := month(2024, time.March)
+
+	trial := newSubscription(userID, "Spotify", 200, month(2024, time.May), nil)
+	trial.TrialMonths = 2
+	trial.TrialPrice = 50
+
+	paused := newSubscription(userID, "Yandex Plus", 300, month(2024, time.January), nil)
+
+	for _, sub := range []models.Subscription{
+		newSubscription(userID, "Netflix", 400, month(2024, time.January), &marEnd),
+		newSubscription(userID, "Netflix", 500, month(2024, time.April), nil),
+		trial,
+		newSubscription(uuid.New(), "Netflix", 1000, month(2024, time.January), nil),
+	} {
+		_, _, err := s.SaveSubscription(ctx, sub)
+		require.NoError(t, err)
+	}
+
+	pausedID, _, err := s.SaveSubscription(ctx, paused)
+	require.NoError(t, err)
+	_, err = s.PauseSubscription(ctx, models.Pause{SubscriptionID: pausedID, PausedFrom: month(2024, time.June)})
+	require.NoError(t, err)
+
+	ptr := func(t time.Time) *time.Time { return &t }
+	uid := userID.String()
+
+	tests := []struct {
+		name string
+		asOf time.Time
+		want []models.ServiceCost
+	}{
+		{
+			name: "ended subscriptions and future ones are left out",
+			asOf: month(2024, time.February),
+			want: []models.ServiceCost{
+				{ServiceName: "Netflix", Cost: 400},
+				{ServiceName: "Yandex Plus", Cost: 300},
+			},
+		},
+		{
+			name: "trial price and paused service",
+			asOf: month(2024, time.June),
+			want: []models.ServiceCost{
+				{ServiceName: "Netflix", Cost: 500},
+				{ServiceName: "Spotify", Cost: 50},
+				{ServiceName: "Yandex Plus", Cost: 0},
+			},
+		},
+		{
+			name: "after the trial",
+			asOf: month(2024, time.July),
+			want: []models.ServiceCost{
+				{ServiceName: "Netflix", Cost: 500},
+				{ServiceName: "Spotify", Cost: 200},
+				{ServiceName: "Yandex Plus", Cost: 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				filter := models.SumFilter{UserID: &uid, AsOf: ptr(tt.asOf), ActiveOnly: true}
+
+				got, err := s.SumSubscriptionsByService(ctx, filter)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+
+				// The grouped totals agree with the per-service sums.
+				var total int64
+				for _, c := range got {
+					service := c.ServiceName
+					filter.ServiceName = &service
+
+					cost, err := s.SumSubscriptions(ctx, filter)
+					require.NoError(t, err)
+					assert.Equal(t, c.Cost, cost, service)
+					total += c.Cost
+				}
+
+				filter.ServiceName = nil
+				all, err := s.SumSubscriptions(ctx, filter)
+				require.NoError(t, err)
+				assert.Equal(t, all, total)
+			},
+		)
+	}
+}
+
+func testTrial(t *testing.T, s Storage) {
+	ctx := background()
+	userID := uuid.New()
+
+	sub := newSubscription(userID, "Netflix", 400, month(2024, time.January), nil)
+	sub.TrialMonths = 3
+
+	id, _, err := s.SaveSubscription(ctx, sub)
+	require.NoError(t, err)
+
+	got, err := s.SubscriptionByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, 3, got.TrialMonths)
+	assert.Equal(t, int64(0), got.TrialPrice)
+
+	// Cancelled in its second month, while still on the discounted trial.
+	marEnd := month(2024, time.March)
+	short := newSubscription(userID, "Spotify", 200, month(2024, time.February), &marEnd)
+	short.TrialMonths = 2
+	short.TrialPrice = 100
+
+	shortID, _, err := s.SaveSubscription(ctx, short)
+	require.NoError(t, err)
+
+	short.ID = shortID
+	short.TrialMonths = 1
+
+	updated, err := s.UpdateSubscription(ctx, short)
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated.TrialMonths)
+	assert.Equal(t, int64(100), updated.TrialPrice)
+
+	ptr := func(t time.Time) *time.Time { return &t }
+	uid := userID.String()
+
+	tests := []struct {
+		name string
+		asOf time.Time
+		want int64
+	}{
+		{name: "before start uses the first month", asOf: month(2023, time.June), want: 0 + 100},
+		{name: "inside both trials", asOf: month(2024, time.February), want: 0 + 100},
+		{name: "last trial month", asOf: month(2024, time.March), want: 0 + 200},
+		{name: "after trial, ended subscription uses its last month", asOf: month(2024, time.April), want: 400 + 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				total, err := s.SumSubscriptions(ctx, models.SumFilter{UserID: &uid, AsOf: ptr(tt.asOf)})
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, total)
+			},
+		)
+	}
+}
+
+func testTrialsEnding(t *testing.T, s Storage) {
+	ctx := background()
+	userID := uuid.New()
+	otherUser := uuid.New()
+	febEnd := month(2024, time.February)
+
+	save := func(sub models.Subscription, trialMonths int) uuid.UUID {
+		sub.TrialMonths = trialMonths
+		id, _, err := s.SaveSubscription(ctx, sub)
+		require.NoError(t, err)
+		return id
+	}
+
+	ending := save(newSubscription(userID, "Netflix", 400, month(2024, time.January), nil), 3)
+	save(newSubscription(userID, "Spotify", 200, month(2024, time.January), nil), 2)
+	save(newSubscription(userID, "Yandex Plus", 300, month(2024, time.March), nil), 0)
+	// Cancelled before the trial would have ended.
+	save(newSubscription(userID, "HBO", 500, month(2024, time.January), &febEnd), 3)
+	otherEnding := save(newSubscription(otherUser, "Netflix", 400, month(2024, time.February), nil), 2)
+
+	subs, err := s.SubscriptionsWithTrialEnding(ctx, month(2024, time.March), &userID)
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+	assert.Equal(t, ending, subs[0].ID)
+	assert.Equal(t, 3, subs[0].TrialMonths)
+
+	subs, err = s.SubscriptionsWithTrialEnding(ctx, month(2024, time.March), nil)
+	require.NoError(t, err)
+
+	var ids []uuid.UUID
+	for _, sub := range subs {
+		ids = append(ids, sub.ID)
+	}
+	assert.Contains(t, ids, ending)
+	assert.Contains(t, ids, otherEnding)
+
+	subs, err = s.SubscriptionsWithTrialEnding(ctx, month(2024, time.April), &userID)
+	require.NoError(t, err)
+	assert.Empty(t, subs)
+}
+
+func testPriceChanges(t *testing.T, s Storage) {
+	ctx := background()
+	userID := uuid.New()
+
+	sub := newSubscription(userID, "Netflix", 400, month(2024, time.January), nil)
+	sub.TrialMonths = 1
+
+	id, _, err := s.SaveSubscription(ctx, sub)
+	require.NoError(t, err)
+
+	for _, c := range []models.PriceChange{
+		{SubscriptionID: id, EffectiveFrom: month(2024, time.June), Price: 600},
+		{SubscriptionID: id, EffectiveFrom: month(2024, time.March), Price: 500},
+		// Rescheduling the same month replaces the price.
+		{SubscriptionID: id, EffectiveFrom: month(2024, time.June), Price: 650},
+	} {
+		saved, err := s.SavePriceChange(ctx, c)
+		require.NoError(t, err)
+		assert.Equal(t, c.Price, saved.Price)
+		assert.False(t, saved.CreatedAt.IsZero())
+	}
+
+	changes, err := s.PriceChanges(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.True(t, month(2024, time.March).Equal(changes[0].EffectiveFrom))
+	assert.Equal(t, int64(500), changes[0].Price)
+	assert.True(t, month(2024, time.June).Equal(changes[1].EffectiveFrom))
+	assert.Equal(t, int64(650), changes[1].Price)
+	assert.Equal(t, id, changes[1].SubscriptionID)
+
+	ptr := func(t time.Time) *time.Time { return &t }
+	uid := userID.String()
+
+	tests := []struct {
+		name string
+		asOf time.Time
+		want int64
+	}{
+		{name: "trial wins over the base price", asOf: month(2024, time.January), want: 0},
+		{name: "base price", asOf: month(2024, time.February), want: 400},
+		{name: "first change", asOf: month(2024, time.March), want: 500},
+		{name: "first change still in effect", asOf: month(2024, time.May), want: 500},
+		{name: "latest change", asOf: month(2025, time.January), want: 650},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				total, err := s.SumSubscriptions(ctx, models.SumFilter{UserID: &uid, AsOf: ptr(tt.asOf)})
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, total)
+			},
+		)
+	}
+
+	_, err = s.SavePriceChange(ctx, models.PriceChange{SubscriptionID: uuid.New(), EffectiveFrom: month(2024, time.March), Price: 1})
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	require.NoError(t, s.DeleteSubscription(ctx, id))
+
+	changes, err = s.PriceChanges(ctx, id)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func testPauses(t *testing.T, s Storage) {
+	ctx := background()
+	userID := uuid.New()
+
+	id, _, err := s.SaveSubscription(ctx, newSubscription(userID, "Netflix", 400, month(2024, time.January), nil))
+	require.NoError(t, err)
+
+	_, err = s.ResumeSubscription(ctx, id, month(2024, time.March))
+	assert.ErrorIs(t, err, storage.ErrNotPaused)
+
+	paused, err := s.PauseSubscription(ctx, models.Pause{SubscriptionID: id, PausedFrom: month(2024, time.March)})
+	require.NoError(t, err)
+	assert.True(t, month(2024, time.March).Equal(paused.PausedFrom))
+	assert.Nil(t, paused.ResumedFrom)
+
+	_, err = s.PauseSubscription(ctx, models.Pause{SubscriptionID: id, PausedFrom: month(2024, time.April)})
+	assert.ErrorIs(t, err, storage.ErrAlreadyPaused)
+
+	got, err := s.SubscriptionByID(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, got.Pauses, 1)
+	assert.NotNil(t, got.OpenPause())
+
+	resumed, err := s.ResumeSubscription(ctx, id, month(2024, time.May))
+	require.NoError(t, err)
+	require.NotNil(t, resumed.ResumedFrom)
+	assert.True(t, month(2024, time.May).Equal(*resumed.ResumedFrom))
+
+	_, err = s.PauseSubscription(ctx, models.Pause{SubscriptionID: id, PausedFrom: month(2024, time.September)})
+	require.NoError(t, err)
+
+	got, err = s.SubscriptionByID(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, got.Pauses, 2)
+	assert.True(t, month(2024, time.March).Equal(got.Pauses[0].PausedFrom))
+	require.NotNil(t, got.Pauses[0].ResumedFrom)
+	assert.True(t, month(2024, time.May).Equal(*got.Pauses[0].ResumedFrom))
+	assert.True(t, month(2024, time.September).Equal(got.Pauses[1].PausedFrom))
+	assert.Nil(t, got.Pauses[1].ResumedFrom)
+
+	// Updates keep the pause history.
+	got.ServiceName = "Netflix Premium"
+	updated, err := s.UpdateSubscription(ctx, got)
+	require.NoError(t, err)
+	assert.Len(t, updated.Pauses, 2)
+
+	ptr := func(t time.Time) *time.Time { return &t }
+	uid := userID.String()
+
+	tests := []struct {
+		name string
+		asOf time.Time
+		want int64
+	}{
+		{name: "before the pause", asOf: month(2024, time.February), want: 400},
+		{name: "first paused month", asOf: month(2024, time.March), want: 0},
+		{name: "last paused month", asOf: month(2024, time.April), want: 0},
+		{name: "resumed", asOf: month(2024, time.May), want: 400},
+		{name: "open pause", asOf: month(2025, time.January), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				total, err := s.SumSubscriptions(ctx, models.SumFilter{UserID: &uid, AsOf: ptr(tt.asOf)})
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, total)
+			},
+		)
+	}
+
+	_, err = s.PauseSubscription(ctx, models.Pause{SubscriptionID: uuid.New(), PausedFrom: month(2024, time.March)})
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	require.NoError(t, s.DeleteSubscription(ctx, id))
+
+	_, err = s.ResumeSubscription(ctx, id, month(2025, time.January))
+	assert.ErrorIs(t, err, storage.ErrNotPaused)
+}
+
+func testBudgets(t *testing.T, s BudgetStorage) {
+	ctx := background()
+	userID := uuid.New()
+	netflix := "Netflix"
+
+	perService, err := s.SaveBudget(ctx, models.Budget{UserID: userID, ServiceName: &netflix, Amount: 500})
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, perService.ID)
+	assert.False(t, perService.CreatedAt.IsZero())
+
+	overall, err := s.SaveBudget(ctx, models.Budget{UserID: userID, Amount: 1000})
+	require.NoError(t, err)
+
+	_, err = s.SaveBudget(ctx, models.Budget{UserID: userID, Amount: 2000})
+	assert.ErrorIs(t, err, storage.ErrBudgetExists, "one overall budget per user")
+
+	_, err = s.SaveBudget(ctx, models.Budget{UserID: userID, ServiceName: &netflix, Amount: 600})
+	assert.ErrorIs(t, err, storage.ErrBudgetExists, "one budget per service")
+
+	_, err = s.SaveBudget(ctx, models.Budget{UserID: uuid.New(), Amount: 1000})
+	require.NoError(t, err)
+
+	got, err := s.BudgetByID(ctx, perService.ID)
+	require.NoError(t, err)
+	assert.Equal(t, userID, got.UserID)
+	require.NotNil(t, got.ServiceName)
+	assert.Equal(t, netflix, *got.ServiceName)
+	assert.Equal(t, int64(500), got.Amount)
+
+	budgets, err := s.BudgetsByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, budgets, 2)
+	assert.Equal(t, overall.ID, budgets[0].ID, "overall budget comes first")
+	assert.Nil(t, budgets[0].ServiceName)
+	assert.Equal(t, perService.ID, budgets[1].ID)
+
+	updated, err := s.UpdateBudget(ctx, overall.ID, 1200)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1200), updated.Amount)
+	assert.Nil(t, updated.ServiceName)
+	assert.False(t, updated.UpdatedAt.Before(overall.UpdatedAt))
+
+	_, err = s.UpdateBudget(ctx, uuid.New(), 1)
+	assert.ErrorIs(t, err, storage.ErrBudgetNotFound)
+
+	marked, err := s.MarkBudgetAlert(ctx, perService.ID, month(2025, time.March))
+	require.NoError(t, err)
+	assert.True(t, marked)
+
+	marked, err = s.MarkBudgetAlert(ctx, perService.ID, month(2025, time.March))
+	require.NoError(t, err)
+	assert.False(t, marked, "a month is marked once")
+
+	marked, err = s.MarkBudgetAlert(ctx, perService.ID, month(2025, time.April))
+	require.NoError(t, err)
+	assert.True(t, marked)
+
+	marked, err = s.MarkBudgetAlert(ctx, overall.ID, month(2025, time.March))
+	require.NoError(t, err)
+	assert.True(t, marked, "marks are per budget")
+
+	require.NoError(t, s.UnmarkBudgetAlert(ctx, perService.ID, month(2025, time.March)))
+	marked, err = s.MarkBudgetAlert(ctx, perService.ID, month(2025, time.March))
+	require.NoError(t, err)
+	assert.True(t, marked, "an unmarked month can be marked again")
+
+	_, err = s.MarkBudgetAlert(ctx, uuid.New(), month(2025, time.March))
+	assert.ErrorIs(t, err, storage.ErrBudgetNotFound)
+
+	// The marks go away with the budget.
+	require.NoError(t, s.DeleteBudget(ctx, perService.ID))
+	assert.ErrorIs(t, s.DeleteBudget(ctx, perService.ID), storage.ErrBudgetNotFound)
+
+	_, err = s.BudgetByID(ctx, perService.ID)
+	assert.ErrorIs(t, err, storage.ErrBudgetNotFound)
+}
+
+func testEraseUser(t *testing.T, s PrivacyStorage) {
+	ctx := background()
+	userID, otherID := uuid.New(), uuid.New()
+
+	id, _, err := s.SaveSubscription(ctx, newSubscription(userID, "Netflix", 400, month(2025, time.January), nil))
+	require.NoError(t, err)
+	_, _, err = s.SaveSubscription(ctx, newSubscription(userID, "Spotify", 200, month(2025, time.March), nil))
+	require.NoError(t, err)
+	otherSub, _, err := s.SaveSubscription(ctx, newSubscription(otherID, "Netflix", 400, month(2025, time.January), nil))
+	require.NoError(t, err)
+
+	_, err = s.SavePriceChange(ctx, models.PriceChange{SubscriptionID: id, EffectiveFrom: month(2025, time.June), Price: 500})
+	require.NoError(t, err)
+	_, err = s.PauseSubscription(ctx, models.Pause{SubscriptionID: id, PausedFrom: month(2025, time.April)})
+	require.NoError(t, err)
+
+	erased, err := s.SaveBudget(ctx, models.Budget{UserID: userID, Amount: 1000})
+	require.NoError(t, err)
+	_, err = s.MarkBudgetAlert(ctx, erased.ID, month(2025, time.March))
+	require.NoError(t, err)
+	otherBudget, err := s.SaveBudget(ctx, models.Budget{UserID: otherID, Amount: 1000})
+	require.NoError(t, err)
+
+	first, err := s.EraseUser(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, first.Subscriptions)
+	assert.Equal(t, 1, first.Budgets)
+	assert.Equal(t, models.HashUserID(userID), first.UserHash)
+	assert.Equal(t, first.ComputeHash(), first.Hash)
+	assert.False(t, first.ErasedAt.IsZero())
+
+	_, err = s.SubscriptionByID(ctx, id)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	changes, err := s.PriceChanges(ctx, id)
+	require.NoError(t, err)
+	assert.Empty(t, changes, "price changes go with the subscription")
+
+	subs, err := s.SubscriptionsByUser(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, subs)
+
+	budgets, err := s.BudgetsByUser(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, budgets)
+
+	_, err = s.SubscriptionByID(ctx, otherSub)
+	assert.NoError(t, err, "other users are kept")
+	_, err = s.BudgetByID(ctx, otherBudget.ID)
+	assert.NoError(t, err, "other users are kept")
+
+	second, err := s.EraseUser(ctx, userID)
+	require.NoError(t, err)
+	assert.Zero(t, second.Subscriptions)
+	assert.Zero(t, second.Budgets)
+
+	chain, err := s.Erasures(ctx)
+	require.NoError(t, err)
+	require.NoError(t, models.VerifyErasures(chain), "stored records hash to the same values")
+
+	var found []int64
+	for _, e := range chain {
+		if e.UserHash == first.UserHash {
+			found = append(found, e.Seq)
+		}
+	}
+	assert.Equal(t, []int64{first.Seq, second.Seq}, found)
+}
@@ -0,0 +1,42 @@
+// Package tenant carries the tenant of a request through the context.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"regexp"
+)
+
+// Default is the tenant of single-tenant deployments and of the rows that existed
+// before tenancy was introduced.
+const Default = "default"
+
+// Header is the default request header that names the tenant, see tenancy.header.
+const Header = "X-Tenant-ID"
+
+// ErrInvalid is returned for a tenant id that does not match the allowed format.
+var ErrInvalid = errors.New("tenant id must be 1-64 letters, digits, '.', '_' or '-', starting with a letter or digit")
+
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+type ctxKey struct{}
+
+// Validate checks that id may be used as a tenant id.
+func Validate(id string) error {
+	if !idPattern.MatchString(id) {
+		return ErrInvalid
+	}
+
+	return nil
+}
+
+// WithID returns a context that carries the tenant id.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the tenant id carried by ctx, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(ctxKey{}).(string)
+	return id, ok && id != ""
+}
@@ -0,0 +1,51 @@
+package tenant_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salivare/subscriptions-service/internal/tenant"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		wantErr bool
+	}{
+		{name: "default", id: tenant.Default},
+		{name: "punctuation", id: "retail.eu-1_b"},
+		{name: "64 chars", id: strings.Repeat("a", 64)},
+		{name: "empty", id: "", wantErr: true},
+		{name: "65 chars", id: strings.Repeat("a", 65), wantErr: true},
+		{name: "leading dash", id: "-retail", wantErr: true},
+		{name: "space", id: "re tail", wantErr: true},
+		{name: "quote", id: "retail'", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tenant.Validate(tt.id)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tenant.ErrInvalid)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	_, ok := tenant.FromContext(context.Background())
+	assert.False(t, ok)
+
+	_, ok = tenant.FromContext(tenant.WithID(context.Background(), ""))
+	assert.False(t, ok)
+
+	id, ok := tenant.FromContext(tenant.WithID(context.Background(), "retail"))
+	assert.True(t, ok)
+	assert.Equal(t, "retail", id)
+}
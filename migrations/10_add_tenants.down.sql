@@ -0,0 +1,52 @@
+-- Fails if two tenants hold rows that the global constraints would reject together.
+DROP POLICY IF EXISTS tenant_isolation ON erasures;
+DROP POLICY IF EXISTS tenant_isolation ON budgets;
+DROP POLICY IF EXISTS tenant_isolation ON subscription_pauses;
+DROP POLICY IF EXISTS tenant_isolation ON subscription_prices;
+DROP POLICY IF EXISTS tenant_isolation ON subscriptions;
+
+ALTER TABLE erasures NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE budgets NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE subscription_pauses NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE subscription_prices NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+ALTER TABLE subscriptions NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY;
+
+ALTER TABLE erasures
+    DROP CONSTRAINT erasures_pkey,
+    ADD PRIMARY KEY (seq);
+
+DROP INDEX IF EXISTS budgets_unique_user_service;
+CREATE UNIQUE INDEX budgets_unique_user_service
+    ON budgets (user_id, COALESCE(service_name, ''));
+
+ALTER TABLE subscriptions
+    DROP CONSTRAINT subscriptions_no_overlap,
+    ADD CONSTRAINT subscriptions_no_overlap
+    EXCLUDE USING gist (
+        user_id WITH =,
+        service_name WITH =,
+        daterange(start_date, end_date, '[]') WITH &&
+    ) WHERE (NOT allow_overlap);
+
+DROP INDEX IF EXISTS subscriptions_unique_user_service_start;
+CREATE UNIQUE INDEX subscriptions_unique_user_service_start
+    ON subscriptions (user_id, service_name, start_date);
+
+ALTER TABLE subscription_pauses
+    DROP CONSTRAINT subscription_pauses_subscription_id_fkey,
+    ADD CONSTRAINT subscription_pauses_subscription_id_fkey
+        FOREIGN KEY (subscription_id) REFERENCES subscriptions (id) ON DELETE CASCADE;
+
+ALTER TABLE subscription_prices
+    DROP CONSTRAINT subscription_prices_subscription_id_fkey,
+    ADD CONSTRAINT subscription_prices_subscription_id_fkey
+        FOREIGN KEY (subscription_id) REFERENCES subscriptions (id) ON DELETE CASCADE;
+
+ALTER TABLE subscriptions
+    DROP CONSTRAINT subscriptions_tenant_id_id;
+
+ALTER TABLE erasures DROP COLUMN tenant_id;
+ALTER TABLE budgets DROP COLUMN tenant_id;
+ALTER TABLE subscription_pauses DROP COLUMN tenant_id;
+ALTER TABLE subscription_prices DROP COLUMN tenant_id;
+ALTER TABLE subscriptions DROP COLUMN tenant_id;
@@ -0,0 +1,90 @@
+-- Every row belongs to a tenant. The storage sets app.tenant_id for each transaction
+-- (see postgres.inTenant) and the policies below hide the rows of every other tenant,
+-- so a query that forgets to filter by tenant still cannot reach them.
+-- Every table added later needs the same tenant_id column, policy and FORCE.
+-- Superusers and roles with BYPASSRLS ignore the policies: the service must connect
+-- as a role without either.
+
+-- Existing rows go to the default tenant; new ones get the tenant of the transaction,
+-- and an insert without one fails instead of landing in some tenant.
+ALTER TABLE subscriptions ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE subscription_prices ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE subscription_pauses ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE budgets ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE erasures ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+
+ALTER TABLE subscriptions ALTER COLUMN tenant_id SET DEFAULT current_setting('app.tenant_id');
+ALTER TABLE subscription_prices ALTER COLUMN tenant_id SET DEFAULT current_setting('app.tenant_id');
+ALTER TABLE subscription_pauses ALTER COLUMN tenant_id SET DEFAULT current_setting('app.tenant_id');
+ALTER TABLE budgets ALTER COLUMN tenant_id SET DEFAULT current_setting('app.tenant_id');
+ALTER TABLE erasures ALTER COLUMN tenant_id SET DEFAULT current_setting('app.tenant_id');
+
+-- Foreign keys are checked regardless of the policies, so price changes and pauses
+-- reference the subscription together with its tenant.
+ALTER TABLE subscriptions
+    ADD CONSTRAINT subscriptions_tenant_id_id UNIQUE (tenant_id, id);
+
+ALTER TABLE subscription_prices
+    DROP CONSTRAINT subscription_prices_subscription_id_fkey,
+    ADD CONSTRAINT subscription_prices_subscription_id_fkey
+        FOREIGN KEY (tenant_id, subscription_id) REFERENCES subscriptions (tenant_id, id) ON DELETE CASCADE;
+
+ALTER TABLE subscription_pauses
+    DROP CONSTRAINT subscription_pauses_subscription_id_fkey,
+    ADD CONSTRAINT subscription_pauses_subscription_id_fkey
+        FOREIGN KEY (tenant_id, subscription_id) REFERENCES subscriptions (tenant_id, id) ON DELETE CASCADE;
+
+-- Uniqueness and overlap are per tenant.
+DROP INDEX subscriptions_unique_user_service_start;
+CREATE UNIQUE INDEX subscriptions_unique_user_service_start
+    ON subscriptions (tenant_id, user_id, service_name, start_date);
+
+ALTER TABLE subscriptions
+    DROP CONSTRAINT subscriptions_no_overlap,
+    ADD CONSTRAINT subscriptions_no_overlap
+    EXCLUDE USING gist (
+        tenant_id WITH =,
+        user_id WITH =,
+        service_name WITH =,
+        daterange(start_date, end_date, '[]') WITH &&
+    ) WHERE (NOT allow_overlap);
+
+DROP INDEX budgets_unique_user_service;
+CREATE UNIQUE INDEX budgets_unique_user_service
+    ON budgets (tenant_id, user_id, COALESCE(service_name, ''));
+
+-- Every tenant has its own erasure chain.
+ALTER TABLE erasures
+    DROP CONSTRAINT erasures_pkey,
+    ADD PRIMARY KEY (tenant_id, seq);
+
+-- current_setting(..., true) is NULL or empty without a tenant, which matches no row.
+ALTER TABLE subscriptions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE subscriptions FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON subscriptions
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+ALTER TABLE subscription_prices ENABLE ROW LEVEL SECURITY;
+ALTER TABLE subscription_prices FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON subscription_prices
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+ALTER TABLE subscription_pauses ENABLE ROW LEVEL SECURITY;
+ALTER TABLE subscription_pauses FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON subscription_pauses
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+ALTER TABLE budgets ENABLE ROW LEVEL SECURITY;
+ALTER TABLE budgets FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON budgets
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+ALTER TABLE erasures ENABLE ROW LEVEL SECURITY;
+ALTER TABLE erasures FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON erasures
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
@@ -0,0 +1,2 @@
+ALTER TABLE subscriptions
+    DROP CONSTRAINT IF EXISTS subscriptions_check_trial_price;
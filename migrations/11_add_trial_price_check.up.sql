@@ -0,0 +1,18 @@
+-- Mirrors the trial_price rule of models.Subscription.Validate: a trial price only makes
+-- sense with trial months. Added NOT VALID like the checks of migration 8, so it applies to
+-- new and updated rows at once and old rows are checked by VALIDATE below.
+ALTER TABLE subscriptions
+    ADD CONSTRAINT subscriptions_check_trial_price CHECK (trial_months > 0 OR trial_price = 0) NOT VALID;
+
+-- Such rows could be written through PATCH before the rule. Their trial price never applied,
+-- so it is dropped. The policies of migration 10 hide every row from a migration, which runs
+-- without app.tenant_id, so FORCE is lifted for the repair and the owner sees all tenants.
+ALTER TABLE subscriptions NO FORCE ROW LEVEL SECURITY;
+
+UPDATE subscriptions
+SET trial_price = 0
+WHERE trial_months = 0 AND trial_price <> 0;
+
+ALTER TABLE subscriptions FORCE ROW LEVEL SECURITY;
+
+ALTER TABLE subscriptions VALIDATE CONSTRAINT subscriptions_check_trial_price;
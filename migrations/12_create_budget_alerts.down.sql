@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS budget_alerts;
+
+ALTER TABLE budgets
+    DROP CONSTRAINT IF EXISTS budgets_tenant_id_id;
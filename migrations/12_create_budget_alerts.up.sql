@@ -0,0 +1,27 @@
+-- Exceeded budget months already published on budget_exceeded. The row is inserted before
+-- publishing and only the instance whose insert went through publishes, so every budget
+-- and month raises one event however many instances evaluate it.
+CREATE TABLE IF NOT EXISTS budget_alerts (
+       tenant_id TEXT NOT NULL DEFAULT current_setting('app.tenant_id'),
+       budget_id UUID NOT NULL,
+       month DATE NOT NULL,
+
+       created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+       PRIMARY KEY (budget_id, month)
+);
+
+-- Like the price changes and pauses of migration 10, an alert references its budget
+-- together with the tenant, and goes away with it.
+ALTER TABLE budgets
+    ADD CONSTRAINT budgets_tenant_id_id UNIQUE (tenant_id, id);
+
+ALTER TABLE budget_alerts
+    ADD CONSTRAINT budget_alerts_budget_id_fkey
+        FOREIGN KEY (tenant_id, budget_id) REFERENCES budgets (tenant_id, id) ON DELETE CASCADE;
+
+ALTER TABLE budget_alerts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE budget_alerts FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON budget_alerts
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
@@ -0,0 +1,3 @@
+ALTER TABLE subscriptions
+    DROP COLUMN IF EXISTS trial_price,
+    DROP COLUMN IF EXISTS trial_months;
@@ -0,0 +1,3 @@
+ALTER TABLE subscriptions
+    ADD COLUMN trial_months INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN trial_price INTEGER NOT NULL DEFAULT 0;
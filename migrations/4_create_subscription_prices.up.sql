@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS subscription_prices (
+       subscription_id UUID NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+       effective_from DATE NOT NULL,
+       price INTEGER NOT NULL,
+
+       created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+       PRIMARY KEY (subscription_id, effective_from)
+);
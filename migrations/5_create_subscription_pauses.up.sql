@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS subscription_pauses (
+       id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+       subscription_id UUID NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+       paused_from DATE NOT NULL,
+       resumed_from DATE, -- first month billed again, NULL while paused
+
+       created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS subscription_pauses_subscription
+    ON subscription_pauses (subscription_id, paused_from);
+
+-- At most one pause per subscription can be open.
+CREATE UNIQUE INDEX IF NOT EXISTS subscription_pauses_one_open
+    ON subscription_pauses (subscription_id)
+    WHERE resumed_from IS NULL;
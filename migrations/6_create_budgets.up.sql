@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS budgets (
+       id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+       user_id UUID NOT NULL,
+       service_name TEXT, -- NULL for the overall budget of the user
+       amount INTEGER NOT NULL,
+
+       created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+       updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One overall budget and one budget per service for every user.
+CREATE UNIQUE INDEX IF NOT EXISTS budgets_unique_user_service
+    ON budgets (user_id, COALESCE(service_name, ''));
@@ -0,0 +1,26 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Subscriptions flagged with allow_overlap are legitimate duplicates and are not checked.
+ALTER TABLE subscriptions
+    ADD COLUMN allow_overlap BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Existing rows that overlap an earlier one are kept as flagged duplicates,
+-- otherwise the constraint below could not be added.
+UPDATE subscriptions s
+SET allow_overlap = TRUE
+WHERE EXISTS (
+    SELECT 1
+    FROM subscriptions o
+    WHERE o.user_id = s.user_id
+      AND o.service_name = s.service_name
+      AND (o.start_date, o.id) < (s.start_date, s.id)
+      AND daterange(o.start_date, o.end_date, '[]') && daterange(s.start_date, s.end_date, '[]')
+);
+
+ALTER TABLE subscriptions
+    ADD CONSTRAINT subscriptions_no_overlap
+    EXCLUDE USING gist (
+        user_id WITH =,
+        service_name WITH =,
+        daterange(start_date, end_date, '[]') WITH &&
+    ) WHERE (NOT allow_overlap);
@@ -0,0 +1,5 @@
+ALTER TABLE subscriptions
+    DROP CONSTRAINT IF EXISTS subscriptions_check_trial,
+    DROP CONSTRAINT IF EXISTS subscriptions_check_period,
+    DROP CONSTRAINT IF EXISTS subscriptions_check_price,
+    DROP CONSTRAINT IF EXISTS subscriptions_check_service_name;
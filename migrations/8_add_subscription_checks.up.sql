@@ -0,0 +1,26 @@
+-- Mirrors models.Subscription.Validate, so invalid rows are rejected whichever path writes them.
+-- Rows written before these rules may break them, so the constraints are added NOT VALID:
+-- they apply to new and updated rows at once, and old rows are checked by VALIDATE below.
+ALTER TABLE subscriptions
+    ADD CONSTRAINT subscriptions_check_service_name CHECK (btrim(service_name) <> '') NOT VALID,
+    ADD CONSTRAINT subscriptions_check_price CHECK (price >= 0) NOT VALID,
+    ADD CONSTRAINT subscriptions_check_period CHECK (end_date IS NULL OR end_date >= start_date) NOT VALID,
+    ADD CONSTRAINT subscriptions_check_trial CHECK (trial_months BETWEEN 0 AND 120 AND trial_price >= 0) NOT VALID;
+
+-- An empty end_date on PATCH could end a subscription before it started. It ends in its
+-- first month instead, the earliest end the rule allows.
+UPDATE subscriptions
+SET end_date = start_date
+WHERE end_date < start_date;
+
+-- Prices and trials were validated on every write, so no old row breaks these.
+ALTER TABLE subscriptions VALIDATE CONSTRAINT subscriptions_check_price;
+ALTER TABLE subscriptions VALIDATE CONSTRAINT subscriptions_check_period;
+ALTER TABLE subscriptions VALIDATE CONSTRAINT subscriptions_check_trial;
+
+-- A blank service name cannot be repaired without knowing the service, so this one is
+-- left NOT VALID and still applies to every new or updated row. Fix old rows by hand:
+--
+--   SELECT id, user_id, start_date FROM subscriptions WHERE btrim(service_name) = '';
+--   UPDATE subscriptions SET service_name = '<name>' WHERE id = '<id>';
+--   ALTER TABLE subscriptions VALIDATE CONSTRAINT subscriptions_check_service_name;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS erasures;
+
+DROP FUNCTION IF EXISTS erasures_append_only();
@@ -0,0 +1,26 @@
+-- Proof that the rows of a user were erased on request. Only a hash of the user id is kept,
+-- and every record hashes the previous one (see models.Erasure), so tampering is evident.
+CREATE TABLE IF NOT EXISTS erasures (
+       seq BIGINT PRIMARY KEY,
+
+       user_hash TEXT NOT NULL,
+       subscriptions INTEGER NOT NULL,
+       budgets INTEGER NOT NULL,
+       erased_at TIMESTAMPTZ NOT NULL,
+
+       prev_hash TEXT NOT NULL,
+       hash TEXT NOT NULL UNIQUE
+);
+
+CREATE INDEX IF NOT EXISTS erasures_user_hash ON erasures (user_hash);
+
+-- The log is append-only.
+CREATE OR REPLACE FUNCTION erasures_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'erasures are append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER erasures_append_only
+    BEFORE UPDATE OR DELETE ON erasures
+    FOR EACH ROW EXECUTE FUNCTION erasures_append_only();
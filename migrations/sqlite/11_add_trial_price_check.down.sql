@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS subscriptions_check_trial_price_update;
+DROP TRIGGER IF EXISTS subscriptions_check_trial_price_insert;
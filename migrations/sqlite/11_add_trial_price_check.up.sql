@@ -0,0 +1,17 @@
+-- Mirrors the trial_price rule of models.Subscription.Validate, see the Postgres migration.
+-- Rows written before the rule are repaired the same way: their trial price never applied.
+UPDATE subscriptions
+SET trial_price = 0
+WHERE trial_months = 0 AND trial_price <> 0;
+
+CREATE TRIGGER IF NOT EXISTS subscriptions_check_trial_price_insert
+    BEFORE INSERT ON subscriptions
+BEGIN
+    SELECT RAISE(ABORT, 'subscriptions_check_trial_price') WHERE NEW.trial_months <= 0 AND NEW.trial_price <> 0;
+END;
+
+CREATE TRIGGER IF NOT EXISTS subscriptions_check_trial_price_update
+    BEFORE UPDATE ON subscriptions
+BEGIN
+    SELECT RAISE(ABORT, 'subscriptions_check_trial_price') WHERE NEW.trial_months <= 0 AND NEW.trial_price <> 0;
+END;
@@ -0,0 +1,10 @@
+-- Exceeded budget months already reported. The row is inserted before reporting and only
+-- the insert that went through reports, so every budget and month is reported once.
+CREATE TABLE IF NOT EXISTS budget_alerts (
+       budget_id TEXT NOT NULL REFERENCES budgets (id) ON DELETE CASCADE,
+       month TEXT NOT NULL, -- YYYY-MM-DD
+
+       created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+
+       PRIMARY KEY (budget_id, month)
+);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS subscriptions (
+       id TEXT PRIMARY KEY,
+
+       service_name TEXT NOT NULL,
+       price INTEGER NOT NULL,
+       user_id TEXT NOT NULL,
+       start_date TEXT NOT NULL, -- YYYY-MM-DD
+       end_date TEXT,            -- YYYY-MM-DD
+
+       created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+       updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+);
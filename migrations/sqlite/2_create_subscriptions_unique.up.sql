@@ -0,0 +1,2 @@
+CREATE UNIQUE INDEX IF NOT EXISTS subscriptions_unique_user_service_start
+    ON subscriptions (user_id, service_name, start_date);
@@ -0,0 +1,2 @@
+ALTER TABLE subscriptions DROP COLUMN trial_price;
+ALTER TABLE subscriptions DROP COLUMN trial_months;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS subscription_prices (
+       subscription_id TEXT NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+       effective_from TEXT NOT NULL, -- YYYY-MM-DD
+       price INTEGER NOT NULL,
+
+       created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+
+       PRIMARY KEY (subscription_id, effective_from)
+);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS subscription_pauses (
+       id TEXT PRIMARY KEY,
+
+       subscription_id TEXT NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+       paused_from TEXT NOT NULL,  -- YYYY-MM-DD
+       resumed_from TEXT,          -- first month billed again, NULL while paused
+
+       created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS subscription_pauses_subscription
+    ON subscription_pauses (subscription_id, paused_from);
+
+-- At most one pause per subscription can be open.
+CREATE UNIQUE INDEX IF NOT EXISTS subscription_pauses_one_open
+    ON subscription_pauses (subscription_id)
+    WHERE resumed_from IS NULL;
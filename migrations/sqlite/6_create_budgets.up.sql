@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS budgets (
+       id TEXT PRIMARY KEY,
+
+       user_id TEXT NOT NULL,
+       service_name TEXT, -- NULL for the overall budget of the user
+       amount INTEGER NOT NULL,
+
+       created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+       updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+);
+
+-- One overall budget and one budget per service for every user.
+CREATE UNIQUE INDEX IF NOT EXISTS budgets_unique_user_service
+    ON budgets (user_id, COALESCE(service_name, ''));
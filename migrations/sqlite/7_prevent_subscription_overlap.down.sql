@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS subscriptions_no_overlap_update;
+DROP TRIGGER IF EXISTS subscriptions_no_overlap_insert;
+
+ALTER TABLE subscriptions DROP COLUMN allow_overlap;
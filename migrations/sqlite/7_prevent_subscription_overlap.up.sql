@@ -0,0 +1,53 @@
+-- Subscriptions flagged with allow_overlap are legitimate duplicates and are not checked.
+ALTER TABLE subscriptions ADD COLUMN allow_overlap INTEGER NOT NULL DEFAULT 0;
+
+-- Existing rows that overlap an earlier one are kept as flagged duplicates.
+UPDATE subscriptions
+SET allow_overlap = 1
+WHERE EXISTS (
+    SELECT 1
+    FROM subscriptions o
+    WHERE o.user_id = subscriptions.user_id
+      AND o.service_name = subscriptions.service_name
+      AND (o.start_date < subscriptions.start_date
+           OR (o.start_date = subscriptions.start_date AND o.id < subscriptions.id))
+      AND o.start_date <= COALESCE(subscriptions.end_date, '9999-12-31')
+      AND subscriptions.start_date <= COALESCE(o.end_date, '9999-12-31')
+);
+
+-- SQLite has no exclusion constraints, so the rule is enforced by triggers.
+-- Rows with the same start date are left to subscriptions_unique_user_service_start.
+CREATE TRIGGER IF NOT EXISTS subscriptions_no_overlap_insert
+    BEFORE INSERT ON subscriptions
+    WHEN NOT NEW.allow_overlap
+BEGIN
+    SELECT RAISE(ABORT, 'subscriptions_no_overlap')
+    WHERE EXISTS (
+        SELECT 1
+        FROM subscriptions o
+        WHERE o.user_id = NEW.user_id
+          AND o.service_name = NEW.service_name
+          AND NOT o.allow_overlap
+          AND o.start_date <> NEW.start_date
+          AND o.start_date <= COALESCE(NEW.end_date, '9999-12-31')
+          AND NEW.start_date <= COALESCE(o.end_date, '9999-12-31')
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS subscriptions_no_overlap_update
+    BEFORE UPDATE ON subscriptions
+    WHEN NOT NEW.allow_overlap
+BEGIN
+    SELECT RAISE(ABORT, 'subscriptions_no_overlap')
+    WHERE EXISTS (
+        SELECT 1
+        FROM subscriptions o
+        WHERE o.id <> NEW.id
+          AND o.user_id = NEW.user_id
+          AND o.service_name = NEW.service_name
+          AND NOT o.allow_overlap
+          AND o.start_date <> NEW.start_date
+          AND o.start_date <= COALESCE(NEW.end_date, '9999-12-31')
+          AND NEW.start_date <= COALESCE(o.end_date, '9999-12-31')
+    );
+END;
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS subscriptions_check_update;
+DROP TRIGGER IF EXISTS subscriptions_check_insert;
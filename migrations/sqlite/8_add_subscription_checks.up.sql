@@ -0,0 +1,27 @@
+-- Mirrors models.Subscription.Validate, so invalid rows are rejected whichever path writes them.
+-- SQLite cannot add CHECK constraints to an existing table, so triggers raise errors named after
+-- the Postgres constraints instead.
+
+-- Rows written before these rules are repaired like in Postgres, so that they can still be
+-- updated. Blank service names are left to be fixed by hand, see the Postgres migration.
+UPDATE subscriptions
+SET end_date = start_date
+WHERE end_date < start_date;
+
+CREATE TRIGGER IF NOT EXISTS subscriptions_check_insert
+    BEFORE INSERT ON subscriptions
+BEGIN
+    SELECT RAISE(ABORT, 'subscriptions_check_service_name') WHERE trim(NEW.service_name) = '';
+    SELECT RAISE(ABORT, 'subscriptions_check_price') WHERE NEW.price < 0;
+    SELECT RAISE(ABORT, 'subscriptions_check_period') WHERE NEW.end_date IS NOT NULL AND NEW.end_date < NEW.start_date;
+    SELECT RAISE(ABORT, 'subscriptions_check_trial') WHERE NEW.trial_months NOT BETWEEN 0 AND 120 OR NEW.trial_price < 0;
+END;
+
+CREATE TRIGGER IF NOT EXISTS subscriptions_check_update
+    BEFORE UPDATE ON subscriptions
+BEGIN
+    SELECT RAISE(ABORT, 'subscriptions_check_service_name') WHERE trim(NEW.service_name) = '';
+    SELECT RAISE(ABORT, 'subscriptions_check_price') WHERE NEW.price < 0;
+    SELECT RAISE(ABORT, 'subscriptions_check_period') WHERE NEW.end_date IS NOT NULL AND NEW.end_date < NEW.start_date;
+    SELECT RAISE(ABORT, 'subscriptions_check_trial') WHERE NEW.trial_months NOT BETWEEN 0 AND 120 OR NEW.trial_price < 0;
+END;
@@ -0,0 +1,28 @@
+-- Proof that the rows of a user were erased on request. Only a hash of the user id is kept,
+-- and every record hashes the previous one (see models.Erasure), so tampering is evident.
+CREATE TABLE IF NOT EXISTS erasures (
+       seq INTEGER PRIMARY KEY,
+
+       user_hash TEXT NOT NULL,
+       subscriptions INTEGER NOT NULL,
+       budgets INTEGER NOT NULL,
+       erased_at TEXT NOT NULL,
+
+       prev_hash TEXT NOT NULL,
+       hash TEXT NOT NULL UNIQUE
+);
+
+CREATE INDEX IF NOT EXISTS erasures_user_hash ON erasures (user_hash);
+
+-- The log is append-only.
+CREATE TRIGGER IF NOT EXISTS erasures_no_update
+    BEFORE UPDATE ON erasures
+BEGIN
+    SELECT RAISE(ABORT, 'erasures are append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS erasures_no_delete
+    BEFORE DELETE ON erasures
+BEGIN
+    SELECT RAISE(ABORT, 'erasures are append-only');
+END;
@@ -70,3 +70,23 @@ func TestGetSubscription_NotFound(t *testing.T) {
 
 	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 }
+
+func TestGetSubscription_NotFoundProblem(t *testing.T) {
+	_, st := suite.New(t)
+
+	resp, err := st.Client.Get(
+		st.URL("/api/v1/subscription/" + uuid.New().String()),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+
+	var problem suite.ProblemResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+
+	assert.Equal(t, "SUBSCRIPTION_NOT_FOUND", problem.Code)
+	assert.Equal(t, http.StatusNotFound, problem.Status)
+	assert.Equal(t, resp.Header.Get("X-Request-ID"), problem.RequestID)
+}
@@ -0,0 +1,60 @@
+package subscription_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/salivare/subscriptions-service/tests/suite"
+)
+
+func TestReplaceSubscription_CreatesThenReplaces(t *testing.T) {
+	_, st := suite.New(t)
+
+	id := uuid.New().String()
+	userID := uuid.New().String()
+	service := gofakeit.AppName()
+
+	put := func(price int) int {
+		body := fmt.Sprintf(
+			`{"service_name": %q, "price": %d, "user_id": %q, "start_date": %q}`,
+			service, price, userID, suite.RandomMonth(),
+		)
+
+		req, err := http.NewRequest(http.MethodPut, st.URL("/api/v1/subscription/"+id), bytes.NewBufferString(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := st.Client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusCreated, put(300))
+	assert.Equal(t, http.StatusOK, put(500))
+}
+
+func TestReplaceSubscription_InvalidUUID(t *testing.T) {
+	_, st := suite.New(t)
+
+	req, err := http.NewRequest(
+		http.MethodPut,
+		st.URL("/api/v1/subscription/not-a-uuid"),
+		bytes.NewBufferString(`{}`),
+	)
+	require.NoError(t, err)
+
+	resp, err := st.Client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -152,3 +152,36 @@ func TestSaveSubscription_MissingFields(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 }
+
+func TestSaveSubscription_ValidationProblem(t *testing.T) {
+	_, st := suite.New(t)
+
+	body := `{
+        "service_name": "Netflix",
+        "price": -10,
+        "start_date": "01-2024"
+    }`
+
+	resp, err := st.Client.Post(
+		st.URL("/api/v1/subscription"),
+		"application/json",
+		bytes.NewBufferString(body),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var problem suite.ProblemResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+
+	assert.Equal(t, "VALIDATION_FAILED", problem.Code)
+	assert.NotEmpty(t, problem.RequestID)
+
+	rules := map[string]string{}
+	for _, e := range problem.Errors {
+		rules[e.Field] = e.Rule
+	}
+	assert.Equal(t, "min", rules["price"])
+	assert.Equal(t, "required", rules["user_id"])
+}
@@ -52,3 +52,16 @@ func RandomMonth() string {
 	year := 2024
 	return fmt.Sprintf("%02d-%d", month, year)
 }
+
+type ProblemResponse struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail"`
+	Code      string `json:"code"`
+	RequestID string `json:"request_id"`
+	Errors    []struct {
+		Field string `json:"field"`
+		Rule  string `json:"rule"`
+	} `json:"errors"`
+}
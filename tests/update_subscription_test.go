@@ -19,16 +19,15 @@ func TestUpdateSubscription_HappyPath(t *testing.T) {
 
 	subID := st.CreateSubscription(t)
 
+	// Ownership is changed by a transfer, not by an update.
 	updateReq := fmt.Sprintf(
 		`{
             "service_name": "%s",
             "price": %d,
-            "user_id": "%s",
             "start_date": "%s"
         }`,
 		gofakeit.AppName(),
 		int64(gofakeit.Number(100, 1000)),
-		uuid.New().String(),
 		suite.RandomMonth(),
 	)
 